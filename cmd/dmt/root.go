@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -32,12 +33,15 @@ import (
 	"github.com/deckhouse/deckhouse/pkg/log"
 
 	"github.com/deckhouse/dmt/internal/bootstrap"
+	"github.com/deckhouse/dmt/internal/fixtures"
 	"github.com/deckhouse/dmt/internal/flags"
 	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/inventory"
 	"github.com/deckhouse/dmt/internal/rendercmd"
 	"github.com/deckhouse/dmt/internal/test"
 	"github.com/deckhouse/dmt/internal/version"
 	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/testers/hooks"
 )
 
 var kebabCaseRegex = regexp.MustCompile(`^([a-z][a-z0-9]*)(-[a-z0-9]+)*$`)
@@ -155,6 +159,7 @@ func execute() {
 		Short: "Tests for Deckhouse modules",
 		Long:  `Run tests on module conversions and other components`,
 	}
+	testCmd.PersistentFlags().AddFlagSet(flags.InitTestFlagSet())
 
 	conversionsCmd := &cobra.Command{
 		Use:          "conversions [module-path]",
@@ -197,10 +202,96 @@ committed 'expected.yaml' snapshot. Use --update to (re)generate snapshots.`,
 	templatesCmd.Flags().BoolVar(&updateSnapshots, "update", false,
 		"update (regenerate) golden snapshots instead of comparing against them")
 
+	prometheusRulesCmd := &cobra.Command{
+		Use:   "prometheus-rules [module-path]",
+		Short: "Run unit tests for module Prometheus rules",
+		Long: `Renders the rules under 'monitoring/prometheus-rules' and evaluates them
+against the promtool-style '*-tests.yaml' unit tests next to them.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var dir = "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			return runTests(dir, test.WithTesters("prometheus-rules"))
+		},
+	}
+
+	var hooksTimeout time.Duration
+
+	hooksCmd := &cobra.Command{
+		Use:   "hooks [module-path]",
+		Short: "Run the go tests of module Go hooks",
+		Long: `Runs 'go test ./...' for the module-sdk based Go hooks of each module
+(every go.mod under 'hooks/' that requires module-sdk) and reports the failed tests.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var dir = "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			return runTests(dir,
+				test.WithTesters("hooks"),
+				test.WithHooksTimeout(hooksTimeout),
+			)
+		},
+	}
+	hooksCmd.Flags().DurationVar(&hooksTimeout, "timeout", hooks.DefaultTimeout,
+		"timeout of a single go test run")
+
+	openapiValidationsCmd := &cobra.Command{
+		Use:   "openapi-validations [module-path]",
+		Short: "Evaluate module x-deckhouse-validations rules against testcases",
+		Long: `Evaluates the x-deckhouse-validations CEL rules of 'openapi/config-values.yaml'
+against the testcases in 'openapi/validations-tests.yaml', binding self and oldSelf
+the way deckhouse-controller does.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var dir = "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			return runTests(dir, test.WithTesters("openapi-validations"))
+		},
+	}
+
+	openapiExamplesCmd := &cobra.Command{
+		Use:   "openapi-examples [module-path]",
+		Short: "Validate module openapi schema examples and render the module with them",
+		Long: `Validates every x-examples and x-example value of 'openapi/config-values.yaml'
+and 'openapi/values.yaml' against the schema it documents, and renders the module
+with each valid top-level config-values example completed with the schema defaults.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var dir = "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			return runTests(dir, test.WithTesters("openapi-examples"))
+		},
+	}
+
 	testCmd.AddCommand(conversionsCmd)
 	testCmd.AddCommand(templatesCmd)
-
-	var renderOutput string
+	testCmd.AddCommand(prometheusRulesCmd)
+	testCmd.AddCommand(hooksCmd)
+	testCmd.AddCommand(openapiValidationsCmd)
+	testCmd.AddCommand(openapiExamplesCmd)
+
+	var (
+		renderOutput   string
+		coverageReport bool
+		coverageFormat string
+		coverageOutput string
+	)
 
 	renderCmd := &cobra.Command{
 		Use:   "render [module-path]",
@@ -215,7 +306,13 @@ root. When --output is set, the output is instead written into
 '<output>/rendered/<module-name>/<edition>/', where the module name is taken
 from the module's 'module.yaml' and editions follow the
 'openapi/values_<edition>.yaml' convention (with 'default' for
-'openapi/values.yaml').`,
+'openapi/values.yaml').
+
+With --coverage nothing is written to the modules; instead dmt reports which
+template lines, if/with/range branches and defines are exercised by the
+module's values scenarios (openapi/values.yaml, every
+openapi/values_<edition>.yaml and every templates-tests case), as text, JSON
+or Cobertura XML.`,
 		Args:         cobra.RangeArgs(0, 1),
 		SilenceUsage: true,
 		RunE: func(_ *cobra.Command, args []string) error {
@@ -224,16 +321,143 @@ from the module's 'module.yaml' and editions follow the
 				dir = args[0]
 			}
 
-			return rendercmd.Render(dir, renderOutput)
+			if coverageReport {
+				return rendercmd.Coverage(dir, coverageFormat, coverageOutput, flags.GlobalValues)
+			}
+
+			return rendercmd.Render(dir, renderOutput, flags.GlobalValues)
 		},
 	}
 	renderCmd.Flags().StringVarP(&renderOutput, "output", "o", "",
 		"directory to write the rendered output into (created if absent; a 'rendered' subdirectory is created inside it)")
+	renderCmd.Flags().BoolVar(&coverageReport, "coverage", false,
+		"report template coverage across values scenarios and templates-tests cases instead of rendering")
+	renderCmd.Flags().StringVar(&coverageFormat, "coverage-format", "text",
+		"coverage report format: text, json or cobertura")
+	renderCmd.Flags().StringVar(&coverageOutput, "coverage-output", "",
+		"file to write the coverage report into (stdout by default)")
+	renderCmd.PersistentFlags().AddFlagSet(flags.InitGlobalValuesFlagSet())
+
+	var diffBase string
+
+	renderDiffCmd := &cobra.Command{
+		Use:   "diff <module-path>",
+		Short: "Diff module render output against a base git revision",
+		Long: `Renders the module as it is at the --base git revision and as it is in the
+working tree, using the same values generated from the working tree's openapi
+schemas, and prints a per-object diff of the rendered manifests (added,
+removed and changed objects with field-level changes) as Markdown suitable
+for a pull request comment.
+
+The base revision is read directly from the git repository; the working tree
+is not checked out or modified.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return rendercmd.Diff(args[0], diffBase, flags.GlobalValues, os.Stdout)
+		},
+	}
+	renderDiffCmd.Flags().StringVar(&diffBase, "base", "",
+		"git revision to compare the working tree against (branch, tag or commit)")
+	_ = renderDiffCmd.MarkFlagRequired("base")
+
+	renderCmd.AddCommand(renderDiffCmd)
+
+	var (
+		inventoryFormat string
+		inventoryOutput string
+	)
+
+	inventoryCmd := &cobra.Command{
+		Use:   "inventory [module-path]",
+		Short: "List rendered objects of Deckhouse modules",
+		Long: `Finds all modules under the given path (including subdirectories), renders
+them the same way dmt renders them for linting, and lists every rendered
+object: module, kind, namespace, name and source template, and for workloads
+the images, service account, replicas, effective CPU/memory requests, host
+paths and privileged settings (privileged containers, hostNetwork, hostPID,
+hostIPC). A per-module summary follows the table, together with the
+privileged surface of each module: the workloads requesting privileges.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var dir = "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			return inventory.Run(dir, inventoryFormat, inventoryOutput, flags.GlobalValues)
+		},
+	}
+	inventoryCmd.Flags().StringVar(&inventoryFormat, "format", inventory.FormatTable,
+		"output format: table or json")
+	inventoryCmd.Flags().StringVarP(&inventoryOutput, "output", "o", "",
+		"file to write the inventory into (stdout by default)")
+	inventoryCmd.Flags().AddFlagSet(flags.InitGlobalValuesFlagSet())
+
+	devCmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Helpers for dmt development",
+	}
+
+	var (
+		fixtureOutput string
+		fixtureForce  bool
+		fixtureList   bool
+	)
+
+	genFixtureCmd := &cobra.Command{
+		Use:   "gen-fixture <linter>/<rule>",
+		Short: "Generate failing and passing e2e cases for a lint rule",
+		Long: `Generates a pair of e2e cases for a lint rule from its fixture in the
+internal/fixtures catalog: '<rule>-fail', a module violating the rule with the
+finding it must produce, and '<rule>-pass', the well-formed baseline module the
+rule must pass. Cases are written in the expected.yaml format under
+<output>/<linter>/.`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if fixtureList {
+				fmt.Println(strings.Join(fixtures.IDs(), "\n"))
+				return nil
+			}
+
+			if len(args) == 0 {
+				return errors.New("rule is required, e.g. templates/vpa")
+			}
+
+			fixture, err := fixtures.Lookup(args[0])
+			if err != nil {
+				return err
+			}
+
+			dirs, err := fixture.Write(fixtureOutput, fixtureForce)
+			if err != nil {
+				return err
+			}
+
+			for _, dir := range dirs {
+				fmt.Println(dir)
+			}
+
+			return nil
+		},
+	}
+	genFixtureCmd.Flags().StringVarP(&fixtureOutput, "output", "o", "test/e2e/testdata",
+		"e2e testdata directory to write the cases into")
+	genFixtureCmd.Flags().BoolVar(&fixtureForce, "force", false,
+		"replace existing cases")
+	genFixtureCmd.Flags().BoolVar(&fixtureList, "list", false,
+		"list the rules with fixtures")
+
+	devCmd.AddCommand(genFixtureCmd)
 
 	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(bootstrapCmd)
 	rootCmd.AddCommand(testCmd)
 	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(inventoryCmd)
+	rootCmd.AddCommand(devCmd)
 	rootCmd.Flags().AddFlagSet(flags.InitDefaultFlagSet())
 
 	err := rootCmd.Execute()
@@ -253,6 +477,13 @@ func runTests(dir string, opts ...test.Option) error {
 		return fmt.Errorf("failed to expand directory: %w", err)
 	}
 
+	opts = append(opts,
+		test.WithParallel(flags.LintersLimit),
+		test.WithRun(flags.TestRun),
+		test.WithFailFast(flags.TestFailFast),
+		test.WithGlobalValues(flags.GlobalValues),
+	)
+
 	manager, err := test.NewManager(expandedDir, cfg, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create test manager: %w", err)
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package coverage measures which parts of a module's Helm templates a render
+// exercises. The chart's own templates are parsed with text/template/parse and
+// every block (a manifest template, a define, each outcome of an
+// if/with/range) gets a printf_debug marker; the instrumented chart is then
+// rendered by the same nelm render the linters use, with the engine's debug
+// output captured, and every marker printed counts as a hit of its block. Hits
+// of several renders (values scenarios, test cases) accumulate in one
+// Collector.
+package coverage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/deckhouse/dmt/internal/modules/render"
+)
+
+// templatesDir is the directory of the chart's own templates, the only ones
+// instrumented and reported (dependencies under charts/ only provide defines).
+const templatesDir = "templates"
+
+// hitRe matches the engine's debug output of one executed marker; see marker.
+var hitRe = regexp.MustCompile(`-- printf_debug format "` + regexp.QuoteMeta(markerPrefix) + `%s" result:\n` +
+	regexp.QuoteMeta(markerPrefix) + `(\d+)\n`)
+
+// Collector accumulates template coverage of one chart across renders.
+type Collector struct {
+	blocks []*block
+	index  map[blockKey]int
+	// lines maps file -> line -> blocks whose statements are on that line.
+	lines map[string]map[int]map[int]struct{}
+	// instrumented maps chart-relative file -> instrumented template source.
+	instrumented map[string][]byte
+}
+
+// NewCollector returns an empty collector.
+func NewCollector() *Collector {
+	return &Collector{
+		index:        make(map[blockKey]int),
+		lines:        make(map[string]map[int]map[int]struct{}),
+		instrumented: make(map[string][]byte),
+	}
+}
+
+func (c *Collector) register(key blockKey, line int, name string) int {
+	if id, ok := c.index[key]; ok {
+		return id
+	}
+
+	id := len(c.blocks)
+	c.blocks = append(c.blocks, &block{key: key, line: line, name: name})
+	c.index[key] = id
+
+	return id
+}
+
+func (c *Collector) addLine(file string, line, id int) {
+	byLine, ok := c.lines[file]
+	if !ok {
+		byLine = make(map[int]map[int]struct{})
+		c.lines[file] = byLine
+	}
+
+	ids, ok := byLine[line]
+	if !ok {
+		ids = make(map[int]struct{})
+		byLine[line] = ids
+	}
+
+	ids[id] = struct{}{}
+}
+
+// Instrument parses every template under the chart's templates/ directory and
+// registers its blocks. It returns the files it could not parse, joined; nelm
+// reports them again when rendering, so the caller may go on.
+func (c *Collector) Instrument(chartDir string) error {
+	root := filepath.Join(chartDir, templatesDir)
+
+	var parseErr error
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// A symlinked template is shared with other charts; writing the
+		// instrumented source would go through the link, so it is left out.
+		if d.IsDir() || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(chartDir, path)
+		if err != nil {
+			return err
+		}
+
+		file := filepath.ToSlash(rel)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read template: %w", err)
+		}
+
+		instrumented, err := c.instrument(file, string(content))
+		if err != nil {
+			parseErr = errors.Join(parseErr, fmt.Errorf("parse %s: %w", file, err))
+
+			return nil
+		}
+
+		c.instrumented[file] = []byte(instrumented)
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("instrument templates: %w", err)
+	}
+
+	return parseErr
+}
+
+// instrument parses one template file, inserts the markers and returns the
+// instrumented source: the file's top-level template followed by its defines.
+func (c *Collector) instrument(file, content string) (string, error) {
+	trees := make(map[string]*parse.Tree)
+
+	t := parse.New(file)
+	t.Mode = parse.SkipFuncCheck
+
+	if _, err := t.Parse(content, "", "", trees); err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(trees))
+	for name := range trees {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	in := &instrumenter{c: c, file: file, source: newSourceLines(content)}
+
+	var sb strings.Builder
+
+	if top, ok := trees[file]; ok {
+		in.tree(top)
+		sb.WriteString(top.Root.String())
+	}
+
+	for _, name := range names {
+		tree := trees[name]
+		if name == file || tree.ParseName != file {
+			continue
+		}
+
+		in.tree(tree)
+		fmt.Fprintf(&sb, "{{define %q}}%s{{end}}", name, tree.Root.String())
+	}
+
+	return sb.String(), nil
+}
+
+// Render renders the chart at opts.Path with its templates replaced by the
+// instrumented ones and records the blocks the render executed. The original
+// templates are restored before Render returns.
+//
+// The render is tolerant (see render.Render): a manifest template that aborts
+// it is dropped and the rest re-rendered, and every attempt prints the markers
+// of what it executed. A block therefore counts the hits of the attempt that
+// executed it most, so a template failing halfway keeps the hits it collected
+// up to the failure and the templates rendered by every attempt are not
+// counted once per attempt.
+func (c *Collector) Render(ctx context.Context, namespace, releaseName string, opts render.Options) error {
+	restore, err := c.swapTemplates(opts.Path)
+	defer restore()
+
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+
+	hits := make(map[int]int)
+
+	flush := func() {
+		attempt := make(map[int]int)
+		for _, m := range hitRe.FindAllStringSubmatch(out.String(), -1) {
+			if id, err := strconv.Atoi(m[1]); err == nil && id < len(c.blocks) {
+				attempt[id]++
+			}
+		}
+
+		for id, n := range attempt {
+			hits[id] = max(hits[id], n)
+		}
+
+		out.Reset()
+	}
+
+	onDrop := opts.OnDrop
+	opts.DebugOutput = &out
+	opts.OnDrop = func(templatePath, renderErr string) {
+		flush()
+
+		if onDrop != nil {
+			onDrop(templatePath, renderErr)
+		}
+	}
+
+	_, renderErr := render.Render(ctx, namespace, releaseName, opts)
+
+	flush()
+
+	for id, n := range hits {
+		c.blocks[id].hits += n
+	}
+
+	if renderErr != nil {
+		// In debug mode the engine appends the whole (instrumented) template to
+		// its errors; keep just the error.
+		msg, _, _ := strings.Cut(renderErr.Error(), "\n\nDetails:")
+
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// swapTemplates writes the instrumented templates over the chart's own and
+// returns a func restoring the originals, to be called even on error.
+func (c *Collector) swapTemplates(chartDir string) (func(), error) {
+	type original struct {
+		content []byte
+		mode    os.FileMode
+	}
+
+	originals := make(map[string]original, len(c.instrumented))
+
+	restore := func() {
+		for file, o := range originals {
+			_ = os.WriteFile(filepath.Join(chartDir, filepath.FromSlash(file)), o.content, o.mode)
+		}
+	}
+
+	for file, content := range c.instrumented {
+		path := filepath.Join(chartDir, filepath.FromSlash(file))
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return restore, fmt.Errorf("stat template: %w", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return restore, fmt.Errorf("read template: %w", err)
+		}
+
+		originals[file] = original{content: data, mode: info.Mode().Perm()}
+
+		if err := os.WriteFile(path, content, info.Mode().Perm()); err != nil {
+			return restore, fmt.Errorf("write instrumented template: %w", err)
+		}
+	}
+
+	return restore, nil
+}
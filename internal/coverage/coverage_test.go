@@ -0,0 +1,227 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/modules/render"
+)
+
+const deploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "name" . }}
+spec:
+  {{- if .Values.test.highAvailability }}
+  replicas: 2
+  {{- else }}
+  replicas: 1
+  {{- end }}
+  args:
+  {{- range .Values.test.args }}
+  - {{ . }}
+  {{- end }}
+`
+
+const helpersTemplate = `{{- define "name" -}}
+app
+{{- end -}}
+
+{{- define "unused" -}}
+{{ .Values.nothing }}
+{{- end -}}
+`
+
+// newTestChart writes a chart with the deployment and helpers templates (plus
+// extra templates, name -> content) into a temp dir and returns the dir.
+func newTestChart(t *testing.T, extra map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"Chart.yaml":                "apiVersion: v2\nname: test\nversion: 0.1.0\n",
+		"templates/deployment.yaml": deploymentTemplate,
+		"templates/_helpers.tpl":    helpersTemplate,
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func renderChart(t *testing.T, c *Collector, dir string, vals map[string]any) error {
+	t.Helper()
+
+	return c.Render(context.Background(), "d8-test", "test", render.Options{Path: dir, Values: vals})
+}
+
+func findFile(t *testing.T, files []File, path string) File {
+	t.Helper()
+
+	for _, f := range files {
+		if f.Path == path {
+			return f
+		}
+	}
+
+	t.Fatalf("no coverage for %s", path)
+
+	return File{}
+}
+
+func branchHits(f File, kind BlockKind) int {
+	for _, b := range f.Branches {
+		if b.Kind == kind {
+			return b.Hits
+		}
+	}
+
+	return -1
+}
+
+func TestCollectorBranches(t *testing.T) {
+	dir := newTestChart(t, nil)
+
+	c := NewCollector()
+	require.NoError(t, c.Instrument(dir))
+
+	err := renderChart(t, c, dir, map[string]any{"test": map[string]any{"highAvailability": true}})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "templates/deployment.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, deploymentTemplate, string(content), "the templates are restored after the render")
+
+	deployment := findFile(t, c.Files(), "templates/deployment.yaml")
+	assert.Equal(t, 1, branchHits(deployment, KindIf))
+	assert.Equal(t, 0, branchHits(deployment, KindElse))
+	assert.Equal(t, 0, branchHits(deployment, KindRange))
+	assert.Equal(t, 1, branchHits(deployment, KindRangeElse))
+	assert.Equal(t, 2, deployment.Totals.BranchesCovered)
+	assert.Equal(t, 4, deployment.Totals.BranchesTotal)
+
+	helpers := findFile(t, c.Files(), "templates/_helpers.tpl")
+	require.Len(t, helpers.Defines, 2)
+	assert.Equal(t, Define{Name: "name", Line: 1, Hits: 1}, helpers.Defines[0])
+	assert.Equal(t, Define{Name: "unused", Line: 5, Hits: 0}, helpers.Defines[1])
+
+	// A second scenario adds up with the first one.
+	err = renderChart(t, c, dir, map[string]any{"test": map[string]any{"args": []any{"-v"}}})
+	require.NoError(t, err)
+
+	deployment = findFile(t, c.Files(), "templates/deployment.yaml")
+	assert.Equal(t, 4, deployment.Totals.BranchesCovered)
+	assert.Equal(t, deployment.Totals.LinesTotal, deployment.Totals.LinesCovered)
+}
+
+func TestCollectorTemplateFailure(t *testing.T) {
+	dir := newTestChart(t, map[string]string{
+		"templates/failing.yaml": "{{- if .Values.test.enabled }}\n{{ fail \"boom\" }}\n{{- end }}\n",
+	})
+
+	c := NewCollector()
+	require.NoError(t, c.Instrument(dir))
+
+	var dropped []string
+
+	err := c.Render(context.Background(), "d8-test", "test", render.Options{
+		Path:   dir,
+		Values: map[string]any{"test": map[string]any{"enabled": true}},
+		OnDrop: func(templatePath, renderErr string) {
+			dropped = append(dropped, templatePath)
+			assert.Contains(t, renderErr, "boom")
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"templates/failing.yaml"}, dropped)
+
+	failing := findFile(t, c.Files(), "templates/failing.yaml")
+	assert.Equal(t, 1, branchHits(failing, KindIf), "hits before the failure are kept")
+
+	deployment := findFile(t, c.Files(), "templates/deployment.yaml")
+	assert.Positive(t, deployment.Totals.LinesCovered, "other templates still run")
+	assert.Equal(t, 1, branchHits(deployment, KindElse), "the re-render after the drop is not counted twice")
+}
+
+func TestInstrumentKeepsTemplateOutput(t *testing.T) {
+	c := NewCollector()
+
+	instrumented, err := c.instrument("templates/deployment.yaml", deploymentTemplate)
+	require.NoError(t, err)
+	assert.Contains(t, instrumented, `{{printf_debug "__dmtCoverage:%s" "0"}}`)
+
+	// Without the engine's debug mode the markers print nothing, so the
+	// instrumented template renders exactly what the original does.
+	vals := map[string]any{"Values": map[string]any{"test": map[string]any{"highAvailability": true, "args": []any{"-v"}}}}
+	funcs := template.FuncMap{
+		"include":      func(string, any) string { return "app" },
+		"printf_debug": func(string, ...any) string { return "" },
+	}
+
+	var want, got strings.Builder
+
+	require.NoError(t, template.Must(template.New("a").Funcs(funcs).Parse(deploymentTemplate)).Execute(&want, vals))
+	require.NoError(t, template.Must(template.New("b").Funcs(funcs).Parse(instrumented)).Execute(&got, vals))
+	assert.Equal(t, want.String(), got.String())
+}
+
+func TestWriteFormats(t *testing.T) {
+	dir := newTestChart(t, nil)
+
+	c := NewCollector()
+	require.NoError(t, c.Instrument(dir))
+	require.NoError(t, renderChart(t, c, dir, map[string]any{"test": map[string]any{}}))
+
+	report := &Report{Modules: []ModuleReport{
+		NewModuleReport("test", "/modules/test", []Scenario{{Name: "values.yaml"}}, c),
+	}}
+
+	var sb strings.Builder
+
+	require.NoError(t, Write(&sb, report, FormatText))
+	assert.Contains(t, sb.String(), "templates/deployment.yaml")
+	assert.Contains(t, sb.String(), `define "unused" never included`)
+	assert.Contains(t, sb.String(), "if branch never taken")
+
+	sb.Reset()
+	require.NoError(t, Write(&sb, report, FormatJSON))
+	assert.Contains(t, sb.String(), `"branchesTotal": 4`)
+
+	sb.Reset()
+	require.NoError(t, Write(&sb, report, FormatCobertura))
+	assert.Contains(t, sb.String(), `<class name="templates/deployment.yaml" filename="templates/deployment.yaml"`)
+	assert.Contains(t, sb.String(), `condition-coverage="50% (1/2)"`)
+	assert.Contains(t, sb.String(), `<method name="unused"`)
+
+	require.Error(t, Write(&sb, report, "html"))
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats accepted by Write.
+const (
+	FormatText      = "text"
+	FormatJSON      = "json"
+	FormatCobertura = "cobertura"
+)
+
+// CheckFormat returns an error unless format is one Write accepts.
+func CheckFormat(format string) error {
+	switch format {
+	case FormatText, "", FormatJSON, FormatCobertura:
+		return nil
+	default:
+		return fmt.Errorf("unknown coverage format %q (want %s, %s or %s)", format, FormatText, FormatJSON, FormatCobertura)
+	}
+}
+
+// Write writes the report in the given format (text, json or cobertura).
+func Write(w io.Writer, r *Report, format string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatText, "":
+		return WriteText(w, r)
+	case FormatJSON:
+		return WriteJSON(w, r)
+	default:
+		return WriteCobertura(w, r)
+	}
+}
+
+// WriteJSON writes the report as indented JSON.
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteText writes a human-readable summary: per-file line and branch
+// coverage, followed by the branches and defines no scenario reached.
+func WriteText(w io.Writer, r *Report) error {
+	var sb strings.Builder
+
+	for _, m := range r.Modules {
+		fmt.Fprintf(&sb, "Module %s (%s)\n", m.Name, m.Path)
+
+		scenarios := make([]string, 0, len(m.Scenarios))
+		for _, s := range m.Scenarios {
+			scenarios = append(scenarios, s.Name)
+		}
+
+		fmt.Fprintf(&sb, "Scenarios: %s\n\n", strings.Join(scenarios, ", "))
+
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FILE\tLINES\tBRANCHES\tDEFINES")
+
+		for i := range m.Files {
+			f := &m.Files[i]
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Path,
+				ratio(f.Totals.LinesCovered, f.Totals.LinesTotal),
+				ratio(f.Totals.BranchesCovered, f.Totals.BranchesTotal),
+				ratio(f.Totals.DefinesCovered, f.Totals.DefinesTotal))
+		}
+
+		fmt.Fprintf(tw, "TOTAL\t%s\t%s\t%s\n",
+			ratio(m.Totals.LinesCovered, m.Totals.LinesTotal),
+			ratio(m.Totals.BranchesCovered, m.Totals.BranchesTotal),
+			ratio(m.Totals.DefinesCovered, m.Totals.DefinesTotal))
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		writeMissed(&sb, &m)
+
+		for _, s := range m.Scenarios {
+			if s.Error != "" {
+				fmt.Fprintf(&sb, "\nScenario %s had render errors:\n  %s\n", s.Name, strings.ReplaceAll(s.Error, "\n", "\n  "))
+			}
+		}
+
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func writeMissed(sb *strings.Builder, m *ModuleReport) {
+	var missed []string
+
+	for _, f := range m.Files {
+		for _, b := range f.Branches {
+			if b.Hits == 0 {
+				missed = append(missed, fmt.Sprintf("%s:%d: %s branch never taken", f.Path, b.Line, b.Kind))
+			}
+		}
+
+		for _, d := range f.Defines {
+			if d.Hits == 0 {
+				missed = append(missed, fmt.Sprintf("%s:%d: define %q never included", f.Path, d.Line, d.Name))
+			}
+		}
+	}
+
+	if len(missed) == 0 {
+		return
+	}
+
+	sb.WriteString("\nNot covered:\n")
+
+	for _, line := range missed {
+		sb.WriteString("  " + line + "\n")
+	}
+}
+
+func ratio(covered, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d/%d (%.1f%%)", covered, total, 100*float64(covered)/float64(total))
+}
+
+// Cobertura XML elements, as consumed by GitLab, Jenkins and most CI coverage
+// viewers. Each module is a package and each template file a class; defines
+// are reported as methods.
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        string             `xml:"line-rate,attr"`
+	BranchRate      string             `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      string             `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string            `xml:"name,attr"`
+	Filename   string            `xml:"filename,attr"`
+	LineRate   string            `xml:"line-rate,attr"`
+	BranchRate string            `xml:"branch-rate,attr"`
+	Complexity string            `xml:"complexity,attr"`
+	Methods    []coberturaMethod `xml:"methods>method"`
+	Lines      []coberturaLine   `xml:"lines>line"`
+}
+
+type coberturaMethod struct {
+	Name       string          `xml:"name,attr"`
+	Signature  string          `xml:"signature,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+// WriteCobertura writes the report as Cobertura XML. File names are relative to
+// the module directory, which is listed as the source of its package.
+func WriteCobertura(w io.Writer, r *Report) error {
+	totals := r.Totals()
+
+	doc := coberturaCoverage{
+		LineRate:        formatRate(totals.LineRate()),
+		BranchRate:      formatRate(totals.BranchRate()),
+		LinesCovered:    totals.LinesCovered,
+		LinesValid:      totals.LinesTotal,
+		BranchesCovered: totals.BranchesCovered,
+		BranchesValid:   totals.BranchesTotal,
+		Complexity:      "0",
+		Version:         "dmt",
+		Timestamp:       time.Now().Unix(),
+	}
+
+	for _, m := range r.Modules {
+		doc.Sources = append(doc.Sources, m.Path)
+
+		pkg := coberturaPackage{
+			Name:       m.Name,
+			LineRate:   formatRate(m.Totals.LineRate()),
+			BranchRate: formatRate(m.Totals.BranchRate()),
+			Complexity: "0",
+		}
+
+		for i := range m.Files {
+			pkg.Classes = append(pkg.Classes, coberturaClassFor(&m.Files[i]))
+		}
+
+		doc.Packages = append(doc.Packages, pkg)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func coberturaClassFor(f *File) coberturaClass {
+	branchesByLine := make(map[int][2]int)
+
+	for _, b := range f.Branches {
+		counts := branchesByLine[b.Line]
+		counts[1]++
+
+		if b.Hits > 0 {
+			counts[0]++
+		}
+
+		branchesByLine[b.Line] = counts
+	}
+
+	class := coberturaClass{
+		Name:       f.Path,
+		Filename:   f.Path,
+		LineRate:   formatRate(f.Totals.LineRate()),
+		BranchRate: formatRate(f.Totals.BranchRate()),
+		Complexity: "0",
+	}
+
+	for _, l := range f.Lines {
+		line := coberturaLine{Number: l.Number, Hits: l.Hits}
+
+		if counts, ok := branchesByLine[l.Number]; ok {
+			line.Branch = true
+			line.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", 100*counts[0]/counts[1], counts[0], counts[1])
+		}
+
+		class.Lines = append(class.Lines, line)
+	}
+
+	for _, d := range f.Defines {
+		class.Methods = append(class.Methods, coberturaMethod{
+			Name:       d.Name,
+			Signature:  "",
+			LineRate:   formatRate(rate(min(d.Hits, 1), 1)),
+			BranchRate: formatRate(1),
+			Lines:      []coberturaLine{{Number: d.Line, Hits: d.Hits}},
+		})
+	}
+
+	return class
+}
+
+func formatRate(r float64) string {
+	return strconv.FormatFloat(r, 'f', 4, 64)
+}
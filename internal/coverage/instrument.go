@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+const (
+	// markerFunc is the template function every instrumented block calls on
+	// entry. It renders nothing; in the engine's debug mode it prints the
+	// formatted marker, which records the hit.
+	markerFunc = "printf_debug"
+	// markerPrefix starts the text a marker prints, followed by the block id.
+	markerPrefix = "__dmtCoverage:"
+)
+
+// BlockKind tells what an instrumented block is.
+type BlockKind string
+
+const (
+	// KindTemplate is the top level of a manifest template file.
+	KindTemplate BlockKind = "template"
+	// KindDefine is the body of a named template ({{ define }}).
+	KindDefine BlockKind = "define"
+	// KindIf / KindElse are the two branches of an {{ if }}.
+	KindIf   BlockKind = "if"
+	KindElse BlockKind = "else"
+	// KindWith / KindWithElse are the two branches of a {{ with }}.
+	KindWith     BlockKind = "with"
+	KindWithElse BlockKind = "with-else"
+	// KindRange / KindRangeElse are a {{ range }} body (iterated at least once)
+	// and its empty-collection branch.
+	KindRange     BlockKind = "range"
+	KindRangeElse BlockKind = "range-else"
+)
+
+// isBranch reports whether blocks of this kind are branch outcomes (as opposed
+// to template or define bodies).
+func (k BlockKind) isBranch() bool {
+	return k != KindTemplate && k != KindDefine
+}
+
+// blockKey identifies a block independently of the render that instrumented it,
+// so hits from several renders of the same chart add up.
+type blockKey struct {
+	file string
+	pos  parse.Pos
+	kind BlockKind
+}
+
+type block struct {
+	key blockKey
+	// line is the source line of the construct the block belongs to: the
+	// if/with/range action for branches, the define action for defines.
+	line int
+	// name is the define name, for define blocks.
+	name string
+	hits int
+}
+
+// sourceLines maps byte offsets of one template file to 1-based line numbers.
+type sourceLines struct {
+	text     string
+	newlines []int
+}
+
+func newSourceLines(text string) *sourceLines {
+	s := &sourceLines{text: text}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			s.newlines = append(s.newlines, i)
+		}
+	}
+
+	return s
+}
+
+// line returns the 1-based line of byte offset pos.
+func (s *sourceLines) line(pos parse.Pos) int {
+	return sort.SearchInts(s.newlines, int(pos)) + 1
+}
+
+// instrumenter inserts a marker action at the start of every block of a parsed
+// template tree and registers the block and the source lines it covers with the
+// collector.
+type instrumenter struct {
+	c      *Collector
+	file   string
+	source *sourceLines
+}
+
+// tree instruments a whole tree: a file's top-level template (named after the
+// file) or a define declared in it.
+func (in *instrumenter) tree(t *parse.Tree) {
+	if t == nil || t.Root == nil {
+		return
+	}
+
+	kind, name := KindTemplate, ""
+	if t.Name != t.ParseName {
+		kind, name = KindDefine, t.Name
+	}
+
+	id := in.c.register(blockKey{file: in.file, pos: t.Root.Pos, kind: kind}, in.defineLine(t, kind), name)
+	in.list(t.Root, id)
+}
+
+// defineLine returns the line of the {{ define }} action opening a define tree:
+// its root list starts right after the action, so scan back for the "define"
+// keyword. Templates report line 1.
+func (in *instrumenter) defineLine(t *parse.Tree, kind BlockKind) int {
+	if kind != KindDefine {
+		return 1
+	}
+
+	pos := int(t.Root.Pos)
+	if pos > len(in.source.text) {
+		pos = len(in.source.text)
+	}
+
+	if idx := strings.LastIndex(in.source.text[:pos], "define"); idx >= 0 {
+		return in.source.line(parse.Pos(idx))
+	}
+
+	return in.source.line(t.Root.Pos)
+}
+
+// list instruments the statements of one block: it records the lines of the
+// block's statements, descends into nested branches and finally prepends the
+// block's marker.
+func (in *instrumenter) list(list *parse.ListNode, id int) {
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			in.textLines(n, id)
+		case *parse.ActionNode, *parse.TemplateNode:
+			in.c.addLine(in.file, in.source.line(node.Position()), id)
+		case *parse.IfNode:
+			in.branch(&n.BranchNode, KindIf, KindElse, id)
+		case *parse.WithNode:
+			in.branch(&n.BranchNode, KindWith, KindWithElse, id)
+		case *parse.RangeNode:
+			in.branch(&n.BranchNode, KindRange, KindRangeElse, id)
+		}
+	}
+
+	list.Nodes = append([]parse.Node{in.marker(id)}, list.Nodes...)
+}
+
+// branch instruments both outcomes of an if/with/range. A missing else gets an
+// empty else list, so the implicit "condition was false" outcome is tracked too.
+func (in *instrumenter) branch(n *parse.BranchNode, thenKind, elseKind BlockKind, parent int) {
+	line := in.source.line(n.Pos)
+	in.c.addLine(in.file, line, parent)
+
+	thenID := in.c.register(blockKey{file: in.file, pos: n.Pos, kind: thenKind}, line, "")
+	in.list(n.List, thenID)
+
+	if n.ElseList == nil {
+		n.ElseList = &parse.ListNode{NodeType: parse.NodeList, Pos: n.Pos}
+	}
+
+	elseID := in.c.register(blockKey{file: in.file, pos: n.Pos, kind: elseKind}, line, "")
+	in.list(n.ElseList, elseID)
+}
+
+// textLines records the lines of a text node that carry non-blank content.
+func (in *instrumenter) textLines(n *parse.TextNode, id int) {
+	line := in.source.line(n.Pos)
+
+	for _, part := range strings.Split(string(n.Text), "\n") {
+		if strings.TrimSpace(part) != "" {
+			in.c.addLine(in.file, line, id)
+		}
+
+		line++
+	}
+}
+
+// marker builds the `{{ printf_debug "__dmtCoverage:%s" "<id>" }}` action
+// recording a hit of block id. It is parsed rather than assembled, since a node
+// prints itself through the tree it was parsed by.
+func (in *instrumenter) marker(id int) parse.Node {
+	t := parse.New(markerFunc)
+	t.Mode = parse.SkipFuncCheck
+
+	src := fmt.Sprintf("{{%s %q %q}}", markerFunc, markerPrefix+"%s", strconv.Itoa(id))
+	if _, err := t.Parse(src, "", "", map[string]*parse.Tree{}); err != nil {
+		panic(fmt.Sprintf("parse coverage marker: %v", err))
+	}
+
+	return t.Root.Nodes[0]
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"sort"
+)
+
+// Report is the coverage of one or more modules.
+type Report struct {
+	Modules []ModuleReport `json:"modules"`
+}
+
+// ModuleReport is the coverage of one module's templates, accumulated over all
+// of its scenarios.
+type ModuleReport struct {
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	Scenarios []Scenario `json:"scenarios"`
+	Files     []File     `json:"files"`
+	Totals    Totals     `json:"totals"`
+}
+
+// Scenario is one render the coverage was collected from: a values file or a
+// templates-tests case. Error holds the templates that failed to execute.
+type Scenario struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// File is the coverage of one template file.
+type File struct {
+	Path     string   `json:"path"`
+	Lines    []Line   `json:"lines"`
+	Branches []Branch `json:"branches"`
+	Defines  []Define `json:"defines,omitempty"`
+	Totals   Totals   `json:"totals"`
+}
+
+// Line is the hit count of one source line with template statements.
+type Line struct {
+	Number int `json:"number"`
+	Hits   int `json:"hits"`
+}
+
+// Branch is one outcome of an if/with/range: "if" / "else", "with" /
+// "with-else", "range" (iterated) / "range-else" (empty collection).
+type Branch struct {
+	Line int       `json:"line"`
+	Kind BlockKind `json:"kind"`
+	Hits int       `json:"hits"`
+}
+
+// Define is the hit count of a named template.
+type Define struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+	Hits int    `json:"hits"`
+}
+
+// Totals summarizes covered versus total lines, branches and defines.
+type Totals struct {
+	LinesCovered    int `json:"linesCovered"`
+	LinesTotal      int `json:"linesTotal"`
+	BranchesCovered int `json:"branchesCovered"`
+	BranchesTotal   int `json:"branchesTotal"`
+	DefinesCovered  int `json:"definesCovered"`
+	DefinesTotal    int `json:"definesTotal"`
+}
+
+func (t *Totals) add(other Totals) {
+	t.LinesCovered += other.LinesCovered
+	t.LinesTotal += other.LinesTotal
+	t.BranchesCovered += other.BranchesCovered
+	t.BranchesTotal += other.BranchesTotal
+	t.DefinesCovered += other.DefinesCovered
+	t.DefinesTotal += other.DefinesTotal
+}
+
+// LineRate returns the covered fraction of lines (1 when there are none).
+func (t *Totals) LineRate() float64 { return rate(t.LinesCovered, t.LinesTotal) }
+
+// BranchRate returns the covered fraction of branches (1 when there are none).
+func (t *Totals) BranchRate() float64 { return rate(t.BranchesCovered, t.BranchesTotal) }
+
+func rate(covered, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+
+	return float64(covered) / float64(total)
+}
+
+// Files returns the per-file coverage collected so far, sorted by path. A line
+// counts the hits of the most executed block with statements on it.
+func (c *Collector) Files() []File {
+	byFile := make(map[string]*File)
+
+	get := func(path string) *File {
+		f, ok := byFile[path]
+		if !ok {
+			f = &File{Path: path}
+			byFile[path] = f
+		}
+
+		return f
+	}
+
+	for path, lines := range c.lines {
+		f := get(path)
+
+		for number, ids := range lines {
+			hits := 0
+			for id := range ids {
+				hits = max(hits, c.blocks[id].hits)
+			}
+
+			f.Lines = append(f.Lines, Line{Number: number, Hits: hits})
+		}
+	}
+
+	for _, b := range c.blocks {
+		f := get(b.key.file)
+
+		switch {
+		case b.key.kind == KindDefine:
+			f.Defines = append(f.Defines, Define{Name: b.name, Line: b.line, Hits: b.hits})
+		case b.key.kind.isBranch():
+			f.Branches = append(f.Branches, Branch{Line: b.line, Kind: b.key.kind, Hits: b.hits})
+		}
+	}
+
+	result := make([]File, 0, len(byFile))
+
+	for _, f := range byFile {
+		sort.Slice(f.Lines, func(i, j int) bool { return f.Lines[i].Number < f.Lines[j].Number })
+		sort.SliceStable(f.Branches, func(i, j int) bool { return f.Branches[i].Line < f.Branches[j].Line })
+		sort.Slice(f.Defines, func(i, j int) bool { return f.Defines[i].Line < f.Defines[j].Line })
+
+		f.Totals = fileTotals(f)
+		result = append(result, *f)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+
+	return result
+}
+
+func fileTotals(f *File) Totals {
+	t := Totals{
+		LinesTotal:    len(f.Lines),
+		BranchesTotal: len(f.Branches),
+		DefinesTotal:  len(f.Defines),
+	}
+
+	for _, l := range f.Lines {
+		if l.Hits > 0 {
+			t.LinesCovered++
+		}
+	}
+
+	for _, b := range f.Branches {
+		if b.Hits > 0 {
+			t.BranchesCovered++
+		}
+	}
+
+	for _, d := range f.Defines {
+		if d.Hits > 0 {
+			t.DefinesCovered++
+		}
+	}
+
+	return t
+}
+
+// NewModuleReport builds the report of one module from its collector.
+func NewModuleReport(name, path string, scenarios []Scenario, c *Collector) ModuleReport {
+	r := ModuleReport{Name: name, Path: path, Scenarios: scenarios, Files: c.Files()}
+
+	for _, f := range r.Files {
+		r.Totals.add(f.Totals)
+	}
+
+	return r
+}
+
+// Totals returns the totals over all modules.
+func (r *Report) Totals() Totals {
+	var t Totals
+	for _, m := range r.Modules {
+		t.add(m.Totals)
+	}
+
+	return t
+}
@@ -0,0 +1,1062 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"maps"
+	"strings"
+)
+
+// The catalog: one register call per rule. Mutations start from the baseline
+// module (see module.go) and should break only the rule they are declared for.
+func init() {
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "vpa",
+		Violation:    "A Deployment without a VerticalPodAutoscaler",
+		Level:        "error",
+		TextContains: "No VPA is found for object",
+		Mutate:       func(m *Module) { m.Remove("VerticalPodAutoscaler") },
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "pdb",
+		Violation:    "A Deployment without a PodDisruptionBudget",
+		Level:        "error",
+		TextContains: "No PodDisruptionBudget found for controller",
+		Mutate:       func(m *Module) { m.Remove("PodDisruptionBudget") },
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "high-availability",
+		Violation:    "A Deployment with 2 replicas and no pod anti-affinity",
+		Level:        "error",
+		TextContains: "does not spread them across nodes",
+		Mutate:       func(m *Module) { delete(m.PodSpec(), "affinity") },
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "service-port",
+		Violation:    "A Service with a numeric targetPort",
+		Level:        "error",
+		TextContains: "Service port must use a named (non-numeric) target port",
+		Mutate: func(m *Module) {
+			ports, _ := Field(m.Object("Service"), "spec")["ports"].([]any)
+			ports[0].(map[string]any)["targetPort"] = 8080
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "resource-requests",
+		Violation:    "A container requesting less CPU than its VPA minAllowed",
+		Level:        "error",
+		TextContains: "Container cpu request 5m is below the VPA minAllowed 10m",
+		Mutate: func(m *Module) {
+			Field(m.Container(), "resources", "requests")["cpu"] = "5m"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "service-wiring",
+		Violation:    "A Service targeting a port name no container declares",
+		Level:        "error",
+		TextContains: `Service targetPort "web" is not a container port name of the selected pods`,
+		Mutate: func(m *Module) {
+			ports, _ := Field(m.Object("Service"), "spec")["ports"].([]any)
+			ports[0].(map[string]any)["targetPort"] = "web"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "network-policy",
+		Violation:    "A NetworkPolicy whose podSelector matches no pod",
+		Level:        "error",
+		TextContains: "NetworkPolicy podSelector does not match any pod template in the module",
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, map[string]any{
+				"apiVersion": "networking.k8s.io/v1",
+				"kind":       "NetworkPolicy",
+				"metadata": map[string]any{
+					"name":      m.Name,
+					"namespace": m.Namespace,
+					"labels":    map[string]any{"heritage": "deckhouse", "module": m.Name},
+				},
+				"spec": map[string]any{
+					"podSelector": map[string]any{"matchLabels": map[string]any{"app": "missing"}},
+					"policyTypes": []any{"Ingress"},
+				},
+			})
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-recommended-labels",
+		Violation:    `An object without the "module" label`,
+		Level:        "error",
+		TextContains: `Object does not have the label "module"`,
+		Mutate: func(m *Module) {
+			delete(Field(m.Object("Deployment"), "metadata", "labels"), "module")
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-priority-class",
+		Violation:    "A Deployment without a priority class",
+		Level:        "error",
+		TextContains: "Priority class must not be empty",
+		Mutate:       func(m *Module) { delete(m.PodSpec(), "priorityClassName") },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-revision-history-limit",
+		Violation:    "A Deployment keeping 10 revisions",
+		Level:        "error",
+		TextContains: "Deployment spec.revisionHistoryLimit must be less or equal to 2",
+		Mutate: func(m *Module) {
+			Field(m.Object("Deployment"), "spec")["revisionHistoryLimit"] = 10
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "controller-security-context",
+		Violation:    "A Deployment without a pod security context",
+		Level:        "error",
+		TextContains: "Object's SecurityContext is not defined",
+		Mutate:       func(m *Module) { delete(m.PodSpec(), "securityContext") },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "pod-security-standards",
+		Violation:    "A Deployment sharing the host PID namespace in a baseline namespace",
+		Level:        "error",
+		TextContains: `control "Host Namespaces": pod sets hostPID to true`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata": map[string]any{
+					"name": m.Namespace,
+					"labels": map[string]any{
+						"heritage":                           "deckhouse",
+						"module":                             m.Name,
+						"pod-security.kubernetes.io/enforce": "baseline",
+					},
+				},
+			})
+			m.PodSpec()["hostPID"] = true
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-references",
+		Violation:    "A container loading its environment from a ConfigMap the module does not render",
+		Level:        "error",
+		TextContains: `ConfigMap "app-config" referenced by envFrom is not rendered by the module`,
+		Mutate: func(m *Module) {
+			m.Container()["envFrom"] = []any{
+				map[string]any{"configMapRef": map[string]any{"name": "app-config"}},
+			}
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "security-context",
+		Violation:    "A container without a security context",
+		Level:        "error",
+		TextContains: "Container ContainerSecurityContext is not defined",
+		Mutate:       func(m *Module) { delete(m.Container(), "securityContext") },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "read-only-root-filesystem",
+		Violation:    "A container with a writable root filesystem",
+		Level:        "error",
+		TextContains: "Container's SecurityContext has `ReadOnlyRootFilesystem: false`",
+		Mutate: func(m *Module) {
+			Field(m.Container(), "securityContext")["readOnlyRootFilesystem"] = false
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "no-new-privileges",
+		Violation:    "A container allowing privilege escalation",
+		Level:        "error",
+		TextContains: "Container's SecurityContext has `AllowPrivilegeEscalation: true`",
+		Mutate: func(m *Module) {
+			Field(m.Container(), "securityContext")["allowPrivilegeEscalation"] = true
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "seccomp-profile",
+		Violation:    "A container with an Unconfined seccomp profile",
+		Level:        "error",
+		TextContains: "Container has seccompProfile.type set to 'Unconfined'",
+		Mutate: func(m *Module) {
+			Field(m.Container(), "securityContext")["seccompProfile"] = map[string]any{"type": "Unconfined"}
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "env-variables-duplicates",
+		Violation:    "A container with two env variables of the same name",
+		Level:        "error",
+		TextContains: "Container has two env variables with same name",
+		Mutate: func(m *Module) {
+			c := m.Container()
+			c["env"] = append(c["env"].([]any), map[string]any{"name": "LOG_LEVEL", "value": "debug"})
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "image-digest",
+		Violation:    "A container image referenced by a tag instead of a digest",
+		Level:        "error",
+		TextContains: "Cannot parse repository from image",
+		Mutate: func(m *Module) {
+			m.Container()["image"] = "registry.example.com/deckhouse:v1.0.0"
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "capabilities",
+		Violation:    "A privileged container",
+		Level:        "error",
+		TextContains: "Container runs privileged",
+		Mutate: func(m *Module) {
+			Field(m.Container(), "securityContext")["privileged"] = true
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "image-policy",
+		Violation:    "A container image of the module registry pinned by a tag instead of a digest",
+		Level:        "error",
+		TextContains: "of the module registry is not pinned by digest",
+		Mutate: func(m *Module) {
+			m.Container()["image"] = "registry.example.com/deckhouse:v1.0.0"
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "ports",
+		Violation:    "A container listening on a privileged port",
+		Level:        "error",
+		TextContains: "Container uses port <= 1024",
+		Mutate: func(m *Module) {
+			ports, _ := m.Container()["ports"].([]any)
+			ports[0].(map[string]any)["containerPort"] = 80
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "resources",
+		Violation:    "A container without an ephemeral storage request",
+		Level:        "error",
+		TextContains: "Ephemeral storage for container is not defined in Resources.Requests",
+		Mutate: func(m *Module) {
+			delete(Field(m.Container(), "resources", "requests"), "ephemeral-storage")
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "liveness-probe",
+		Violation:    "A container without a liveness probe",
+		Level:        "error",
+		TextContains: "Container does not contain liveness-probe",
+		Mutate:       func(m *Module) { delete(m.Container(), "livenessProbe") },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "readiness-probe",
+		Violation:    "A container without a readiness probe",
+		Level:        "error",
+		TextContains: "Container does not contain readiness-probe",
+		Mutate:       func(m *Module) { delete(m.Container(), "readinessProbe") },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "probe-correctness",
+		Violation:    "A liveness probe referring to a port name the container does not declare",
+		Level:        "error",
+		TextContains: `livenessProbe port "metrics" is not a named port of the container`,
+		Mutate: func(m *Module) {
+			m.Container()["livenessProbe"] = map[string]any{
+				"httpGet": map[string]any{"path": "/healthz", "port": "metrics"},
+			}
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "readme",
+		Violation:    "A module without docs/README.md",
+		Level:        "error",
+		TextContains: "README.md file is missing in docs/ directory",
+		Mutate:       func(m *Module) { delete(m.Files, "docs/README.md") },
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "bilingual",
+		Violation:    "An English document without its Russian counterpart",
+		Level:        "error",
+		TextContains: "Russian counterpart is missing",
+		Mutate:       func(m *Module) { delete(m.Files, "docs/README.ru.md") },
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "cyrillic-in-english",
+		Violation:    "English documentation with a Russian sentence",
+		Level:        "error",
+		TextContains: "English documentation contains cyrillic characters",
+		Mutate: func(m *Module) {
+			m.Files["docs/README.md"] += "\nМодуль для e2e-тестов.\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "no-lang-key",
+		Violation:    "A document declaring its language in the front matter",
+		Level:        "error",
+		TextContains: "Documentation contains 'lang' key in front matter",
+		Mutate: func(m *Module) {
+			m.Files["docs/README.md"] = "---\ntitle: The module\nlang: en\n---\n\nAn e2e fixture module.\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "markdownlint",
+		Violation:    "A document with a heading not surrounded by blank lines",
+		Level:        "warn",
+		TextContains: "MD022/blanks-around-headings",
+		Mutate: func(m *Module) {
+			m.Files["docs/USAGE.md"] = "# Usage\nRun the module.\n"
+			m.Files["docs/USAGE.ru.md"] = "# Использование\n\nЗапустите модуль.\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "size",
+		Violation:    "A docs/ directory larger than 15 MB",
+		Level:        "warn",
+		TextContains: "docs/ directory size exceeds the limit of 15.0 MB",
+		Mutate: func(m *Module) {
+			m.Files["docs/images/diagram.svg"] = strings.Repeat("0", 15*1024*1024+1)
+		},
+	})
+	register(&Fixture{
+		Linter:       "documentation",
+		Rule:         "front-matter",
+		Violation:    "A document whose front matter is never closed",
+		Level:        "error",
+		TextContains: "unterminated YAML front matter",
+		Mutate: func(m *Module) {
+			m.Files["docs/README.md"] = "---\ntitle: The module\n\nAn e2e fixture module.\n"
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "rbac",
+		Rule:         "binding-subject",
+		Violation:    "A ClusterRoleBinding to a ServiceAccount the module does not render",
+		Level:        "error",
+		TextContains: "ClusterRoleBinding bind to the wrong ServiceAccount (doesn't exist in the store)",
+		Mutate: func(m *Module) {
+			m.ReplaceInFile("templates/rbac-for-us.yaml", "  - kind: ServiceAccount\n    name: "+m.Name+"\n",
+				"  - kind: ServiceAccount\n    name: missing\n")
+		},
+	})
+	register(&Fixture{
+		Linter:       "rbac",
+		Rule:         "placement",
+		Violation:    "A ClusterRole outside of the rbac-for-us.yaml templates",
+		Level:        "error",
+		TextContains: `ClusterRole should be in "templates/user-authz-cluster-roles.yaml" or "templates/rbac-for-us.yaml"`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, map[string]any{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "ClusterRole",
+				"metadata": map[string]any{
+					"name":   "d8:" + m.Name + ":reader",
+					"labels": map[string]any{"heritage": "deckhouse", "module": m.Name},
+				},
+				"rules": []any{map[string]any{
+					"apiGroups": []any{""},
+					"resources": []any{"pods"},
+					"verbs":     []any{"get"},
+				}},
+			})
+		},
+	})
+	register(&Fixture{
+		Linter:       "rbac",
+		Rule:         "user-authz",
+		Violation:    "A user-authz ClusterRole without an access level",
+		Level:        "error",
+		TextContains: `User-authz access ClusterRoles should have annotation "user-authz.deckhouse.io/access-level"`,
+		Mutate: func(m *Module) {
+			m.Files["templates/user-authz-cluster-roles.yaml"] = "apiVersion: rbac.authorization.k8s.io/v1\n" +
+				"kind: ClusterRole\n" +
+				"metadata:\n" +
+				"  name: d8:user-authz:" + m.Name + ":user\n" +
+				"  labels:\n    heritage: deckhouse\n    module: " + m.Name + "\n" +
+				"rules:\n" +
+				"  - apiGroups: [\"\"]\n    resources: [\"configmaps\"]\n    verbs: [\"get\"]\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "rbac",
+		Rule:         "wildcards",
+		Violation:    "A ClusterRole of the module granting every verb",
+		Level:        "error",
+		TextContains: "verbs contains a wildcards. Replace them with an explicit list of resources",
+		Mutate: func(m *Module) {
+			m.ReplaceInFile("templates/rbac-for-us.yaml", `verbs: ["get", "list", "watch"]`, `verbs: ["*"]`)
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "conversions",
+		Violation:    "A settings conversion without x-config-version in config-values.yaml",
+		Level:        "error",
+		TextContains: "x-config-version is not set in config-values.yaml, but conversions exist",
+		Mutate: func(m *Module) {
+			m.Files["openapi/conversions/v2.yaml"] = "version: 2\nconversions:\n  - del(.obsolete)\ndescription:\n  en: Drop obsolete.\n  ru: Удалить obsolete.\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "definition-file",
+		Violation:    "A module.yaml without a stage",
+		Level:        "error",
+		TextContains: "Field 'stage' is required",
+		Mutate: func(m *Module) {
+			m.ReplaceInFile("module.yaml", "stage: General Availability\n", "")
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "enabled-script",
+		Violation:    "A module enabled by the deprecated enabled script",
+		Level:        "warn",
+		TextContains: "The enabled-script mechanism is deprecated and must be removed.",
+		Mutate: func(m *Module) {
+			m.Files["enabled"] = "#!/bin/bash\n\necho true > $MODULE_ENABLED_RESULT\n"
+			m.Files[".helmignore"] += "enabled\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "helmignore",
+		Violation:    "A module without .helmignore",
+		Level:        "error",
+		TextContains: "File .helmignore is required in module root",
+		Mutate: func(m *Module) {
+			delete(m.Files, ".helmignore")
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "legacy-release-file",
+		Violation:    "A module versioned by release.yaml",
+		Level:        "error",
+		TextContains: "Remove release.yaml. Version need to be defined in 'version.json'.",
+		Mutate: func(m *Module) {
+			m.Files["release.yaml"] = "version: v1.0.0\n"
+			m.Files[".helmignore"] += "release.yaml\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "license",
+		Violation:    "A script without a license header",
+		Level:        "error",
+		TextContains: "no license header found",
+		Mutate: func(m *Module) {
+			m.Files["tools/check.sh"] = "#!/bin/bash\n\nexit 0\n"
+			m.Files[".helmignore"] += "tools\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "module-package-consistency",
+		Violation:    "A package.yaml naming another module than module.yaml",
+		Level:        "error",
+		TextContains: "does not match package.yaml name",
+		Mutate: func(m *Module) {
+			m.Files["package.yaml"] = "apiVersion: v1\nname: other\nrequirements:\n  deckhouse:\n    constraint: \">= 1.68\"\n"
+			m.Files[".helmignore"] += "package.yaml\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "oss",
+		Violation:    "A module with images and an empty oss.yaml",
+		Level:        "error",
+		TextContains: "no projects described",
+		Mutate: func(m *Module) {
+			m.Files["images/app/werf.inc.yaml"] = "---\nimage: {{ .ModuleNamePrefix }}{{ .ImageName }}\nfrom: registry.example.com/base:1.0\n"
+			m.Files["oss.yaml"] = "[]\n"
+			m.Files[".helmignore"] += "images\noss.yaml\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "package-yaml",
+		Violation:    "A package.yaml without an apiVersion",
+		Level:        "error",
+		TextContains: "package.yaml apiVersion is required",
+		Mutate: func(m *Module) {
+			m.Files["package.yaml"] = "name: " + m.Name + "\nrequirements:\n  deckhouse:\n    constraint: \">= 1.68\"\n"
+			m.Files[".helmignore"] += "package.yaml\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "module",
+		Rule:         "requirements",
+		Violation:    "A module with a stage requiring a too old Deckhouse",
+		Level:        "error",
+		TextContains: "deckhouse version range should start no lower than",
+		Mutate: func(m *Module) {
+			m.ReplaceInFile("module.yaml", `deckhouse: ">= 1.68"`, `deckhouse: ">= 1.60"`)
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "bilingual",
+		Violation:    "A CRD without its doc-ru- translation",
+		Level:        "error",
+		TextContains: `translation file is missing: expected "doc-ru-things.yaml" in the same directory`,
+		Mutate: func(m *Module) {
+			m.Files["crds/things.yaml"] = moduleCRD(m.Name, m.Name)
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "deckhouse-crds",
+		Violation:    "A Deckhouse CRD labeled with another module",
+		Level:        "error",
+		TextContains: `CRD should contain "module = `,
+		Mutate: func(m *Module) {
+			m.Files["crds/things.yaml"] = moduleCRD(m.Name, "other")
+			m.Files["crds/doc-ru-things.yaml"] = moduleCRDTranslation
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "deckhouse-validations",
+		Violation:    "An x-deckhouse-validations block that is not a list",
+		Level:        "warn",
+		TextContains: `"x-deckhouse-validations" at "x-deckhouse-validations" must be a list of {expression, message} entries`,
+		Mutate: func(m *Module) {
+			m.Files["openapi/config-values.yaml"] = "type: object\nproperties: {}\n" +
+				"x-deckhouse-validations:\n  expression: self.replicas > 0\n  message: replicas must be positive\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "doc-ru-yaml",
+		Violation:    "A doc-ru- translation that is not valid YAML",
+		Level:        "error",
+		TextContains: "doc-ru file is not valid YAML",
+		Mutate: func(m *Module) {
+			m.Files["openapi/doc-ru-config-values.yaml"] = "properties:\n  logLevel: [\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "enum",
+		Violation:    "A settings enum with a lowercase value",
+		Level:        "error",
+		TextContains: "value 'debug' must start with Capital letter",
+		Mutate: func(m *Module) {
+			m.Files["openapi/config-values.yaml"] = "type: object\nproperties:\n" +
+				"  logLevel:\n    type: string\n    enum: [\"debug\", \"Info\"]\n    default: Info\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "high-availability",
+		Violation:    "A highAvailability setting with a default",
+		Level:        "error",
+		TextContains: "properties.highAvailability is invalid: must have no default value",
+		Mutate: func(m *Module) {
+			m.Files["openapi/config-values.yaml"] = "type: object\nproperties:\n" +
+				"  highAvailability:\n    type: boolean\n    default: false\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "openapi",
+		Rule:         "keys",
+		Violation:    "A CRD enum with a name banned by the linter config",
+		Level:        "error",
+		TextContains: "Forbidden is invalid name for property Forbidden",
+		Mutate: func(m *Module) {
+			m.Files[".dmtlint.yaml"] = "linters-settings:\n  openapi:\n    exclude-rules:\n      key-banned-names: [Forbidden]\n"
+			m.Files[".helmignore"] += ".dmtlint.yaml\n"
+			m.Files["crds/things.yaml"] = strings.Replace(moduleCRD(m.Name, m.Name),
+				"                  type: string\n", "                  type: string\n                  enum: [Allowed, Forbidden]\n", 1)
+			m.Files["crds/doc-ru-things.yaml"] = moduleCRDTranslation
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "images",
+		Rule:         "distroless",
+		Violation:    "A Dockerfile whose final image is not distroless",
+		Level:        "error",
+		TextContains: "Last `FROM` instruction should use one of our $BASE_DISTROLESS images",
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/Dockerfile", "FROM $BASE_UBUNTU\n")
+		},
+	})
+	register(&Fixture{
+		Linter:       "images",
+		Rule:         "dockerfile",
+		Violation:    "A Dockerfile pulling an upstream image instead of a base image",
+		Level:        "error",
+		TextContains: "Please use $BASE_UBUNTU as an image name",
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/Dockerfile", "FROM ubuntu:22.04@sha256:"+strings.Repeat("0", 64)+" AS build\nFROM $BASE_DISTROLESS\n")
+		},
+	})
+	register(&Fixture{
+		Linter:       "images",
+		Rule:         "patches",
+		Violation:    "An image patch without a README",
+		Level:        "error",
+		TextContains: "Patch file should have a corresponding README file",
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/patches/001-fix.patch", "--- a/main.go\n+++ b/main.go\n")
+		},
+	})
+	register(&Fixture{
+		Linter:       "images",
+		Rule:         "werf",
+		Violation:    "A werf image built from an upstream image",
+		Level:        "error",
+		TextContains: "Invalid `fromImage:` value - image should be in format `base/<name>`",
+		Mutate: func(m *Module) {
+			m.Files["werf.yaml"] = "---\nimage: " + m.Name + "/app\nfromImage: ubuntu:22.04\n"
+			m.Files[".helmignore"] += "werf.yaml\n"
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "hooks",
+		Rule:         "ingress",
+		Violation:    "An Ingress in a module without the copy_custom_certificate hook",
+		Level:        "error",
+		TextContains: "Ingress resource exists but module does not have copy_custom_certificate hook",
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, ingress(m, nil))
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "no-cyrillic",
+		Rule:         "files",
+		Violation:    "Cyrillic letters in an English schema",
+		Level:        "error",
+		TextContains: "has cyrillic letters",
+		Mutate: func(m *Module) {
+			m.Files["openapi/config-values.yaml"] = "type: object\ndescription: Настройки модуля.\nproperties: {}\n"
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "cluster-domain",
+		Violation:    "A template hardcoding the cluster.local domain",
+		Level:        "error",
+		TextContains: "File contains hardcoded 'cluster.local' substring",
+		Mutate: func(m *Module) {
+			m.Files["templates/_helpers.tpl"] = `{{- define "app.url" }}http://app.{{ .Release.Namespace }}.svc.cluster.local{{- end }}` + "\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "crd-enabled-modules",
+		Violation:    "A template checking for a removed standalone -crd module",
+		Level:        "warn",
+		TextContains: `Deprecated "cert-manager-crd" reference in .Values.global.enabledModules`,
+		Mutate: func(m *Module) {
+			m.Files["templates/_helpers.tpl"] = `{{- define "app.certificates" }}{{ .Values.global.enabledModules | has "cert-manager-crd" }}{{- end }}` + "\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "global-values",
+		Violation:    "A template referencing a global value the global schema does not define",
+		Level:        "warn",
+		TextContains: "Template references .Values.global.unknownSetting, which is not defined in the global values schema",
+		Mutate: func(m *Module) {
+			m.Files["templates/_helpers.tpl"] = `{{- define "app.setting" }}{{ .Values.global.unknownSetting }}{{- end }}` + "\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "grafana-dashboards",
+		Violation:    "Grafana dashboards not rendered by templates/monitoring.yaml",
+		Level:        "error",
+		TextContains: "The content of the 'templates/monitoring.yaml' should be equal to",
+		Mutate: func(m *Module) {
+			m.Files["monitoring/grafana-dashboards/main.json"] = `{"title": "Main", "panels": [], "templating": {"list": [` +
+				`{"name": "ds_prometheus", "type": "datasource", "query": "prometheus"}]}}` + "\n"
+			m.Files["templates/monitoring.yaml"] = "{{- /* monitoring */ -}}\n"
+			m.Files[".helmignore"] += "monitoring\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "httproute-rules",
+		Violation:    "An Ingress without the HTTPRoute replacing it",
+		Level:        "error",
+		TextContains: `Ingress "app" ships in this module, but the corresponding Gateway API resources are missing`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, ingress(m, nil))
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "ingress-rules",
+		Violation:    "An Ingress configuration snippet without the HSTS header",
+		Level:        "error",
+		TextContains: `Ingress annotation "nginx.ingress.kubernetes.io/configuration-snippet" does not contain required snippet`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, ingress(m, map[string]any{
+				"nginx.ingress.kubernetes.io/configuration-snippet": "more_set_headers \"X-Frame-Options: DENY\";\n",
+			}))
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "kube-rbac-proxy",
+		Violation:    "A system namespace without the kube-rbac-proxy CA certificate",
+		Level:        "error",
+		TextContains: "All system namespaces should contain kube-rbac-proxy CA certificate.",
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, namespace(m))
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "mount-points",
+		Violation:    "A mount-points.yaml directory no container mounts",
+		Level:        "warn",
+		TextContains: `mount-points.yaml references dir "/var/lib/app" which is not used as a mountPath in any pod controller`,
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/mount-points.yaml", "dirs:\n  - /var/lib/app\n")
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "prometheus-rules",
+		Violation:    "Prometheus rules not rendered by templates/monitoring.yaml",
+		Level:        "error",
+		TextContains: "The content of the 'templates/monitoring.yaml' should be equal to",
+		Mutate: func(m *Module) {
+			m.Files["monitoring/prometheus-rules/app.yaml"] = "- name: app\n  rules:\n    - alert: AppDown\n      expr: up{job=\"app\"} == 0\n"
+			m.Files["templates/monitoring.yaml"] = "{{- /* monitoring */ -}}\n"
+			m.Files[".helmignore"] += "monitoring\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "registry",
+		Violation:    "A registry secret using the global registry credentials only",
+		Level:        "error",
+		TextContains: "registry-secret.yaml file contains .Values.global.modulesImages.registry.dockercfg but missing",
+		Mutate: func(m *Module) {
+			m.Files["templates/registry-secret.yaml"] = "{{- if .Values.global.modulesImages.registry.dockercfg }}\n" +
+				"apiVersion: v1\nkind: Secret\nmetadata:\n  name: registry-secret\n  namespace: " + m.Namespace + "\n" +
+				"  labels:\n    heritage: deckhouse\n    module: " + m.Name + "\n" +
+				"type: kubernetes.io/dockerconfigjson\ndata:\n  .dockerconfigjson: {{ .Values.global.modulesImages.registry.dockercfg }}\n" +
+				"{{- end }}\n"
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "webhook-configuration-annotations",
+		Violation:    "A webhook configuration without a deploy order",
+		Level:        "error",
+		TextContains: `ValidatingWebhookConfiguration "d8-`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, map[string]any{
+				"apiVersion": "admissionregistration.k8s.io/v1",
+				"kind":       "ValidatingWebhookConfiguration",
+				"metadata": map[string]any{
+					"name":   "d8-" + m.Name,
+					"labels": map[string]any{"heritage": "deckhouse", "module": m.Name},
+				},
+				"webhooks": []any{map[string]any{
+					"name":                    "validate." + m.Name + ".deckhouse.io",
+					"admissionReviewVersions": []any{"v1"},
+					"sideEffects":             "None",
+					"clientConfig":            map[string]any{"url": "https://validate.example.com/"},
+				}},
+			})
+		},
+	})
+	register(&Fixture{
+		Linter:       "templates",
+		Rule:         "werf",
+		Violation:    "A module image named with an underscore",
+		Level:        "error",
+		TextContains: `Image name "app_tool" in images/app/werf.inc.yaml (document 1) must not contain underscores`,
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/werf.inc.yaml", "---\nimage: app_tool\nfromImage: base/distroless\n")
+		},
+	})
+
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "container-image-name",
+		Violation:    "A container image named with an underscore",
+		Level:        "error",
+		TextContains: `Image name "app_tool" must not contain underscores`,
+		Mutate: func(m *Module) {
+			deployment := m.Object("Deployment")
+			m.Remove("Deployment")
+
+			data, _ := marshal(deployment)
+			m.Files["templates/app.yaml"] = `# image: {{ include "helm_lib_module_image" (list . "app_tool") }}` + "\n" + string(data)
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "dns-policy",
+		Violation:    "A hostNetwork pod resolving names through the host DNS",
+		Level:        "error",
+		TextContains: "dnsPolicy must be `ClusterFirstWithHostNet` when hostNetwork is `true`",
+		Mutate: func(m *Module) {
+			m.PodSpec()["hostNetwork"] = true
+			ports, _ := m.Container()["ports"].([]any)
+			ports[0].(map[string]any)["containerPort"] = 4201
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "host-network-ports",
+		Violation:    "A container publishing a host port outside of the module range",
+		Level:        "error",
+		TextContains: "Container uses hostPort that doesn't fit the range [4200,4299]",
+		Mutate: func(m *Module) {
+			ports, _ := m.Container()["ports"].([]any)
+			ports[0].(map[string]any)["hostPort"] = 8080
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "image-pull-policy",
+		Violation:    "A container never pulling its image",
+		Level:        "error",
+		TextContains: `Container imagePullPolicy should be unspecified or "IfNotPresent"`,
+		Mutate:       func(m *Module) { m.Container()["imagePullPolicy"] = "Never" },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "mount-points",
+		Violation:    "A container mounting a directory mount-points.yaml does not declare",
+		Level:        "warn",
+		TextContains: `Container "app" mountPath "/cache" is not declared in any mount-points.yaml`,
+		Mutate: func(m *Module) {
+			addImageFile(m, "app/mount-points.yaml", "dirs:\n  - /data\n")
+			m.PodSpec()["volumes"] = []any{
+				map[string]any{"name": "data", "emptyDir": map[string]any{}},
+				map[string]any{"name": "cache", "emptyDir": map[string]any{}},
+			}
+			m.Container()["volumeMounts"] = []any{
+				map[string]any{"name": "data", "mountPath": "/data"},
+				map[string]any{"name": "cache", "mountPath": "/cache"},
+			}
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "name-duplicates",
+		Violation:    "A pod with two containers of the same name",
+		Level:        "error",
+		TextContains: "Duplicate container name",
+		Mutate: func(m *Module) {
+			spec := m.PodSpec()
+			spec["containers"] = append(spec["containers"].([]any), maps.Clone(m.Container()))
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-api-version",
+		Violation:    "A Deployment of a removed API version",
+		Level:        "error",
+		TextContains: `Object defined using deprecated api version, wanted "apps/v1"`,
+		Mutate:       func(m *Module) { m.Object("Deployment")["apiVersion"] = "apps/v1beta2" },
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "object-namespace-labels",
+		Violation:    "A namespace with Prometheus rules not watched by the rules watcher",
+		Level:        "error",
+		TextContains: `Namespace object does not have the label "prometheus.deckhouse.io/rules-watcher-enabled"`,
+		Mutate: func(m *Module) {
+			m.Objects = append(m.Objects, namespace(m), kubeRBACProxyCA(m), map[string]any{
+				"apiVersion": "monitoring.coreos.com/v1",
+				"kind":       "PrometheusRule",
+				"metadata": map[string]any{
+					"name":      m.Name,
+					"namespace": m.Namespace,
+					"labels":    map[string]any{"heritage": "deckhouse", "module": m.Name},
+				},
+				"spec": map[string]any{"groups": []any{map[string]any{
+					"name": m.Name,
+					"rules": []any{map[string]any{
+						"alert": "AppDown",
+						"expr":  `up{job="app"} == 0`,
+					}},
+				}}},
+			})
+		},
+	})
+	register(&Fixture{
+		Linter:       "container",
+		Rule:         "sys-cgroup-mount",
+		Violation:    "A container mounting /sys without /sys/fs/cgroup",
+		Level:        "warn",
+		TextContains: `Container "app" mounts "/sys" but not "/sys/fs/cgroup"`,
+		Mutate: func(m *Module) {
+			m.PodSpec()["volumes"] = []any{
+				map[string]any{"name": "sys", "hostPath": map[string]any{"path": "/sys"}},
+			}
+			m.Container()["volumeMounts"] = []any{
+				map[string]any{"name": "sys", "mountPath": "/sys", "readOnly": true},
+			}
+		},
+	})
+}
+
+// addImageFile adds a file under images/ together with the oss.yaml such a
+// module must describe its third-party projects in.
+func addImageFile(m *Module, name, content string) {
+	m.Files["images/"+name] = content
+	m.Files["oss.yaml"] = "- id: example/app\n  name: App\n  description: An example project.\n" +
+		"  link: https://github.com/example/app\n  license: Apache License 2.0\n  version: 1.0.0\n"
+	m.Files[".helmignore"] += "images\noss.yaml\n"
+}
+
+// namespace returns the namespace object of the module.
+func namespace(m *Module) map[string]any {
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]any{
+			"name":   m.Namespace,
+			"labels": map[string]any{"heritage": "deckhouse", "module": m.Name},
+		},
+	}
+}
+
+// kubeRBACProxyCA returns the kube-rbac-proxy CA ConfigMap every system
+// namespace must contain.
+func kubeRBACProxyCA(m *Module) map[string]any {
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "kube-rbac-proxy-ca.crt",
+			"namespace": m.Namespace,
+			"labels":    map[string]any{"heritage": "deckhouse", "module": m.Name},
+		},
+		"data": map[string]any{"ca.crt": "certificate"},
+	}
+}
+
+// ingress returns an Ingress to the Service of the baseline module.
+func ingress(m *Module, annotations map[string]any) map[string]any {
+	metadata := map[string]any{
+		"name":      "app",
+		"namespace": m.Namespace,
+		"labels":    map[string]any{"heritage": "deckhouse", "module": m.Name, "app": "app"},
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+
+	return map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   metadata,
+		"spec": map[string]any{
+			"ingressClassName": "nginx",
+			"rules": []any{map[string]any{
+				"host": m.Name + ".example.com",
+				"http": map[string]any{"paths": []any{map[string]any{
+					"path":     "/",
+					"pathType": "Prefix",
+					"backend": map[string]any{"service": map[string]any{
+						"name": "app",
+						"port": map[string]any{"name": "http"},
+					}},
+				}}},
+			}},
+		},
+	}
+}
+
+// moduleCRD returns a Deckhouse CRD of module moduleName labeled as belonging
+// to labelModule.
+func moduleCRD(moduleName, labelModule string) string {
+	return `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: things.deckhouse.io
+  labels:
+    heritage: deckhouse
+    module: ` + labelModule + `
+spec:
+  group: deckhouse.io
+  scope: Cluster
+  names:
+    plural: things
+    singular: thing
+    kind: Thing
+  versions:
+    - name: v1alpha1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          description: A thing of the ` + moduleName + ` module.
+          properties:
+            spec:
+              type: object
+              properties:
+                mode:
+                  type: string
+`
+}
+
+// moduleCRDTranslation is the doc-ru- translation of moduleCRD.
+const moduleCRDTranslation = `spec:
+  versions:
+    - name: v1alpha1
+      schema:
+        openAPIV3Schema:
+          description: Объект модуля.
+`
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures generates e2e cases for lint rules. Every rule in the
+// catalog declares how to violate it: a mutation of a well-formed baseline
+// module and the finding the mutated module must produce. From that, a pair
+// of cases in the expected.yaml format of the e2e framework is generated: a
+// failing case that expects the finding and a passing case (the baseline) that
+// expects the rule to pass.
+package fixtures
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	failSuffix = "-fail"
+	passSuffix = "-pass"
+)
+
+// Fixture declares how a rule is violated.
+type Fixture struct {
+	Linter string
+	Rule   string
+	// Violation describes the mutated module, e.g. "A Deployment without a
+	// VerticalPodAutoscaler".
+	Violation string
+	// Level and TextContains select the expected finding; see Finding.
+	Level        string
+	TextContains string
+	// Mutate turns the baseline module into one violating the rule.
+	Mutate func(m *Module)
+}
+
+// ID returns the "<linter>/<rule>" identifier of the fixture.
+func (f *Fixture) ID() string {
+	return f.Linter + "/" + f.Rule
+}
+
+var catalog = map[string]*Fixture{}
+
+// register adds a fixture to the catalog.
+func register(f *Fixture) {
+	if _, ok := catalog[f.ID()]; ok {
+		panic(fmt.Sprintf("fixture %s is registered twice", f.ID()))
+	}
+
+	catalog[f.ID()] = f
+}
+
+// Lookup returns the fixture of a "<linter>/<rule>" identifier.
+func Lookup(id string) (*Fixture, error) {
+	f, ok := catalog[id]
+	if !ok {
+		return nil, fmt.Errorf("no fixture for %q, known rules: %s", id, strings.Join(IDs(), ", "))
+	}
+
+	return f, nil
+}
+
+// All returns the catalog sorted by identifier.
+func All() []*Fixture {
+	ids := IDs()
+
+	result := make([]*Fixture, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, catalog[id])
+	}
+
+	return result
+}
+
+// IDs returns the sorted identifiers of the catalog.
+func IDs() []string {
+	ids := make([]string, 0, len(catalog))
+	for id := range catalog {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Finding is an expected finding of a generated case, in the expected.yaml
+// format of the e2e framework (test/e2e.Finding).
+type Finding struct {
+	Linter       string `yaml:"linter,omitempty"`
+	Rule         string `yaml:"rule,omitempty"`
+	Level        string `yaml:"level,omitempty"`
+	TextContains string `yaml:"textContains,omitempty"`
+}
+
+// Spec is the expected.yaml of a generated case: the subset of the e2e
+// framework's test/e2e.CaseSpec the generator fills in.
+type Spec struct {
+	Description string    `yaml:"description,omitempty"`
+	Expect      []Finding `yaml:"expect,omitempty"`
+	ExpectPass  []Finding `yaml:"expectPass,omitempty"`
+}
+
+// Case is a generated e2e case.
+type Case struct {
+	// Name is the case directory name, e.g. "vpa-fail".
+	Name   string
+	Spec   *Spec
+	Module *Module
+}
+
+// Cases returns the failing and the passing case of the fixture.
+func (f *Fixture) Cases() ([]Case, error) {
+	failing, err := Baseline(moduleName(f.Rule + failSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	f.Mutate(failing)
+
+	passing, err := Baseline(moduleName(f.Rule + passSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	finding := Finding{Linter: f.Linter, Rule: f.Rule}
+
+	expected := finding
+	expected.Level = f.Level
+	expected.TextContains = f.TextContains
+
+	return []Case{
+		{
+			Name: f.Rule + failSuffix,
+			Spec: &Spec{
+				Description: fmt.Sprintf("Generated by `dmt dev gen-fixture %s`. %s must be flagged by the %s %s rule.",
+					f.ID(), f.Violation, f.Linter, f.Rule),
+				Expect: []Finding{expected},
+			},
+			Module: failing,
+		},
+		{
+			Name: f.Rule + passSuffix,
+			Spec: &Spec{
+				Description: fmt.Sprintf("Generated by `dmt dev gen-fixture %s`. The well-formed baseline module must pass the %s %s rule.",
+					f.ID(), f.Linter, f.Rule),
+				ExpectPass: []Finding{finding},
+			},
+			Module: passing,
+		},
+	}, nil
+}
+
+// moduleName turns a case name into a valid module name.
+func moduleName(caseName string) string {
+	return "e2e-" + caseName
+}
+
+// Write generates the cases of the fixture under root/<linter>/ and returns
+// their directories. Existing cases are only replaced when force is set.
+func (f *Fixture) Write(root string, force bool) ([]string, error) {
+	cases, err := f.Cases()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(cases))
+
+	for i := range cases {
+		dir := filepath.Join(root, f.Linter, cases[i].Name)
+
+		if _, err := os.Stat(dir); err == nil {
+			if !force {
+				return nil, fmt.Errorf("case %s already exists, use --force to replace it", dir)
+			}
+
+			if err := os.RemoveAll(dir); err != nil {
+				return nil, fmt.Errorf("remove case %s: %w", dir, err)
+			}
+		}
+
+		if err := WriteCase(dir, &cases[i]); err != nil {
+			return nil, err
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	return dirs, nil
+}
+
+// WriteCase writes expected.yaml and the module of the case into dir.
+func WriteCase(dir string, c *Case) error {
+	spec, err := marshal(c.Spec)
+	if err != nil {
+		return fmt.Errorf("encode expected.yaml: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create case %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "expected.yaml"), spec, 0o600); err != nil {
+		return fmt.Errorf("write expected.yaml: %w", err)
+	}
+
+	if err := c.Module.WriteTo(filepath.Join(dir, "module")); err != nil {
+		return fmt.Errorf("write module: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/test/e2e"
+)
+
+func TestEveryFixtureMutatesTheBaseline(t *testing.T) {
+	require.NotEmpty(t, All())
+
+	for _, f := range All() {
+		t.Run(f.ID(), func(t *testing.T) {
+			cases, err := f.Cases()
+			require.NoError(t, err)
+			require.Len(t, cases, 2)
+
+			failing, passing := cases[0], cases[1]
+			assert.Equal(t, f.Rule+"-fail", failing.Name)
+			assert.Equal(t, f.Rule+"-pass", passing.Name)
+
+			require.Len(t, failing.Spec.Expect, 1)
+			assert.Equal(t, Finding{Linter: f.Linter, Rule: f.Rule, Level: f.Level, TextContains: f.TextContains}, failing.Spec.Expect[0])
+			assert.Equal(t, []Finding{{Linter: f.Linter, Rule: f.Rule}}, passing.Spec.ExpectPass)
+
+			baseline, err := Baseline(failing.Module.Name)
+			require.NoError(t, err)
+
+			changed := !reflect.DeepEqual(failing.Module.Objects, baseline.Objects) ||
+				!reflect.DeepEqual(failing.Module.Files, baseline.Files)
+			assert.True(t, changed, "mutation must change the module")
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	f, err := Lookup("templates/vpa")
+	require.NoError(t, err)
+	assert.Equal(t, "vpa", f.Rule)
+
+	_, err = Lookup("templates/unknown")
+	require.ErrorContains(t, err, `no fixture for "templates/unknown"`)
+}
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+
+	f, err := Lookup("templates/vpa")
+	require.NoError(t, err)
+
+	dirs, err := f.Write(root, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "templates", "vpa-fail"),
+		filepath.Join(root, "templates", "vpa-pass"),
+	}, dirs)
+
+	spec, err := e2e.LoadCaseSpec(dirs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "module", spec.Module)
+	assert.Equal(t, e2e.KindLint, spec.Kind)
+	assert.Equal(t, []e2e.Finding{{Linter: "templates", Rule: "vpa", Level: "error", TextContains: "No VPA is found for object"}}, spec.Expect)
+
+	objects, err := os.ReadFile(filepath.Join(dirs[0], "module", "templates", "objects.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(objects), "VerticalPodAutoscaler")
+	assert.Contains(t, string(objects), "namespace: d8-e2e-vpa-fail\n")
+
+	for _, name := range []string{"module.yaml", "openapi/config-values.yaml", "openapi/values.yaml"} {
+		assert.FileExists(t, filepath.Join(dirs[1], "module", filepath.FromSlash(name)))
+	}
+
+	_, err = f.Write(root, false)
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = f.Write(root, true)
+	require.NoError(t, err)
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// baselineObjects is a minimal, well-formed workload: a Deployment with its
+// VerticalPodAutoscaler, PodDisruptionBudget and Service. It passes every rule
+// in the catalog; each fixture mutates it to violate exactly one.
+const baselineObjects = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: NAMESPACE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+spec:
+  replicas: 2
+  revisionHistoryLimit: 2
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      priorityClassName: cluster-medium
+      serviceAccountName: MODULE
+      affinity:
+        podAntiAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            - topologyKey: kubernetes.io/hostname
+              labelSelector:
+                matchLabels:
+                  app: app
+      securityContext:
+        runAsNonRoot: true
+        runAsUser: 64535
+        runAsGroup: 64535
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+        - name: app
+          image: registry.example.com/deckhouse@sha256:0000000000000000000000000000000000000000000000000000000000000000
+          env:
+            - name: LOG_LEVEL
+              value: info
+          ports:
+            - name: http
+              containerPort: 8080
+          securityContext:
+            readOnlyRootFilesystem: true
+            allowPrivilegeEscalation: false
+            capabilities:
+              drop:
+                - ALL
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+          readinessProbe:
+            httpGet:
+              path: /ready
+              port: http
+          resources:
+            requests:
+              cpu: 10m
+              memory: 32Mi
+              ephemeral-storage: 50Mi
+---
+apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: app
+  namespace: NAMESPACE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app
+  updatePolicy:
+    updateMode: InPlaceOrRecreate
+  resourcePolicy:
+    containerPolicies:
+      - containerName: app
+        minAllowed:
+          cpu: 10m
+          memory: 32Mi
+        maxAllowed:
+          cpu: 100m
+          memory: 128Mi
+---
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: app
+  namespace: NAMESPACE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+spec:
+  maxUnavailable: 1
+  selector:
+    matchLabels:
+      app: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: NAMESPACE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+spec:
+  selector:
+    app: app
+  ports:
+    - name: http
+      port: 80
+      targetPort: http
+`
+
+// baselineRBAC is the templates/rbac-for-us.yaml of the baseline module: the
+// ServiceAccount of the Deployment with a ClusterRole bound to it.
+const baselineRBAC = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: MODULE
+  namespace: NAMESPACE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: d8:MODULE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+rules:
+  - apiGroups: [""]
+    resources: ["configmaps"]
+    verbs: ["get", "list", "watch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: d8:MODULE
+  labels:
+    heritage: deckhouse
+    module: MODULE
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: d8:MODULE
+subjects:
+  - kind: ServiceAccount
+    name: MODULE
+    namespace: NAMESPACE
+`
+
+// baselineModuleYAML is the module.yaml of the baseline module.
+const baselineModuleYAML = `name: MODULE
+namespace: d8-MODULE
+stage: General Availability
+weight: 910
+descriptions:
+  en: An e2e fixture module.
+requirements:
+  deckhouse: ">= 1.68"
+`
+
+// baselineFiles returns the files of the well-formed module named name other
+// than its objects.
+func baselineFiles(name string) map[string]string {
+	return map[string]string{
+		"module.yaml":                strings.ReplaceAll(baselineModuleYAML, "MODULE", name),
+		"templates/rbac-for-us.yaml": strings.NewReplacer("NAMESPACE", "d8-"+name, "MODULE", name).Replace(baselineRBAC),
+		".helmignore":                "crds\ndocs\nopenapi\nmodule.yaml\n",
+		"docs/README.md":             "---\ntitle: The module\n---\n\nAn e2e fixture module.\n",
+		"docs/README.ru.md":          "---\ntitle: Модуль\n---\n\nМодуль для e2e-тестов.\n",
+		"openapi/config-values.yaml": "type: object\nproperties: {}\n",
+		"openapi/values.yaml":        "x-extend:\n  schema: config-values.yaml\ntype: object\nproperties: {}\n",
+	}
+}
+
+// Module is an in-memory module fixture: the objects of templates/objects.yaml
+// and the module's other files (module.yaml, openapi schemas, docs, ...).
+type Module struct {
+	Name      string
+	Namespace string
+	Objects   []map[string]any
+	// Files maps module-relative, slash-separated paths to their content.
+	Files map[string]string
+}
+
+// Baseline returns the well-formed module named name.
+func Baseline(name string) (*Module, error) {
+	m := &Module{Name: name, Namespace: "d8-" + name, Files: baselineFiles(name)}
+
+	text := strings.NewReplacer("NAMESPACE", m.Namespace, "MODULE", name).Replace(baselineObjects)
+
+	dec := yaml.NewDecoder(strings.NewReader(text))
+	for {
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("decode baseline objects: %w", err)
+		}
+
+		m.Objects = append(m.Objects, obj)
+	}
+
+	return m, nil
+}
+
+// Object returns the first object of the kind, or nil.
+func (m *Module) Object(kind string) map[string]any {
+	for _, obj := range m.Objects {
+		if obj["kind"] == kind {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// Remove drops the objects of the kind.
+func (m *Module) Remove(kind string) {
+	kept := m.Objects[:0]
+
+	for _, obj := range m.Objects {
+		if obj["kind"] != kind {
+			kept = append(kept, obj)
+		}
+	}
+
+	m.Objects = kept
+}
+
+// ReplaceInFile replaces old with new in a file of the module. It panics when
+// the file does not contain old, so a fixture cannot silently stop mutating
+// the baseline.
+func (m *Module) ReplaceInFile(name, old, new string) {
+	content, ok := m.Files[name]
+	if !ok || !strings.Contains(content, old) {
+		panic(fmt.Sprintf("%s of the baseline module does not contain %q", name, old))
+	}
+
+	m.Files[name] = strings.Replace(content, old, new, 1)
+}
+
+// PodSpec returns the pod template spec of the Deployment.
+func (m *Module) PodSpec() map[string]any {
+	return Field(m.Object("Deployment"), "spec", "template", "spec")
+}
+
+// Container returns the first container of the Deployment.
+func (m *Module) Container() map[string]any {
+	containers, _ := m.PodSpec()["containers"].([]any)
+	if len(containers) == 0 {
+		return nil
+	}
+
+	container, _ := containers[0].(map[string]any)
+
+	return container
+}
+
+// Field returns the nested map at path, or nil when a step is missing.
+func Field(obj map[string]any, path ...string) map[string]any {
+	for _, key := range path {
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		obj = next
+	}
+
+	return obj
+}
+
+// WriteTo writes the module files into dir.
+func (m *Module) WriteTo(dir string) error {
+	var objects bytes.Buffer
+
+	for i, obj := range m.Objects {
+		if i > 0 {
+			objects.WriteString("---\n")
+		}
+
+		data, err := marshal(obj)
+		if err != nil {
+			return fmt.Errorf("encode %v: %w", obj["kind"], err)
+		}
+
+		objects.Write(data)
+	}
+
+	files := make(map[string]string, len(m.Files)+1)
+	for name, content := range m.Files {
+		files[name] = content
+	}
+
+	files["templates/objects.yaml"] = objects.String()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshal encodes v as YAML with the two-space indentation of the testdata.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
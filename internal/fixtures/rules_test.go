@@ -0,0 +1,231 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lintersDir is pkg/linters relative to this package.
+const lintersDir = "../../pkg/linters"
+
+// switchedOffRules are rules no module can violate in a full lint, so no fixture
+// exists for them, with the reason.
+var switchedOffRules = map[string]string{
+	"templates/enabled-modules": "the check is switched off for the migration period (see rules/enabled_modules.go)",
+	"templates/helm-render": "it repeats the tolerant render of the module load, whose failure already stops the lint " +
+		"with \"cannot create module\" (see testdata/templates/helm-render-broken)",
+}
+
+// TestCatalogCoversEveryRule fails for every lint rule without a fixture, so a
+// new rule cannot land without its generated e2e cases.
+func TestCatalogCoversEveryRule(t *testing.T) {
+	rules := registeredRules(t)
+	require.NotEmpty(t, rules)
+
+	var missing []string
+
+	for _, id := range rules {
+		if _, ok := catalog[id]; !ok {
+			if _, off := switchedOffRules[id]; !off {
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	assert.Empty(t, missing, "rules without a fixture in catalog.go")
+
+	for _, id := range IDs() {
+		assert.Contains(t, rules, id, "fixture of an unknown rule")
+	}
+}
+
+// registeredRules walks the linters under pkg/linters and returns the
+// "<linter>/<rule>" identifier of every rule they declare: the Name of each
+// pkg.RuleMeta literal, under the ID the linter passes to WithLinterID.
+func registeredRules(t *testing.T) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(lintersDir)
+	require.NoError(t, err)
+
+	seen := map[string]struct{}{}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		files := parseLinter(t, filepath.Join(lintersDir, entry.Name()))
+		consts := stringConsts(files)
+
+		var linterID string
+
+		for _, f := range files {
+			ast.Inspect(f.file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) != 1 {
+					return true
+				}
+
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "WithLinterID" {
+					linterID = stringValue(call.Args[0], consts[f.dir])
+				}
+
+				return true
+			})
+		}
+
+		require.NotEmpty(t, linterID, "linter %s has no WithLinterID call", entry.Name())
+
+		for _, f := range files {
+			ast.Inspect(f.file, func(n ast.Node) bool {
+				lit, ok := n.(*ast.CompositeLit)
+				if !ok || !isRuleMeta(lit.Type) {
+					return true
+				}
+
+				for _, elt := range lit.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+
+					if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Name" {
+						name := stringValue(kv.Value, consts[f.dir])
+						require.NotEmpty(t, name, "rule name in %s is not a string constant", f.path)
+
+						seen[linterID+"/"+name] = struct{}{}
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	rules := make([]string, 0, len(seen))
+	for id := range seen {
+		rules = append(rules, id)
+	}
+
+	sort.Strings(rules)
+
+	return rules
+}
+
+type linterFile struct {
+	path, dir string
+	file      *ast.File
+}
+
+// parseLinter parses the non-test Go files of a linter, its rules included.
+func parseLinter(t *testing.T, root string) []linterFile {
+	t.Helper()
+
+	var files []linterFile
+
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, linterFile{path: path, dir: filepath.Dir(path), file: f})
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return files
+}
+
+// stringConsts returns the package-level string constants of every package
+// directory of the files.
+func stringConsts(files []linterFile) map[string]map[string]string {
+	consts := map[string]map[string]string{}
+
+	for _, f := range files {
+		if consts[f.dir] == nil {
+			consts[f.dir] = map[string]string{}
+		}
+
+		for _, decl := range f.file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						if s := stringValue(vs.Values[i], nil); s != "" {
+							consts[f.dir][name.Name] = s
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return consts
+}
+
+// stringValue returns the value of a string literal or of a constant of the
+// package, or "".
+func stringValue(expr ast.Expr, consts map[string]string) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if s, err := strconv.Unquote(e.Value); err == nil {
+			return s
+		}
+	case *ast.Ident:
+		return consts[e.Name]
+	}
+
+	return ""
+}
+
+func isRuleMeta(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkgIdent, ok := sel.X.(*ast.Ident)
+
+	return ok && pkgIdent.Name == "pkg" && sel.Sel.Name == "RuleMeta"
+}
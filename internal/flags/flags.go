@@ -36,6 +36,7 @@ var (
 	PrintVersion      bool
 	Version           string
 	ValuesFile        string
+	GlobalValues      string
 	PprofFile         string
 	HideWarnings      bool
 	AbsPath           bool
@@ -44,6 +45,11 @@ var (
 	Fix               bool
 )
 
+var (
+	TestRun      string
+	TestFailFast bool
+)
+
 var (
 	BootstrapRepositoryType string
 	BootstrapRepositoryURL  string
@@ -68,6 +74,7 @@ func InitLintFlagSet() *pflag.FlagSet {
 	lint.StringVarP(&LogLevel, "log-level", "l", "INFO", "log-level [DEBUG | INFO | WARN | ERROR]")
 	lint.StringVarP(&ValuesFile, "values-file", "f", "", "path to values.yaml file with override values")
 	lint.StringVarP(&PprofFile, "pprof-file", "", "", "path to pprof file")
+	lint.AddFlagSet(InitGlobalValuesFlagSet())
 
 	// automatically fix findings that support autofix
 	lint.BoolVarP(&Fix, "fix", "", false, "automatically fix findings that support autofix")
@@ -106,6 +113,28 @@ func InitLintFlagSet() *pflag.FlagSet {
 	return lint
 }
 
+// InitGlobalValuesFlagSet returns the --global-values flag shared by the
+// commands that render modules.
+func InitGlobalValuesFlagSet() *pflag.FlagSet {
+	globalValues := pflag.NewFlagSet("global-values", pflag.ContinueOnError)
+
+	globalValues.StringVar(&GlobalValues, "global-values", "",
+		"directory with the global values schema (config-values.yaml and values.yaml, or a deckhouse repository root) to render modules with")
+
+	return globalValues
+}
+
+func InitTestFlagSet() *pflag.FlagSet {
+	test := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	test.IntVarP(&LintersLimit, "parallel", "p", numThreads, "number of modules to test in parallel")
+	test.StringVar(&TestRun, "run", "", "run only the testcases matching the regular expression, split by '/' into <tester>/<module>/<case>")
+	test.BoolVar(&TestFailFast, "fail-fast", false, "stop after the first failure")
+	test.AddFlagSet(InitGlobalValuesFlagSet())
+
+	return test
+}
+
 func InitBootstrapFlagSet() *pflag.FlagSet {
 	bootstrap := pflag.NewFlagSet("bootstrap", pflag.ContinueOnError)
 
@@ -44,6 +44,30 @@ func IsFile(path string) bool {
 	return err == nil && !fi.IsDir()
 }
 
+// GetRootDirectory walks up from dir looking for a deckhouse repository root
+// (one that ships global-hooks/openapi values) and returns it, or "" when dir is
+// not inside one. Callers use its global values instead of the embedded
+// defaults.
+func GetRootDirectory(dir string) string {
+	for {
+		if IsDir(filepath.Join(dir, "global-hooks", "openapi")) &&
+			IsDir(filepath.Join(dir, "modules")) &&
+			IsFile(filepath.Join(dir, "global-hooks", "openapi", "config-values.yaml")) &&
+			IsFile(filepath.Join(dir, "global-hooks", "openapi", "values.yaml")) {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == parent || parent == "" {
+			break
+		}
+
+		dir = parent
+	}
+
+	return ""
+}
+
 // Getwd returns the current working directory.
 func Getwd() (string, error) {
 	var (
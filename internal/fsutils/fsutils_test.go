@@ -43,6 +43,25 @@ func TestIsFile(t *testing.T) {
 	assert.False(t, IsFile(tempDir), "Expected IsFile to return false for a directory")
 }
 
+func TestGetRootDirectory(t *testing.T) {
+	root := t.TempDir()
+	openapiDir := filepath.Join(root, "global-hooks", "openapi")
+	moduleDir := filepath.Join(root, "modules", "010-test")
+
+	require.NoError(t, os.MkdirAll(openapiDir, 0755))
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+
+	assert.Empty(t, GetRootDirectory(moduleDir), "a root without the global values files is not a deckhouse root")
+
+	for _, name := range []string{"config-values.yaml", "values.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(openapiDir, name), []byte("type: object\n"), 0600))
+	}
+
+	assert.Equal(t, root, GetRootDirectory(moduleDir))
+	assert.Equal(t, root, GetRootDirectory(root))
+	assert.Empty(t, GetRootDirectory(t.TempDir()))
+}
+
 func TestGetwd(t *testing.T) {
 	wd, err := Getwd()
 	require.NoError(t, err, "Getwd returned an error")
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitutils
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	repo := t.TempDir()
+
+	_, err := Run(repo, "init", "-q")
+	require.NoError(t, err)
+
+	topLevel, err := Run(repo, "rev-parse", "--show-toplevel")
+	require.NoError(t, err)
+
+	resolved, err := filepath.EvalSymlinks(repo)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, strings.TrimSpace(topLevel))
+
+	_, err = Run(repo, "rev-parse", "--verify", "no-such-ref")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git rev-parse: ")
+	assert.Contains(t, err.Error(), "Needed a single revision", "the error carries git's stderr")
+}
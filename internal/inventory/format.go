@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats accepted by Write.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// CheckFormat returns an error unless format is one Write accepts.
+func CheckFormat(format string) error {
+	switch format {
+	case FormatTable, "", FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown inventory format %q (want %s or %s)", format, FormatTable, FormatJSON)
+	}
+}
+
+// Write writes the inventory in the given format (table or json).
+func Write(w io.Writer, inv *Inventory, format string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(inv)
+	}
+
+	return WriteTable(w, inv)
+}
+
+// WriteTable writes one row per object followed by a per-module summary and
+// the privileged surface of the modules. Empty cells are shown as "-".
+func WriteTable(w io.Writer, inv *Inventory) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "MODULE\tKIND\tNAMESPACE\tNAME\tTEMPLATE\tREPLICAS\tIMAGES\tSERVICE ACCOUNT\tCPU\tMEMORY\tHOST PATHS\tPRIVILEGED\tCAPABILITIES")
+
+	for i := range inv.Objects {
+		obj := &inv.Objects[i]
+
+		replicas := ""
+		if obj.Replicas != nil {
+			replicas = strconv.FormatInt(*obj.Replicas, 10)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			obj.Module, obj.Kind, cell(obj.Namespace), obj.Name, obj.Template, cell(replicas),
+			list(obj.Images), list(obj.ServiceAccounts), cell(obj.CPURequest), cell(obj.MemoryRequest),
+			list(obj.HostPaths), list(obj.Privileged), list(obj.Capabilities))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "MODULE\tOBJECTS\tWORKLOADS\tCPU\tMEMORY\tPRIVILEGED\tHOST PATHS\tCAPABILITIES")
+
+	for _, m := range inv.Modules {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%d\t%d\t%s\n",
+			m.Name, m.Objects, m.Workloads, m.CPURequest, m.MemoryRequest, m.Privileged, m.HostPaths, list(m.Capabilities))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	return writePrivilegedSurface(w, inv)
+}
+
+// writePrivilegedSurface writes the privileged surface of every module: one row
+// per workload requesting privileges. Nothing is written when no module has
+// one.
+func writePrivilegedSurface(w io.Writer, inv *Inventory) error {
+	if !slices.ContainsFunc(inv.Modules, func(m ModuleSummary) bool { return len(m.PrivilegedSurface) > 0 }) {
+		return nil
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "MODULE\tKIND\tNAMESPACE\tNAME\tPRIVILEGES")
+
+	for _, m := range inv.Modules {
+		for _, obj := range m.PrivilegedSurface {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				m.Name, obj.Kind, cell(obj.Namespace), obj.Name, strings.Join(obj.Privileges, ", "))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func cell(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+func list(items []string) string {
+	return cell(strings.Join(items, ","))
+}
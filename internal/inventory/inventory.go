@@ -0,0 +1,520 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory implements the "inventory" command. It renders every module
+// under a directory the way dmt renders them for linting and lists every
+// rendered object with the properties that matter for capacity planning and
+// security reviews: images, service accounts, resource requests, host paths and
+// privileged settings.
+package inventory
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/deckhouse/pkg/log"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/moduleloader"
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/internal/storage"
+	dmtErrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+// templatesDirName is the per-module directory holding the templates. A
+// directory without it has nothing to render.
+const templatesDirName = "templates"
+
+// Inventory is the list of rendered objects of one or more modules.
+type Inventory struct {
+	Objects []Object        `json:"objects"`
+	Modules []ModuleSummary `json:"modules"`
+}
+
+// Object is one rendered object. Workload fields are only set for pods and pod
+// controllers.
+type Object struct {
+	Module    string `json:"module"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Template  string `json:"template"`
+
+	Replicas        *int64   `json:"replicas,omitempty"`
+	Images          []string `json:"images,omitempty"`
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+	// CPURequest and MemoryRequest are the effective requests of one pod: the
+	// sum over containers, or the largest init container if that is higher.
+	CPURequest    string   `json:"cpuRequest,omitempty"`
+	MemoryRequest string   `json:"memoryRequest,omitempty"`
+	HostPaths     []string `json:"hostPaths,omitempty"`
+	// Privileged lists the privileged settings of the pod: "privileged" for a
+	// privileged container, "hostNetwork", "hostPID" and "hostIPC".
+	Privileged []string `json:"privileged,omitempty"`
+	// Capabilities are the capabilities the containers add, without the CAP_
+	// prefix.
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	cpu    resource.Quantity
+	memory resource.Quantity
+}
+
+// ModuleSummary totals the objects of one module.
+type ModuleSummary struct {
+	Name      string `json:"name"`
+	Objects   int    `json:"objects"`
+	Workloads int    `json:"workloads"`
+	// CPURequest and MemoryRequest sum the requests of all workloads, multiplied
+	// by their replicas where the replica count is known (DaemonSets count once).
+	CPURequest    string `json:"cpuRequest"`
+	MemoryRequest string `json:"memoryRequest"`
+	Privileged    int    `json:"privileged"`
+	HostPaths     int    `json:"hostPaths"`
+	// Capabilities are the capabilities any workload of the module adds.
+	Capabilities []string `json:"capabilities,omitempty"`
+	// PrivilegedSurface lists the workloads requesting privileges, in the order
+	// of the objects.
+	PrivilegedSurface []PrivilegedObject `json:"privilegedSurface,omitempty"`
+}
+
+// PrivilegedObject is a workload of a module's privileged surface with the
+// privileges it requests: its privileged settings, host paths and added
+// capabilities.
+type PrivilegedObject struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Name       string   `json:"name"`
+	Privileges []string `json:"privileges"`
+}
+
+// Build discovers all modules under dir, renders each of them with values
+// generated from its openapi schemas and returns the inventory of the rendered
+// objects. A module that fails to render is logged and skipped; Build then
+// returns the inventory of the others together with an error.
+// globalValuesPath, when set, is the global values schema to render with (see
+// values.NewGlobalSchemaResolver).
+func Build(dir, globalValuesPath string) (*Inventory, error) {
+	expandedDir, err := fsutils.ExpandDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand directory: %w", err)
+	}
+
+	paths, err := moduleloader.GetModulePaths(expandedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module paths: %w", err)
+	}
+
+	globalSchemas := values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(expandedDir), globalValuesPath)
+
+	inv := &Inventory{Objects: []Object{}, Modules: []ModuleSummary{}}
+
+	var hasErrors bool
+
+	for _, modulePath := range paths {
+		if !fsutils.IsDir(filepath.Join(modulePath, templatesDirName)) {
+			log.Debug("Skipping path without templates", slog.String("path", modulePath))
+
+			continue
+		}
+
+		name, objects, err := moduleObjects(modulePath, globalSchemas)
+		if err != nil {
+			log.Error("Failed to render module", slog.String("path", modulePath), log.Err(err))
+
+			hasErrors = true
+
+			continue
+		}
+
+		inv.add(name, objects)
+	}
+
+	if hasErrors {
+		return inv, fmt.Errorf("failed to render some modules")
+	}
+
+	return inv, nil
+}
+
+// moduleObjects renders one module into an object store, exactly as the linters
+// see it, and returns its inventory objects.
+func moduleObjects(modulePath string, globalSchemas *values.GlobalSchemaResolver) (string, []Object, error) {
+	mod, err := modules.LoadModule(modulePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	globalValues, err := globalSchemas.ForModule(modulePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	vals, err := values.ComposeValuesFromSchemas(mod.GetPath(), mod.GetName(), globalValues.Schema)
+	if err != nil {
+		return "", nil, fmt.Errorf("compose values: %w", err)
+	}
+
+	store := storage.NewUnstructuredObjectStore()
+	errorList := dmtErrors.NewLintRuleErrorsList()
+
+	if err := modules.RunRender(mod, vals, store, errorList); err != nil {
+		return "", nil, err
+	}
+
+	for _, e := range errorList.GetErrors() {
+		log.Warn(e.Text, slog.String("module", mod.GetName()), slog.Any("cause", e.ObjectValue))
+	}
+
+	objects, err := FromStore(mod.GetName(), store)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return mod.GetName(), objects, nil
+}
+
+// FromStore returns the inventory objects of a module's object store, sorted by
+// template, kind, namespace and name.
+func FromStore(moduleName string, store *storage.UnstructuredObjectStore) ([]Object, error) {
+	objects := make([]Object, 0, len(store.Storage))
+
+	for _, so := range store.Storage {
+		obj, err := newObject(moduleName, so)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", so.Unstructured.GetKind(), so.Unstructured.GetName(), err)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.Template != b.Template {
+			return a.Template < b.Template
+		}
+
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+
+		return a.Name < b.Name
+	})
+
+	return objects, nil
+}
+
+func newObject(moduleName string, so storage.StoreObject) (Object, error) {
+	obj := Object{
+		Module:    moduleName,
+		Kind:      so.Unstructured.GetKind(),
+		Namespace: so.Unstructured.GetNamespace(),
+		Name:      so.Unstructured.GetName(),
+		Template:  so.ShortPath(),
+	}
+
+	podSpec, err := so.GetPodSpec()
+	if err != nil {
+		return Object{}, err
+	}
+
+	if podSpec == nil {
+		return obj, nil
+	}
+
+	obj.Replicas = replicas(&so.Unstructured)
+
+	obj.Images = podImages(podSpec)
+	obj.ServiceAccounts = []string{serviceAccount(podSpec)}
+	obj.cpu, obj.memory = podRequests(podSpec)
+	obj.CPURequest = formatQuantity(obj.cpu)
+	obj.MemoryRequest = formatQuantity(obj.memory)
+	obj.HostPaths = hostPaths(podSpec)
+	obj.Privileged = privileged(podSpec)
+	obj.Capabilities = addedCapabilities(podSpec)
+
+	return obj, nil
+}
+
+// replicas returns the replica count of a Deployment, StatefulSet or
+// ReplicaSet (one when unset) and nil for other kinds.
+func replicas(u *unstructured.Unstructured) *int64 {
+	switch u.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+	default:
+		return nil
+	}
+
+	count := int64(1)
+
+	// Rendered numbers may be decoded as either integers or floats.
+	switch v, _, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "replicas"); n := v.(type) {
+	case int64:
+		count = n
+	case int:
+		count = int64(n)
+	case float64:
+		count = int64(n)
+	}
+
+	return &count
+}
+
+func podImages(spec *v1.PodSpec) []string {
+	seen := make(map[string]struct{})
+
+	var images []string
+
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			if _, ok := seen[containers[i].Image]; ok {
+				continue
+			}
+
+			seen[containers[i].Image] = struct{}{}
+			images = append(images, containers[i].Image)
+		}
+	}
+
+	return images
+}
+
+// serviceAccount returns the service account the pod runs as; an unset name
+// means the namespace's "default" account.
+func serviceAccount(spec *v1.PodSpec) string {
+	switch {
+	case spec.ServiceAccountName != "":
+		return spec.ServiceAccountName
+	case spec.DeprecatedServiceAccount != "":
+		return spec.DeprecatedServiceAccount
+	default:
+		return "default"
+	}
+}
+
+// podRequests returns the effective CPU and memory requests of a pod the way
+// the scheduler computes them: the sum over containers, raised to the largest
+// init container request.
+func podRequests(spec *v1.PodSpec) (resource.Quantity, resource.Quantity) {
+	var cpu, memory resource.Quantity
+
+	for i := range spec.Containers {
+		requests := spec.Containers[i].Resources.Requests
+		cpu.Add(requests[v1.ResourceCPU])
+		memory.Add(requests[v1.ResourceMemory])
+	}
+
+	for i := range spec.InitContainers {
+		requests := spec.InitContainers[i].Resources.Requests
+		if q := requests[v1.ResourceCPU]; q.Cmp(cpu) > 0 {
+			cpu = q.DeepCopy()
+		}
+
+		if q := requests[v1.ResourceMemory]; q.Cmp(memory) > 0 {
+			memory = q.DeepCopy()
+		}
+	}
+
+	return cpu, memory
+}
+
+func hostPaths(spec *v1.PodSpec) []string {
+	var paths []string
+
+	for i := range spec.Volumes {
+		if hp := spec.Volumes[i].HostPath; hp != nil {
+			paths = append(paths, hp.Path)
+		}
+	}
+
+	return paths
+}
+
+func privileged(spec *v1.PodSpec) []string {
+	var flags []string
+
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			sc := containers[i].SecurityContext
+			if sc != nil && sc.Privileged != nil && *sc.Privileged {
+				flags = append(flags, "privileged")
+
+				break
+			}
+		}
+
+		if len(flags) > 0 {
+			break
+		}
+	}
+
+	if spec.HostNetwork {
+		flags = append(flags, "hostNetwork")
+	}
+
+	if spec.HostPID {
+		flags = append(flags, "hostPID")
+	}
+
+	if spec.HostIPC {
+		flags = append(flags, "hostIPC")
+	}
+
+	return flags
+}
+
+func addedCapabilities(spec *v1.PodSpec) []string {
+	var capabilities []string
+
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			sc := containers[i].SecurityContext
+			if sc == nil || sc.Capabilities == nil {
+				continue
+			}
+
+			for _, capability := range sc.Capabilities.Add {
+				name := strings.TrimPrefix(strings.ToUpper(string(capability)), "CAP_")
+				if !slices.Contains(capabilities, name) {
+					capabilities = append(capabilities, name)
+				}
+			}
+		}
+	}
+
+	return capabilities
+}
+
+func formatQuantity(q resource.Quantity) string {
+	if q.IsZero() {
+		return ""
+	}
+
+	return q.String()
+}
+
+// privileges returns the privileged settings, host paths ("hostPath /dev") and
+// added capabilities of a workload.
+func (obj *Object) privileges() []string {
+	privileges := slices.Clone(obj.Privileged)
+
+	for _, path := range obj.HostPaths {
+		privileges = append(privileges, "hostPath "+path)
+	}
+
+	return append(privileges, obj.Capabilities...)
+}
+
+// add appends a module's objects and its summary.
+func (inv *Inventory) add(moduleName string, objects []Object) {
+	summary := ModuleSummary{Name: moduleName, Objects: len(objects)}
+
+	var cpuMilli, memoryBytes int64
+
+	for i := range objects {
+		obj := &objects[i]
+		if obj.ServiceAccounts == nil {
+			continue
+		}
+
+		summary.Workloads++
+
+		if len(obj.Privileged) > 0 {
+			summary.Privileged++
+		}
+
+		if len(obj.HostPaths) > 0 {
+			summary.HostPaths++
+		}
+
+		if privileges := obj.privileges(); len(privileges) > 0 {
+			summary.PrivilegedSurface = append(summary.PrivilegedSurface, PrivilegedObject{
+				Kind:       obj.Kind,
+				Namespace:  obj.Namespace,
+				Name:       obj.Name,
+				Privileges: privileges,
+			})
+		}
+
+		for _, capability := range obj.Capabilities {
+			if !slices.Contains(summary.Capabilities, capability) {
+				summary.Capabilities = append(summary.Capabilities, capability)
+			}
+		}
+
+		replicas := int64(1)
+		if obj.Replicas != nil {
+			replicas = *obj.Replicas
+		}
+
+		cpuMilli += obj.cpu.MilliValue() * replicas
+		memoryBytes += obj.memory.Value() * replicas
+	}
+
+	slices.Sort(summary.Capabilities)
+
+	summary.CPURequest = resource.NewMilliQuantity(cpuMilli, resource.DecimalSI).String()
+	summary.MemoryRequest = resource.NewQuantity(memoryBytes, resource.BinarySI).String()
+
+	inv.Objects = append(inv.Objects, objects...)
+	inv.Modules = append(inv.Modules, summary)
+}
+
+// Run builds the inventory of the modules under dir and writes it to output
+// (stdout when empty) in the given format. Modules that rendered are written
+// even when others failed; the error is returned afterwards.
+func Run(dir, format, output, globalValuesPath string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+
+	inv, buildErr := Build(dir, globalValuesPath)
+	if inv == nil {
+		return buildErr
+	}
+
+	var w io.Writer = os.Stdout
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("create inventory output: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := Write(w, inv, format); err != nil {
+		return fmt.Errorf("write inventory: %w", err)
+	}
+
+	return buildErr
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/storage"
+)
+
+const deployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: d8-test
+spec:
+  replicas: 2
+  template:
+    spec:
+      serviceAccountName: controller
+      hostNetwork: true
+      initContainers:
+      - name: init
+        image: registry.example.com/init@sha256:1
+        resources:
+          requests:
+            cpu: 500m
+      containers:
+      - name: controller
+        image: registry.example.com/controller@sha256:2
+        securityContext:
+          privileged: true
+        resources:
+          requests:
+            cpu: 100m
+            memory: 64Mi
+      - name: sidecar
+        image: registry.example.com/controller@sha256:2
+        securityContext:
+          capabilities:
+            add: [CAP_NET_ADMIN, SYS_TIME]
+        resources:
+          requests:
+            cpu: 50m
+            memory: 32Mi
+      volumes:
+      - name: dev
+        hostPath:
+          path: /dev
+`
+
+const daemonSet = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      containers:
+      - name: agent
+        image: registry.example.com/agent@sha256:3
+        resources:
+          requests:
+            cpu: 10m
+            memory: 16Mi
+`
+
+const configMap = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: d8-test
+`
+
+func newStore(t *testing.T, manifests map[string]string) *storage.UnstructuredObjectStore {
+	t.Helper()
+
+	store := storage.NewUnstructuredObjectStore()
+
+	for path, manifest := range manifests {
+		obj := map[string]any{}
+		require.NoError(t, yaml.Unmarshal([]byte(manifest), &obj))
+		require.NoError(t, store.Put("/modules/test/"+path, path, obj, []byte(manifest)))
+	}
+
+	return store
+}
+
+func TestFromStore(t *testing.T) {
+	store := newStore(t, map[string]string{
+		"templates/controller.yaml": deployment,
+		"templates/agent.yaml":      daemonSet,
+		"templates/settings.yaml":   configMap,
+	})
+
+	objects, err := FromStore("test", store)
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+
+	agent, controller, settings := objects[0], objects[1], objects[2]
+
+	assert.Equal(t, "settings", settings.Name)
+	assert.Nil(t, settings.ServiceAccounts, "non-workloads carry no workload fields")
+	assert.Nil(t, settings.Replicas)
+
+	assert.Equal(t, "templates/controller.yaml", controller.Template)
+	require.NotNil(t, controller.Replicas)
+	assert.Equal(t, int64(2), *controller.Replicas)
+	assert.Equal(t, []string{"registry.example.com/init@sha256:1", "registry.example.com/controller@sha256:2"}, controller.Images)
+	assert.Equal(t, []string{"controller"}, controller.ServiceAccounts)
+	assert.Equal(t, "500m", controller.CPURequest, "the init container request exceeds the containers' sum")
+	assert.Equal(t, "96Mi", controller.MemoryRequest)
+	assert.Equal(t, []string{"/dev"}, controller.HostPaths)
+	assert.Equal(t, []string{"privileged", "hostNetwork"}, controller.Privileged)
+	assert.Equal(t, []string{"NET_ADMIN", "SYS_TIME"}, controller.Capabilities)
+
+	assert.Nil(t, agent.Replicas, "DaemonSets have no replica count")
+	assert.Equal(t, []string{"default"}, agent.ServiceAccounts)
+
+	inv := &Inventory{}
+	inv.add("test", objects)
+
+	require.Len(t, inv.Modules, 1)
+	assert.Equal(t, ModuleSummary{
+		Name:          "test",
+		Objects:       3,
+		Workloads:     2,
+		CPURequest:    "1010m",
+		MemoryRequest: "208Mi",
+		Privileged:    1,
+		HostPaths:     1,
+		Capabilities:  []string{"NET_ADMIN", "SYS_TIME"},
+		PrivilegedSurface: []PrivilegedObject{{
+			Kind:       "Deployment",
+			Namespace:  "d8-test",
+			Name:       "controller",
+			Privileges: []string{"privileged", "hostNetwork", "hostPath /dev", "NET_ADMIN", "SYS_TIME"},
+		}},
+	}, inv.Modules[0], "the agent requests no privileges and is not part of the privileged surface")
+}
+
+func TestWrite(t *testing.T) {
+	objects, err := FromStore("test", newStore(t, map[string]string{"templates/controller.yaml": deployment}))
+	require.NoError(t, err)
+
+	inv := &Inventory{}
+	inv.add("test", objects)
+
+	var sb strings.Builder
+
+	require.NoError(t, Write(&sb, inv, FormatTable))
+	assert.Contains(t, sb.String(), "SERVICE ACCOUNT")
+	assert.Contains(t, sb.String(), "privileged,hostNetwork")
+	assert.Contains(t, sb.String(), "NET_ADMIN,SYS_TIME")
+	assert.Regexp(t, `test\s+Deployment\s+d8-test\s+controller\s+privileged, hostNetwork, hostPath /dev, NET_ADMIN, SYS_TIME\n`, sb.String(),
+		"the privileged surface follows the module summary")
+
+	sb.Reset()
+	require.NoError(t, Write(&sb, &Inventory{Modules: []ModuleSummary{{Name: "plain"}}}, FormatTable))
+	assert.NotContains(t, sb.String(), "PRIVILEGES", "modules without privileges have no privileged surface")
+
+	sb.Reset()
+	require.NoError(t, Write(&sb, inv, FormatJSON))
+	assert.Contains(t, sb.String(), `"cpuRequest": "500m"`)
+	assert.Contains(t, sb.String(), `"privilegedSurface": [`)
+
+	require.Error(t, Write(&sb, inv, "csv"))
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/werf"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/linters/container"
+	"github.com/deckhouse/dmt/pkg/linters/hooks"
+	"github.com/deckhouse/dmt/pkg/linters/images"
+	"github.com/deckhouse/dmt/pkg/linters/rbac"
+	"github.com/deckhouse/dmt/pkg/linters/templates"
+)
+
+// editionLinters are the linters whose input differs between editions: they
+// inspect the rendered objects, the generated values or the rendered werf.yaml.
+// The remaining linters only read files from the module directory, so running
+// them again for every edition could not find anything new.
+var editionLinters = map[string]struct{}{
+	container.ID: {},
+	hooks.ID:     {},
+	images.ID:    {},
+	rbac.ID:      {},
+	templates.ID: {},
+}
+
+// lintedEditions returns the editions of the module at modulePath to lint in
+// addition to the default render, sorted by name. When WERF_ENV is set only the
+// edition it selects is linted (if the module ships it), mirroring a Deckhouse
+// build of that edition.
+func lintedEditions(modulePath string) ([]string, error) {
+	editions, err := modules.DiscoverEditions(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, hasSelection := os.LookupEnv(werf.EnvVar)
+
+	result := make([]string, 0, len(editions))
+
+	for edition := range editions {
+		if hasSelection && selected != "" && !strings.EqualFold(werf.EditionEnv(edition), selected) {
+			continue
+		}
+
+		result = append(result, edition)
+	}
+
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// findingKey identifies a finding independently of the edition it was reported
+// for.
+type findingKey struct {
+	linterID, moduleID, ruleID, objectID, text, filePath string
+	lineNumber                                           int
+	level                                                pkg.Level
+}
+
+func newFindingKey(err *pkg.LinterError) findingKey {
+	return findingKey{
+		linterID:   err.LinterID,
+		moduleID:   err.ModuleID,
+		ruleID:     err.RuleID,
+		objectID:   err.ObjectID,
+		text:       err.Text,
+		filePath:   err.FilePath,
+		lineNumber: err.LineNumber,
+		level:      err.Level,
+	}
+}
+
+// mergeEditionFindings folds the findings of the per-edition runs into the
+// default run's findings. A finding the default render also produced is not
+// edition-specific and is dropped; the same edition-specific finding reported by
+// several editions is kept once, with Edition listing all of them (e.g.
+// "ee, fe"). The order of the remaining findings is preserved.
+func mergeEditionFindings(errs []pkg.LinterError) []pkg.LinterError {
+	defaults := make(map[findingKey]struct{})
+
+	for idx := range errs {
+		if errs[idx].Edition == "" {
+			defaults[newFindingKey(&errs[idx])] = struct{}{}
+		}
+	}
+
+	result := make([]pkg.LinterError, 0, len(errs))
+	editionIdx := make(map[findingKey]int)
+
+	for idx := range errs {
+		err := errs[idx]
+		if err.Edition == "" {
+			result = append(result, err)
+
+			continue
+		}
+
+		key := newFindingKey(&err)
+		if _, ok := defaults[key]; ok {
+			continue
+		}
+
+		if pos, ok := editionIdx[key]; ok {
+			editions := strings.Split(result[pos].Edition, ", ")
+			if !slices.Contains(editions, err.Edition) {
+				editions = append(editions, err.Edition)
+				slices.Sort(editions)
+				result[pos].Edition = strings.Join(editions, ", ")
+			}
+
+			continue
+		}
+
+		editionIdx[key] = len(result)
+		result = append(result, err)
+	}
+
+	return result
+}
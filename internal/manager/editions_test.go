@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestMergeEditionFindings(t *testing.T) {
+	shared := pkg.LinterError{LinterID: "container", ModuleID: "test", ObjectID: "Deployment/app", Text: "no probes", Level: pkg.Error}
+	eeOnly := pkg.LinterError{LinterID: "templates", ModuleID: "test", ObjectID: "Service/ee", Text: "no VPA", Level: pkg.Error}
+
+	withEdition := func(e pkg.LinterError, edition string) pkg.LinterError {
+		e.Edition = edition
+		return e
+	}
+
+	merged := mergeEditionFindings([]pkg.LinterError{
+		shared,
+		withEdition(shared, "ce"),
+		withEdition(shared, "ee"),
+		withEdition(eeOnly, "fe"),
+		withEdition(eeOnly, "ee"),
+	})
+
+	require.Equal(t, []pkg.LinterError{shared, withEdition(eeOnly, "ee, fe")}, merged)
+}
+
+func TestApplyFixesOncePerEditionFinding(t *testing.T) {
+	m := &Manager{errors: errors.NewLintRuleErrorsList()}
+
+	var applied int
+
+	fix := func() error {
+		applied++
+		return nil
+	}
+
+	list := m.errors.WithLinterID("module").WithModule("test").WithFilePath("oss.yaml")
+	list.WithEdition("ee").WithFix(fix).Error("oss.yaml is malformed")
+	list.WithEdition("fe").WithFix(fix).Error("oss.yaml is malformed")
+
+	m.ApplyFixes()
+
+	require.Equal(t, 1, applied, "the finding both editions reported is fixed once")
+	require.Empty(t, m.GetErrors(), "the fixed finding is dropped for every edition")
+}
+
+func TestLintedEditions(t *testing.T) {
+	modulePath := t.TempDir()
+	openapiDir := filepath.Join(modulePath, "openapi")
+
+	require.NoError(t, os.MkdirAll(openapiDir, 0o755))
+
+	for _, name := range []string{"values.yaml", "values_ee.yaml", "values_ce.yaml", "config-values.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(openapiDir, name), []byte("type: object\n"), 0o644))
+	}
+
+	t.Setenv("WERF_ENV", "")
+
+	editions, err := lintedEditions(modulePath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ce", "ee"}, editions)
+
+	t.Setenv("WERF_ENV", "CE")
+
+	editions, err = lintedEditions(modulePath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ce"}, editions)
+
+	t.Setenv("WERF_ENV", "FE")
+
+	editions, err = lintedEditions(modulePath)
+	require.NoError(t, err)
+	require.Empty(t, editions)
+
+	editions, err = lintedEditions(t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, editions)
+}
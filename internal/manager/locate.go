@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/storage"
+)
+
+// containerIDRe extracts the container of object IDs such as
+// "kind = Deployment ; name = app ; container = app".
+var containerIDRe = regexp.MustCompile(`;\s*container\s*=\s*([^\s;]+)`)
+
+// objectLocator maps the object IDs of findings to the file path and template
+// line of the rendered object they were reported for, pointing container
+// findings to the container.
+func objectLocator(objects map[storage.ResourceIndex]storage.StoreObject) func(objectID string) (string, int) {
+	byIdentity := make(map[string]storage.StoreObject, len(objects))
+	for _, object := range objects {
+		byIdentity[object.Identity()] = object
+	}
+
+	return func(objectID string) (string, int) {
+		object, ok := lookupObject(byIdentity, objectID)
+		if !ok {
+			return "", 0
+		}
+
+		if m := containerIDRe.FindStringSubmatch(objectID); m != nil {
+			return object.GetPath(), object.ContainerLineNumber(m[1])
+		}
+
+		return object.GetPath(), object.LineNumber()
+	}
+}
+
+// lookupObject finds the object whose identity the ID starts with, followed by
+// the end of the ID or a ';' separated suffix. Longer identities are tried
+// first, so a namespaced object wins over a cluster-scoped one of the same name.
+func lookupObject(byIdentity map[string]storage.StoreObject, objectID string) (storage.StoreObject, bool) {
+	for prefix := objectID; prefix != ""; {
+		if object, ok := byIdentity[strings.TrimSpace(prefix)]; ok {
+			return object, true
+		}
+
+		sep := strings.LastIndex(prefix, ";")
+		if sep < 0 {
+			break
+		}
+
+		prefix = prefix[:sep]
+	}
+
+	return storage.StoreObject{}, false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/sourcemap"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const deploymentTemplate = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: init
+      containers:
+      - name: app
+        image: app
+      - name: {{ .Values.sidecar }}
+        image: sidecar
+`
+
+func newStore(t *testing.T) *storage.UnstructuredObjectStore {
+	t.Helper()
+
+	tpl := sourcemap.Parse([]byte(deploymentTemplate))
+	store := storage.NewUnstructuredObjectStore()
+
+	var deployment map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app", "namespace": "d8-app"}}`), &deployment))
+	require.NoError(t, store.PutWithSource("/module/templates/app.yaml", "templates/app.yaml", deployment, nil, tpl.Document("Deployment", "app")))
+
+	var role map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(`{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "Role", "metadata": {"name": "app", "namespace": "d8-app"}}`), &role))
+	require.NoError(t, store.Put("/module/templates/rbac.yaml", "templates/rbac.yaml", role, nil))
+
+	return store
+}
+
+func TestObjectLocator(t *testing.T) {
+	locate := objectLocator(newStore(t).Storage)
+
+	tests := []struct {
+		objectID string
+		path     string
+		line     int
+	}{
+		{objectID: "kind = Deployment ; name = app ; namespace = d8-app", path: "templates/app.yaml", line: 3},
+		{objectID: "kind = Deployment ; name = app ; namespace = d8-app ; container = app", path: "templates/app.yaml", line: 14},
+		{objectID: "kind = Deployment ; name = app ; namespace = d8-app; container = init", path: "templates/app.yaml", line: 11},
+		{objectID: "kind = Deployment ; name = app ; namespace = d8-app ; container = proxy", path: "templates/app.yaml", line: 16},
+		{objectID: "kind = Role ; name = app ; namespace = d8-app", path: "templates/rbac.yaml", line: 0},
+		{objectID: "kind = Deployment ; name = other ; namespace = d8-app"},
+		{objectID: "module = app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.objectID, func(t *testing.T) {
+			path, line := locate(tt.objectID)
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.line, line)
+		})
+	}
+}
+
+func TestLocateFindings(t *testing.T) {
+	errList := errors.NewLintRuleErrorsList().WithLinterID("container").WithModule("app")
+
+	const objectID = "kind = Deployment ; name = app ; namespace = d8-app ; container = app"
+
+	errList.WithObjectID(objectID).WithFilePath("templates/app.yaml").Error("located")
+	errList.WithObjectID(objectID).Error("located without a path")
+	errList.WithObjectID(objectID).WithFilePath("templates/app.yaml").WithLineNumber(2).Error("keeps its line")
+	errList.WithObjectID(objectID).WithFilePath("openapi/values.yaml").Error("another file")
+	errList.WithModule("other").WithObjectID(objectID).Error("another module")
+	errList.WithEdition("ee").WithObjectID(objectID).Error("another edition")
+
+	errList.Locate("app", "", objectLocator(newStore(t).Storage))
+
+	lines := map[string]int{}
+	for _, e := range errList.GetErrors() {
+		lines[e.Text] = e.LineNumber
+	}
+
+	assert.Equal(t, map[string]int{
+		"located":                14,
+		"located without a path": 14,
+		"keeps its line":         2,
+		"another file":           0,
+		"another module":         0,
+		"another edition":        0,
+	}, lines)
+}
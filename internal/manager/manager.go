@@ -107,11 +107,7 @@ func (m *Manager) initManager(dir string) *Manager {
 		log.Error("Failed to decode values file", log.Err(err))
 	}
 
-	globalValues, err := values.GetGlobalValues(getRootDirectory(dir))
-	if err != nil {
-		log.Error("Failed to get global values", log.Err(err))
-		return m
-	}
+	globalSchemas := values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(dir), flags.GlobalValues)
 
 	errorList := m.errors.WithLinterID("manager")
 
@@ -124,6 +120,16 @@ func (m *Manager) initManager(dir string) *Manager {
 			continue
 		}
 
+		globalValues, err := globalSchemas.ForModule(paths[i])
+		if err != nil {
+			errorList.
+				WithFilePath(paths[i]).WithModule(moduleName).
+				WithValue(err.Error()).
+				Errorf("cannot load global values schema for module `%s`", moduleName)
+
+			continue
+		}
+
 		mdl, err := modules.NewModule(paths[i], &vals, globalValues, m.cfg, errorList)
 		if err != nil {
 			errorList.
@@ -135,6 +141,8 @@ func (m *Manager) initManager(dir string) *Manager {
 		}
 
 		m.Modules = append(m.Modules, mdl)
+
+		m.addEditionModules(paths[i], moduleName, &vals, globalValues, errorList)
 	}
 
 	log.Info("Found modules", slog.Int("count", len(m.Modules)))
@@ -142,6 +150,38 @@ func (m *Manager) initManager(dir string) *Manager {
 	return m
 }
 
+// addEditionModules renders the module at path once more for every edition it
+// ships (openapi/values_<edition>.yaml, see lintedEditions), so edition-only
+// templates and values are linted too. Findings of these renders are tagged with
+// the edition.
+func (m *Manager) addEditionModules(path, moduleName string, vals *chartutil.Values, globalValues *values.GlobalSchema, errorList *errors.LintRuleErrorsList) {
+	editions, err := lintedEditions(path)
+	if err != nil {
+		errorList.
+			WithFilePath(path).WithModule(moduleName).
+			WithValue(err.Error()).
+			Errorf("cannot discover editions of module `%s`", moduleName)
+
+		return
+	}
+
+	for _, edition := range editions {
+		editionErrorList := errorList.WithEdition(edition)
+
+		mdl, err := modules.NewEditionModule(path, edition, vals, globalValues, m.cfg, editionErrorList)
+		if err != nil {
+			editionErrorList.
+				WithFilePath(path).WithModule(moduleName).
+				WithValue(err.Error()).
+				Errorf("cannot create module `%s` for edition `%s`", moduleName, edition)
+
+			continue
+		}
+
+		m.Modules = append(m.Modules, mdl)
+	}
+}
+
 func decodeValuesFile(path string) (chartutil.Values, error) {
 	if path == "" {
 		return nil, nil
@@ -170,17 +210,29 @@ func (m *Manager) Run() {
 				wg.Done()
 			}()
 
-			log.Info("Run linters for module", slog.String("module", module.GetName()))
+			log.Info("Run linters for module", slog.String("module", module.GetName()), slog.String("edition", module.GetEdition()))
 
-			for _, linter := range getLintersForModule(module.GetModuleConfig(), m.errors) {
+			errList := m.errors
+			if module.GetEdition() != "" {
+				errList = errList.WithEdition(module.GetEdition())
+			}
+
+			for _, linter := range getLintersForModule(module.GetModuleConfig(), errList) {
 				if flags.LinterName != "" && linter.Name() != flags.LinterName {
 					continue
 				}
 
+				if _, ok := editionLinters[linter.Name()]; module.GetEdition() != "" && !ok {
+					continue
+				}
+
 				log.Debug("Running linter", slog.String("linter", linter.Name()), slog.String("module", module.GetName()))
 
 				linter.Run(module)
 			}
+
+			// Point the findings reported for rendered objects to their template lines.
+			errList.Locate(module.GetName(), module.GetEdition(), objectLocator(module.GetStorage()))
 		}()
 	}
 
@@ -202,7 +254,7 @@ func getLintersForModule(cfg *pkg.LintersSettings, errList *errors.LintRuleError
 }
 
 func (m *Manager) PrintResult() {
-	errs := m.errors.GetErrors()
+	errs := m.GetErrors()
 
 	if len(errs) == 0 {
 		return
@@ -214,6 +266,7 @@ func (m *Manager) PrintResult() {
 			cmp.Compare(a.ModuleID, b.ModuleID),
 			cmp.Compare(a.LinterID, b.LinterID),
 			cmp.Compare(a.RuleID, b.RuleID),
+			cmp.Compare(a.Edition, b.Edition),
 		)
 	})
 
@@ -278,6 +331,10 @@ func (m *Manager) PrintResult() {
 			fmt.Fprintf(w, "\t%s\t\t%s\n", "FilePath:", strings.TrimSpace(err.FilePath))
 		}
 
+		if err.Edition != "" {
+			fmt.Fprintf(w, "\t%s\t\t%s\n", "Edition:", color.New(color.FgHiMagenta).Sprintf("%s only", err.Edition))
+		}
+
 		if err.LineNumber != 0 {
 			fmt.Fprintf(w, "\t%s\t\t%d\n", "LineNumber:", err.LineNumber)
 		}
@@ -306,8 +363,9 @@ func (m *Manager) HasCriticalErrors() bool {
 }
 
 // ApplyFixes is the single entry point for the --fix flag. It runs every fix
-// attached to a collected finding. Findings whose fix succeeds are marked Fixed
-// and subsequently dropped by GetErrors; findings whose fix fails are kept, and
+// attached to a collected finding, once per finding even when several edition
+// renders reported it. Findings whose fix succeeds are marked Fixed and
+// subsequently dropped by GetErrors; findings whose fix fails are kept, and
 // PrintResult reports the failure via the finding's FixError.
 func (m *Manager) ApplyFixes() {
 	for _, fix := range m.errors.GetFixes() {
@@ -315,11 +373,12 @@ func (m *Manager) ApplyFixes() {
 	}
 }
 
-// GetErrors returns all findings collected during the run.
+// GetErrors returns all findings collected during the run, with the findings
+// of the per-edition renders merged in (see mergeEditionFindings).
 // It is primarily intended for tests (e.g. the e2e framework) that need to
 // assert on the structured findings produced by the linters.
 func (m *Manager) GetErrors() []pkg.LinterError {
-	return m.errors.GetErrors()
+	return mergeEditionFindings(m.errors.GetErrors())
 }
 
 // prepareString handle ussual string and prepare it for tablewriter
@@ -341,23 +400,3 @@ func prepareString(input string) string {
 
 	return w.String()
 }
-
-func getRootDirectory(dir string) string {
-	for {
-		if fsutils.IsDir(filepath.Join(dir, "global-hooks", "openapi")) &&
-			fsutils.IsDir(filepath.Join(dir, "modules")) &&
-			fsutils.IsFile(filepath.Join(dir, "global-hooks", "openapi", "config-values.yaml")) &&
-			fsutils.IsFile(filepath.Join(dir, "global-hooks", "openapi", "values.yaml")) {
-			return dir
-		}
-
-		parent := filepath.Dir(dir)
-		if dir == parent || parent == "" {
-			break
-		}
-
-		dir = parent
-	}
-
-	return ""
-}
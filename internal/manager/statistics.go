@@ -108,6 +108,8 @@ type statistics struct {
 	total int
 	// byLinter holds the per-linter breakdown, most findings first.
 	byLinter []linterStat
+	// byEdition counts the edition-specific findings per edition, by name.
+	byEdition []linterStat
 	// elapsed is the wall-clock duration of the run.
 	elapsed time.Duration
 }
@@ -117,13 +119,21 @@ type statistics struct {
 // --show-ignored display flags: the summary is meant to give the full picture.
 func (m *Manager) collectStatistics() statistics {
 	s := statistics{
-		modules: len(m.Modules),
 		elapsed: time.Since(m.startedAt),
 	}
 
+	// Edition renders of a module are linted as separate modules; count the
+	// module once.
+	for _, module := range m.Modules {
+		if module.GetEdition() == "" {
+			s.modules++
+		}
+	}
+
 	perLinter := make(map[string]int)
+	perEdition := make(map[string]int)
 
-	errs := m.errors.GetErrors()
+	errs := m.GetErrors()
 	for idx := range errs {
 		s.total++
 
@@ -139,8 +149,20 @@ func (m *Manager) collectStatistics() statistics {
 		}
 
 		perLinter[errs[idx].LinterID]++
+
+		if errs[idx].Edition != "" {
+			perEdition[errs[idx].Edition]++
+		}
+	}
+
+	for name, count := range perEdition {
+		s.byEdition = append(s.byEdition, linterStat{name: name, count: count})
 	}
 
+	slices.SortFunc(s.byEdition, func(a, b linterStat) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
 	for name, count := range perLinter {
 		s.byLinter = append(s.byLinter, linterStat{name: name, count: count})
 	}
@@ -222,6 +244,16 @@ func renderStatistics(s statistics) string {
 		}
 	}
 
+	// Edition-specific findings, i.e. ones the default render did not produce.
+	if len(s.byEdition) > 0 {
+		fmt.Fprintln(&b, bar())
+		fmt.Fprintf(&b, "%s %s\n", bar(), cLabel("Edition-specific"))
+
+		for _, es := range s.byEdition {
+			fmt.Fprintf(&b, "%s   %-*s %s\n", bar(), nameWidth, es.name, cCount(fmt.Sprint(es.count)))
+		}
+	}
+
 	fmt.Fprintln(&b, bar())
 	fmt.Fprintf(&b, "%s %s %s\n", bar(), cLabel(padLabel("Total")), cCount(fmt.Sprintf("%d findings", s.total)))
 
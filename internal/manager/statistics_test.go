@@ -51,6 +51,9 @@ func TestRenderStatistics_Failed(t *testing.T) {
 			{name: "openapi", count: 3},
 			{name: "module", count: 1},
 		},
+		byEdition: []linterStat{
+			{name: "ee", count: 2},
+		},
 		elapsed: 250 * time.Millisecond,
 	})
 
@@ -67,6 +70,10 @@ func TestRenderStatistics_Failed(t *testing.T) {
 	// Per-linter breakdown, most findings first.
 	require.Contains(t, out, "By linter")
 	require.Contains(t, out, "templates")
+
+	// Findings only the edition renders produced.
+	require.Contains(t, out, "Edition-specific")
+	require.Contains(t, out, "ee")
 	require.Contains(t, out, "Total:      9 findings")
 	require.Contains(t, out, "Elapsed: 250ms")
 }
@@ -0,0 +1,467 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifestdiff computes an object-aware diff between two sets of
+// rendered Kubernetes manifests. Documents are matched by kind, namespace and
+// name rather than by position, so reordering documents does not show up as a
+// change; matched objects are compared field by field. An object moved to
+// another template file is reported as changed, with no field changes when only
+// its source path differs.
+package manifestdiff
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Object is a single rendered manifest together with the chart-relative path of
+// the template it came from.
+type Object struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Source is the chart-relative template path (e.g. "templates/foo.yaml").
+	Source string
+	// Content is the parsed manifest.
+	Content map[string]any
+}
+
+// ID returns the human-readable identity of the object, e.g.
+// "Deployment d8-foo/bar" or "ClusterRole d8:foo" for cluster-scoped objects.
+func (o *Object) ID() string {
+	if o.Namespace == "" {
+		return o.Kind + " " + o.Name
+	}
+
+	return o.Kind + " " + o.Namespace + "/" + o.Name
+}
+
+// ParseFiles parses rendered manifests keyed by chart-relative source path (the
+// shape modules.RenderModuleWithValues returns) into objects. Empty documents are
+// skipped.
+func ParseFiles(files map[string]string) ([]Object, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	var objects []Object
+
+	for _, path := range paths {
+		parsed, err := ParseDocuments(path, files[path])
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, parsed...)
+	}
+
+	return objects, nil
+}
+
+// ParseDocuments parses a multi-document YAML stream rendered from the template
+// at source. Empty documents are skipped.
+func ParseDocuments(source, content string) ([]Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(content)))
+
+	var objects []Object
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read rendered manifest %q: %w", source, err)
+		}
+
+		var obj map[string]any
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, fmt.Errorf("parse rendered manifest %q: %w", source, err)
+		}
+
+		if len(obj) == 0 {
+			continue
+		}
+
+		objects = append(objects, newObject(source, obj))
+	}
+
+	return objects, nil
+}
+
+// sourcePrefix marks the template path comment helm and the templates tester
+// put at the top of every rendered document.
+const sourcePrefix = "# Source: "
+
+// ParseStream parses a single multi-document YAML stream whose documents carry a
+// "# Source: <path>" comment, such as `helm template` output or a templates
+// test snapshot. Documents without the comment get an empty source; empty
+// documents are skipped.
+func ParseStream(content string) ([]Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(content)))
+
+	var objects []Object
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read manifest stream: %w", err)
+		}
+
+		source := documentSource(string(doc))
+
+		var obj map[string]any
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, fmt.Errorf("parse manifest %q: %w", source, err)
+		}
+
+		if len(obj) == 0 {
+			continue
+		}
+
+		objects = append(objects, newObject(source, obj))
+	}
+
+	return objects, nil
+}
+
+// documentSource returns the path of the first "# Source:" comment in doc.
+func documentSource(doc string) string {
+	for _, line := range strings.Split(doc, "\n") {
+		if source, ok := strings.CutPrefix(strings.TrimSpace(line), sourcePrefix); ok {
+			return strings.TrimSpace(source)
+		}
+	}
+
+	return ""
+}
+
+func newObject(source string, content map[string]any) Object {
+	obj := Object{Source: source, Content: content}
+
+	obj.Kind, _ = content["kind"].(string)
+
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		obj.Name, _ = metadata["name"].(string)
+		obj.Namespace, _ = metadata["namespace"].(string)
+	}
+
+	return obj
+}
+
+// Op is the kind of a single field change.
+type Op int
+
+const (
+	// Added marks a field present only in the new object.
+	Added Op = iota
+	// Removed marks a field present only in the old object.
+	Removed
+	// Modified marks a field present in both objects with different values.
+	Modified
+)
+
+// Change is a single field-level difference between two versions of an object.
+type Change struct {
+	// Path is the dotted field path, e.g. "spec.template.spec.containers[name=app].image".
+	Path string
+	Op   Op
+	Old  any
+	New  any
+}
+
+// ObjectDiff holds the field-level changes of an object present on both sides.
+type ObjectDiff struct {
+	Old     Object
+	New     Object
+	Changes []Change
+}
+
+// Result is the object-level comparison of two manifest sets.
+type Result struct {
+	Added     []Object
+	Removed   []Object
+	Changed   []ObjectDiff
+	Unchanged int
+}
+
+// Empty reports whether both sides rendered the same objects.
+func (r *Result) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Summary returns a one-line count of the differences, e.g.
+// "1 added, 0 removed, 2 changed, 10 unchanged".
+func (r *Result) Summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d changed, %d unchanged",
+		len(r.Added), len(r.Removed), len(r.Changed), r.Unchanged)
+}
+
+// Compare matches the objects of both sides by kind, namespace and name and
+// returns what was added, removed and changed going from oldObjects to
+// newObjects. An object whose source template changed is changed even when its
+// fields are equal. Every slice in the result is sorted by object ID.
+func Compare(oldObjects, newObjects []Object) Result {
+	oldIndex := indexObjects(oldObjects)
+	newIndex := indexObjects(newObjects)
+
+	var res Result
+
+	for key, newObj := range newIndex {
+		oldObj, ok := oldIndex[key]
+		if !ok {
+			res.Added = append(res.Added, newObj)
+			continue
+		}
+
+		changes := Fields(oldObj.Content, newObj.Content)
+		if len(changes) == 0 && oldObj.Source == newObj.Source {
+			res.Unchanged++
+			continue
+		}
+
+		res.Changed = append(res.Changed, ObjectDiff{Old: oldObj, New: newObj, Changes: changes})
+	}
+
+	for key, oldObj := range oldIndex {
+		if _, ok := newIndex[key]; !ok {
+			res.Removed = append(res.Removed, oldObj)
+		}
+	}
+
+	sortObjects(res.Added)
+	sortObjects(res.Removed)
+	sort.Slice(res.Changed, func(i, j int) bool {
+		return objectLess(&res.Changed[i].New, &res.Changed[j].New)
+	})
+
+	return res
+}
+
+// indexObjects keys objects by identity. The same identity rendered twice (a
+// chart bug the linters report separately) is kept apart by its source path so
+// neither copy is silently dropped.
+func indexObjects(objects []Object) map[string]Object {
+	index := make(map[string]Object, len(objects))
+
+	for _, obj := range objects {
+		key := obj.ID()
+		if _, exists := index[key]; exists {
+			key += " " + obj.Source
+		}
+
+		index[key] = obj
+	}
+
+	return index
+}
+
+func sortObjects(objects []Object) {
+	sort.Slice(objects, func(i, j int) bool { return objectLess(&objects[i], &objects[j]) })
+}
+
+func objectLess(a, b *Object) bool {
+	if a.ID() != b.ID() {
+		return a.ID() < b.ID()
+	}
+
+	return a.Source < b.Source
+}
+
+// Fields returns the field-level differences between two decoded YAML values,
+// ordered by path. Lists whose items all carry a unique "name" (containers, env,
+// ports, volumes, ...) are matched by name instead of by index, so inserting an
+// item reports one addition rather than a shifted list.
+func Fields(oldValue, newValue any) []Change {
+	var changes []Change
+
+	diffValues("", oldValue, newValue, &changes)
+
+	return changes
+}
+
+func diffValues(path string, oldValue, newValue any, changes *[]Change) {
+	switch oldTyped := oldValue.(type) {
+	case map[string]any:
+		if newTyped, ok := newValue.(map[string]any); ok {
+			diffMaps(path, oldTyped, newTyped, changes)
+			return
+		}
+	case []any:
+		if newTyped, ok := newValue.([]any); ok {
+			diffLists(path, oldTyped, newTyped, changes)
+			return
+		}
+	}
+
+	if !equalScalars(oldValue, newValue) {
+		*changes = append(*changes, Change{Path: path, Op: Modified, Old: oldValue, New: newValue})
+	}
+}
+
+func diffMaps(path string, oldMap, newMap map[string]any, changes *[]Change) {
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := joinKey(path, key)
+		oldValue, inOld := oldMap[key]
+		newValue, inNew := newMap[key]
+
+		switch {
+		case !inOld:
+			*changes = append(*changes, Change{Path: child, Op: Added, New: newValue})
+		case !inNew:
+			*changes = append(*changes, Change{Path: child, Op: Removed, Old: oldValue})
+		default:
+			diffValues(child, oldValue, newValue, changes)
+		}
+	}
+}
+
+func diffLists(path string, oldList, newList []any, changes *[]Change) {
+	oldNamed, oldOK := namedItems(oldList)
+	newNamed, newOK := namedItems(newList)
+
+	if oldOK && newOK {
+		diffNamedLists(path, oldList, newList, oldNamed, newNamed, changes)
+		return
+	}
+
+	for i := 0; i < max(len(oldList), len(newList)); i++ {
+		child := fmt.Sprintf("%s[%d]", path, i)
+
+		switch {
+		case i >= len(oldList):
+			*changes = append(*changes, Change{Path: child, Op: Added, New: newList[i]})
+		case i >= len(newList):
+			*changes = append(*changes, Change{Path: child, Op: Removed, Old: oldList[i]})
+		default:
+			diffValues(child, oldList[i], newList[i], changes)
+		}
+	}
+}
+
+func diffNamedLists(path string, oldList, newList []any, oldNamed, newNamed map[string]int, changes *[]Change) {
+	for _, item := range oldList {
+		name := item.(map[string]any)["name"].(string)
+		child := fmt.Sprintf("%s[name=%s]", path, name)
+
+		newIdx, ok := newNamed[name]
+		if !ok {
+			*changes = append(*changes, Change{Path: child, Op: Removed, Old: item})
+			continue
+		}
+
+		diffValues(child, item, newList[newIdx], changes)
+	}
+
+	for _, item := range newList {
+		name := item.(map[string]any)["name"].(string)
+		if _, ok := oldNamed[name]; !ok {
+			*changes = append(*changes, Change{Path: fmt.Sprintf("%s[name=%s]", path, name), Op: Added, New: item})
+		}
+	}
+}
+
+// namedItems returns the index of every item keyed by its "name" field, and
+// false when the list is empty or any item is not a map with a unique string
+// name.
+func namedItems(list []any) (map[string]int, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+
+	named := make(map[string]int, len(list))
+
+	for idx, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		name, ok := obj["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+
+		if _, dup := named[name]; dup {
+			return nil, false
+		}
+
+		named[name] = idx
+	}
+
+	return named, true
+}
+
+func joinKey(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		key = "[" + key + "]"
+		return path + key
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// equalScalars compares two decoded leaf values. Values of different types
+// (e.g. a map replaced by a string) are never equal.
+func equalScalars(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	switch a.(type) {
+	case map[string]any, []any:
+		return false
+	}
+
+	switch b.(type) {
+	case map[string]any, []any:
+		return false
+	}
+
+	return a == b
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app:1
+      - name: sidecar
+        image: registry.example.com/sidecar:1
+`
+
+func mustParse(t *testing.T, files map[string]string) []Object {
+	t.Helper()
+
+	objects, err := ParseFiles(files)
+	require.NoError(t, err)
+
+	return objects
+}
+
+func TestParseFiles(t *testing.T) {
+	objects := mustParse(t, map[string]string{
+		"templates/b.yaml": "kind: ClusterRole\nmetadata:\n  name: d8:test\n",
+		"templates/a.yaml": baseDeployment + "---\n---\nkind: Service\nmetadata:\n  name: app\n  namespace: d8-test\n",
+	})
+
+	require.Len(t, objects, 3)
+	assert.Equal(t, "Deployment d8-test/app", objects[0].ID())
+	assert.Equal(t, "templates/a.yaml", objects[0].Source)
+	assert.Equal(t, "Service d8-test/app", objects[1].ID())
+	assert.Equal(t, "ClusterRole d8:test", objects[2].ID())
+}
+
+func TestCompare(t *testing.T) {
+	oldObjects := mustParse(t, map[string]string{
+		"templates/deployment.yaml": baseDeployment,
+		"templates/removed.yaml":    "kind: ConfigMap\nmetadata:\n  name: gone\n  namespace: d8-test\n",
+		"templates/same.yaml":       "kind: Secret\nmetadata:\n  name: same\n  namespace: d8-test\n",
+	})
+
+	changed := strings.ReplaceAll(baseDeployment, "replicas: 1", "replicas: 2")
+	changed = strings.ReplaceAll(changed, "sidecar:1", "sidecar:2")
+
+	newObjects := mustParse(t, map[string]string{
+		"templates/deployment.yaml": changed,
+		"templates/added.yaml":      "kind: ConfigMap\nmetadata:\n  name: new\n  namespace: d8-test\n",
+		"templates/same.yaml":       "kind: Secret\nmetadata:\n  name: same\n  namespace: d8-test\n",
+	})
+
+	res := Compare(oldObjects, newObjects)
+
+	require.Len(t, res.Added, 1)
+	assert.Equal(t, "ConfigMap d8-test/new", res.Added[0].ID())
+	require.Len(t, res.Removed, 1)
+	assert.Equal(t, "ConfigMap d8-test/gone", res.Removed[0].ID())
+	assert.Equal(t, 1, res.Unchanged)
+
+	require.Len(t, res.Changed, 1)
+	assert.Equal(t, []Change{
+		{Path: "spec.replicas", Op: Modified, Old: float64(1), New: float64(2)},
+		{
+			Path: "spec.template.spec.containers[name=sidecar].image", Op: Modified,
+			Old: "registry.example.com/sidecar:1", New: "registry.example.com/sidecar:2",
+		},
+	}, res.Changed[0].Changes)
+	assert.Equal(t, "1 added, 1 removed, 1 changed, 1 unchanged", res.Summary())
+}
+
+func TestCompareReorderedAndMoved(t *testing.T) {
+	cm := "kind: ConfigMap\nmetadata:\n  name: cm\n  namespace: d8-test\n"
+
+	oldObjects := mustParse(t, map[string]string{"templates/a.yaml": baseDeployment + "---\n" + cm})
+	newObjects := mustParse(t, map[string]string{
+		"templates/a.yaml": cm + "---\n" + baseDeployment,
+	})
+
+	reordered := Compare(oldObjects, newObjects)
+	assert.True(t, reordered.Empty())
+}
+
+func TestCompareMovedObject(t *testing.T) {
+	cm := "kind: ConfigMap\nmetadata:\n  name: cm\n  namespace: d8-test\n"
+
+	oldObjects := mustParse(t, map[string]string{"templates/a.yaml": baseDeployment + "---\n" + cm})
+	moved := mustParse(t, map[string]string{"templates/a.yaml": baseDeployment, "templates/b.yaml": cm})
+
+	res := Compare(oldObjects, moved)
+
+	require.Len(t, res.Changed, 1, "moving an object to another template is a change")
+	assert.Empty(t, res.Changed[0].Changes, "the fields did not change")
+	assert.Equal(t, "templates/a.yaml", res.Changed[0].Old.Source)
+	assert.Equal(t, "templates/b.yaml", res.Changed[0].New.Source)
+	assert.Equal(t, "0 added, 0 removed, 1 changed, 1 unchanged", res.Summary())
+
+	var sb strings.Builder
+	require.NoError(t, WriteMarkdown(&sb, "Render diff", &res))
+	assert.Contains(t, sb.String(), "templates/a.yaml → templates/b.yaml")
+	assert.Contains(t, sb.String(), "Moved without field changes.")
+}
+
+func TestFieldsLists(t *testing.T) {
+	changes := Fields(
+		map[string]any{"args": []any{"a", "b"}, "env": []any{map[string]any{"name": "A", "value": "1"}}},
+		map[string]any{"args": []any{"a"}, "env": []any{
+			map[string]any{"name": "B", "value": "2"},
+			map[string]any{"name": "A", "value": "1"},
+		}},
+	)
+
+	assert.Equal(t, []Change{
+		{Path: "args[1]", Op: Removed, Old: "b"},
+		{Path: "env[name=B]", Op: Added, New: map[string]any{"name": "B", "value": "2"}},
+	}, changes)
+}
+
+func TestFieldsKeysWithDots(t *testing.T) {
+	changes := Fields(
+		map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "a"}}},
+		map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "b"}}},
+	)
+
+	require.Len(t, changes, 1)
+	assert.Equal(t, "metadata.labels[app.kubernetes.io/name]", changes[0].Path)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	oldObjects := mustParse(t, map[string]string{"templates/deployment.yaml": baseDeployment})
+	newObjects := mustParse(t, map[string]string{
+		"templates/deployment.yaml": strings.ReplaceAll(baseDeployment, "replicas: 1", "replicas: 3"),
+		"templates/cm.yaml":         "kind: ConfigMap\nmetadata:\n  name: cm\n  namespace: d8-test\n",
+	})
+
+	res := Compare(oldObjects, newObjects)
+
+	var sb strings.Builder
+	require.NoError(t, WriteMarkdown(&sb, "Render diff", &res))
+
+	out := sb.String()
+	assert.Contains(t, out, "### Render diff\n")
+	assert.Contains(t, out, "**1 added, 0 removed, 1 changed, 0 unchanged**")
+	assert.Contains(t, out, "#### Added")
+	assert.Contains(t, out, "+ kind: ConfigMap")
+	assert.Contains(t, out, "#### Changed")
+	assert.Contains(t, out, "- spec.replicas: 1\n+ spec.replicas: 3\n")
+	assert.NotContains(t, out, "#### Removed")
+
+	empty := Compare(oldObjects, oldObjects)
+
+	sb.Reset()
+	require.NoError(t, WriteMarkdown(&sb, "Render diff", &empty))
+	assert.Equal(t, "### Render diff\n\nNo changes in rendered output (1 objects).\n", sb.String())
+}
+
+func TestParseStream(t *testing.T) {
+	objects, err := ParseStream("---\n# Source: templates/deployment.yaml\n" + baseDeployment +
+		"---\n# Source: templates/cm.yaml\nkind: ConfigMap\nmetadata:\n  name: cm\n  namespace: d8-test\n" +
+		"---\nkind: Secret\nmetadata:\n  name: s\n")
+	require.NoError(t, err)
+
+	require.Len(t, objects, 3)
+	assert.Equal(t, "Deployment d8-test/app", objects[0].ID())
+	assert.Equal(t, "templates/deployment.yaml", objects[0].Source)
+	assert.Equal(t, "templates/cm.yaml", objects[1].Source)
+	assert.Empty(t, objects[2].Source, "documents without a source comment")
+}
+
+func TestWriteUnified(t *testing.T) {
+	oldObjects := mustParse(t, map[string]string{
+		"templates/deployment.yaml": baseDeployment,
+		"templates/gone.yaml":       "kind: ConfigMap\nmetadata:\n  name: gone\n  namespace: d8-test\n",
+	})
+	newObjects := mustParse(t, map[string]string{
+		"templates/deployment.yaml": strings.ReplaceAll(baseDeployment, "replicas: 1", "replicas: 3"),
+	})
+
+	res := Compare(oldObjects, newObjects)
+
+	var sb strings.Builder
+	require.NoError(t, WriteUnified(&sb, &res, "expected", "rendered"))
+
+	out := sb.String()
+	assert.Contains(t, out, "--- expected: ConfigMap d8-test/gone (templates/gone.yaml)\n+++ rendered: (none)\n")
+	assert.Contains(t, out, "-kind: ConfigMap\n")
+	assert.Contains(t, out, "--- expected: Deployment d8-test/app (templates/deployment.yaml)\n"+
+		"+++ rendered: Deployment d8-test/app (templates/deployment.yaml)\n")
+	assert.Contains(t, out, "-  replicas: 1\n+  replicas: 3\n")
+	assert.NotContains(t, out, "sidecar", "unchanged lines far from the change are left out")
+	assert.Less(t, strings.Index(out, "ConfigMap"), strings.Index(out, "Deployment"), "removed objects come first")
+}
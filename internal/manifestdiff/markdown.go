@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// WriteMarkdown renders the result as GitHub-flavoured Markdown suitable for a
+// pull request comment: a summary line, then one collapsible section per added,
+// removed and changed object. Changed objects list their field-level changes in
+// a "diff" code block.
+func WriteMarkdown(w io.Writer, title string, res *Result) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "### %s\n\n", title)
+
+	if res.Empty() {
+		fmt.Fprintf(&sb, "No changes in rendered output (%d objects).\n", res.Unchanged)
+
+		_, err := io.WriteString(w, sb.String())
+
+		return err
+	}
+
+	fmt.Fprintf(&sb, "**%s**\n", res.Summary())
+
+	if len(res.Added) > 0 {
+		sb.WriteString("\n#### Added\n\n")
+
+		for i := range res.Added {
+			writeObjectDetails(&sb, &res.Added[i], "+")
+		}
+	}
+
+	if len(res.Removed) > 0 {
+		sb.WriteString("\n#### Removed\n\n")
+
+		for i := range res.Removed {
+			writeObjectDetails(&sb, &res.Removed[i], "-")
+		}
+	}
+
+	if len(res.Changed) > 0 {
+		sb.WriteString("\n#### Changed\n\n")
+
+		for i := range res.Changed {
+			writeChangedDetails(&sb, &res.Changed[i])
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func writeObjectDetails(sb *strings.Builder, obj *Object, sign string) {
+	fmt.Fprintf(sb, "<details><summary><code>%s</code> (%s)</summary>\n\n```diff\n", obj.ID(), obj.Source)
+
+	data, err := yaml.Marshal(obj.Content)
+	if err != nil {
+		data = []byte(err.Error())
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		sb.WriteString(sign + " " + line + "\n")
+	}
+
+	sb.WriteString("```\n\n</details>\n")
+}
+
+func writeChangedDetails(sb *strings.Builder, diff *ObjectDiff) {
+	source := diff.New.Source
+	if diff.Old.Source != diff.New.Source {
+		source = diff.Old.Source + " → " + diff.New.Source
+	}
+
+	fmt.Fprintf(sb, "<details><summary><code>%s</code> (%s, %d field(s))</summary>\n\n", diff.New.ID(), source, len(diff.Changes))
+
+	if len(diff.Changes) == 0 {
+		sb.WriteString("Moved without field changes.\n\n</details>\n")
+		return
+	}
+
+	sb.WriteString("```diff\n")
+
+	for _, line := range FormatChanges(diff.Changes) {
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("```\n\n</details>\n")
+}
+
+// FormatChanges renders field changes as diff lines: a removed or old value is
+// prefixed with "-", an added or new value with "+".
+func FormatChanges(changes []Change) []string {
+	lines := make([]string, 0, len(changes)*2)
+
+	for _, change := range changes {
+		switch change.Op {
+		case Added:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", change.Path, formatValue(change.New)))
+		case Removed:
+			lines = append(lines, fmt.Sprintf("- %s: %s", change.Path, formatValue(change.Old)))
+		case Modified:
+			lines = append(lines,
+				fmt.Sprintf("- %s: %s", change.Path, formatValue(change.Old)),
+				fmt.Sprintf("+ %s: %s", change.Path, formatValue(change.New)),
+			)
+		}
+	}
+
+	return lines
+}
+
+// formatValue renders a field value on a single line: strings as-is unless they
+// span several lines, composite values as compact JSON.
+func formatValue(value any) string {
+	if s, ok := value.(string); ok && !strings.Contains(s, "\n") {
+		return s
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestdiff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// contextLines is the number of unchanged lines shown around each hunk.
+const contextLines = 3
+
+// WriteUnified renders the result as a unified diff of the objects' YAML: one
+// section per removed, added and changed object, in that order, each with a
+// "---"/"+++" header naming the object and its source. oldName and newName
+// label the two sides (e.g. "expected" and "rendered").
+func WriteUnified(w io.Writer, res *Result, oldName, newName string) error {
+	var sb strings.Builder
+
+	for i := range res.Removed {
+		obj := &res.Removed[i]
+		if err := writeUnifiedObject(&sb, oldName+": "+describe(obj), newName+": (none)", obj.Content, nil); err != nil {
+			return err
+		}
+	}
+
+	for i := range res.Added {
+		obj := &res.Added[i]
+		if err := writeUnifiedObject(&sb, oldName+": (none)", newName+": "+describe(obj), nil, obj.Content); err != nil {
+			return err
+		}
+	}
+
+	for i := range res.Changed {
+		diff := &res.Changed[i]
+
+		from, to := oldName+": "+describe(&diff.Old), newName+": "+describe(&diff.New)
+		if err := writeUnifiedObject(&sb, from, to, diff.Old.Content, diff.New.Content); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// UnifiedText returns a unified diff of two plain texts, for output that does
+// not differ object-wise (e.g. only in comments or document order).
+func UnifiedText(oldText, newText, oldName, newName string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldText),
+		B:        difflib.SplitLines(newText),
+		FromFile: oldName,
+		ToFile:   newName,
+		Context:  contextLines,
+	})
+}
+
+func writeUnifiedObject(sb *strings.Builder, from, to string, oldContent, newContent map[string]any) error {
+	oldText, err := marshalContent(oldContent)
+	if err != nil {
+		return err
+	}
+
+	newText, err := marshalContent(newContent)
+	if err != nil {
+		return err
+	}
+
+	diff, err := UnifiedText(oldText, newText, from, to)
+	if err != nil {
+		return fmt.Errorf("diff %s: %w", to, err)
+	}
+
+	// A changed object with identical content was only moved between sources.
+	if diff == "" {
+		fmt.Fprintf(sb, "--- %s\n+++ %s\n", from, to)
+		return nil
+	}
+
+	sb.WriteString(diff)
+
+	return nil
+}
+
+func marshalContent(content map[string]any) (string, error) {
+	if content == nil {
+		return "", nil
+	}
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func describe(obj *Object) string {
+	if obj.Source == "" {
+		return obj.ID()
+	}
+
+	return obj.ID() + " (" + obj.Source + ")"
+}
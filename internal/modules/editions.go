@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// editionValuesPrefix / editionValuesSuffix bound the edition name in an
+	// edition-specific values schema file (e.g. "values_ce.yaml" -> "ce").
+	editionValuesPrefix = "values_"
+	editionValuesSuffix = ".yaml"
+)
+
+// DiscoverEditions returns the edition-specific values schema files found in the
+// module's openapi directory, keyed by edition name (e.g. "ce" ->
+// "values_ce.yaml"). A module without edition-specific schemas yields an empty
+// map.
+func DiscoverEditions(modulePath string) (map[string]string, error) {
+	entries, err := os.ReadDir(filepath.Join(modulePath, "openapi"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read openapi directory: %w", err)
+	}
+
+	editions := make(map[string]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if !strings.HasPrefix(name, editionValuesPrefix) || !strings.HasSuffix(name, editionValuesSuffix) {
+			continue
+		}
+
+		edition := strings.TrimSuffix(strings.TrimPrefix(name, editionValuesPrefix), editionValuesSuffix)
+		if edition == "" {
+			continue
+		}
+
+		editions[edition] = name
+	}
+
+	return editions, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modules
+
+import (
+	"fmt"
+
+	"github.com/mohae/deepcopy"
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/internal/storage"
+	dmtErrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+// GetHAStorage returns the module objects rendered in the high-availability
+// scenario: the module's values with global.highAvailability,
+// global.discovery.clusterControlPlaneIsHighlyAvailable and the module's own
+// highAvailability setting enabled, which is what helm_lib_is_ha_to_value
+// switches replicas on. The default lint render is not highly available. The
+// render runs once, on first use, and reports the templates it drops to
+// errorList.
+func (m *Module) GetHAStorage(errorList *dmtErrors.LintRuleErrorsList) (map[storage.ResourceIndex]storage.StoreObject, error) {
+	if m.haStore != nil || m.haErr != nil {
+		return m.haStorage(), m.haErr
+	}
+
+	vals, _ := deepcopy.Copy(m.values).(map[string]any)
+	if vals == nil {
+		vals = map[string]any{}
+	}
+
+	enableHighAvailability(vals, values.ModuleCamelName(m.GetName()))
+
+	objectStore := storage.NewUnstructuredObjectStore()
+
+	if err := RunRender(m, vals, objectStore, errorList); err != nil {
+		m.haErr = fmt.Errorf("render the high-availability scenario: %w", err)
+
+		return nil, m.haErr
+	}
+
+	m.haStore = objectStore
+
+	return m.haStorage(), nil
+}
+
+func (m *Module) haStorage() map[storage.ResourceIndex]storage.StoreObject {
+	if m.haStore == nil {
+		return nil
+	}
+
+	return m.haStore.Storage
+}
+
+func enableHighAvailability(vals map[string]any, moduleKey string) {
+	global := childMap(vals, "global")
+	global["highAvailability"] = true
+	childMap(global, "discovery")["clusterControlPlaneIsHighlyAvailable"] = true
+	childMap(vals, moduleKey)["highAvailability"] = true
+}
+
+// childMap returns the map under key, creating it when absent.
+func childMap(parent map[string]any, key string) map[string]any {
+	switch child := parent[key].(type) {
+	case map[string]any:
+		return child
+	case chartutil.Values:
+		return child
+	default:
+		created := map[string]any{}
+		parent[key] = created
+
+		return created
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestEnableHighAvailability(t *testing.T) {
+	vals := map[string]any{
+		"global": chartutil.Values{
+			"highAvailability": false,
+			"discovery":        map[string]any{"clusterUUID": "aa", "clusterControlPlaneIsHighlyAvailable": false},
+		},
+		"myModule": map[string]any{"replicas": 2},
+	}
+
+	enableHighAvailability(vals, "myModule")
+
+	assert.Equal(t, map[string]any{
+		"global": chartutil.Values{
+			"highAvailability": true,
+			"discovery":        map[string]any{"clusterUUID": "aa", "clusterControlPlaneIsHighlyAvailable": true},
+		},
+		"myModule": map[string]any{"replicas": 2, "highAvailability": true},
+	}, vals)
+
+	empty := map[string]any{}
+	enableHighAvailability(empty, "myModule")
+
+	assert.Equal(t, map[string]any{
+		"global":   map[string]any{"highAvailability": true, "discovery": map[string]any{"clusterControlPlaneIsHighlyAvailable": true}},
+		"myModule": map[string]any{"highAvailability": true},
+	}, empty)
+}
@@ -23,7 +23,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/go-openapi/spec"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"sigs.k8s.io/yaml"
@@ -53,6 +52,14 @@ type Module struct {
 	objectStore *storage.UnstructuredObjectStore
 	werfFile    string
 	values      map[string]any
+	// edition is the edition (openapi/values_<edition>.yaml) the module was
+	// rendered for; empty for the default openapi/values.yaml render.
+	edition string
+	// globalSchema is the global values schema the module was rendered against.
+	globalSchema *values.GlobalSchema
+	// haStore and haErr hold the high-availability render (see GetHAStorage).
+	haStore *storage.UnstructuredObjectStore
+	haErr   error
 
 	linterConfig *pkg.LintersSettings
 }
@@ -137,6 +144,26 @@ func (m *Module) GetWerfFile() string {
 	return m.werfFile
 }
 
+// GetGlobalSchema returns the global values schema the module was rendered
+// against, or nil for a module that was only loaded.
+func (m *Module) GetGlobalSchema() *values.GlobalSchema {
+	if m == nil {
+		return nil
+	}
+
+	return m.globalSchema
+}
+
+// GetEdition returns the edition the module was rendered for, or "" for the
+// default render.
+func (m *Module) GetEdition() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.edition
+}
+
 func (m *Module) GetModuleConfig() *pkg.LintersSettings {
 	if m == nil {
 		return nil
@@ -238,6 +265,14 @@ func mapContainerRules(linterSettings *pkg.LintersSettings, configSettings *conf
 		globalConfig.Container.Rules.NewRevisionHistoryLimitRule.Impact,
 		configSettings.Container.Impact,
 	)
+	linterSettings.Container.Rules.PodSecurityStandardsRule.SetLevel(
+		globalConfig.Container.Rules.PodSecurityStandardsRule.Impact,
+		configSettings.Container.Impact,
+	)
+	linterSettings.Container.Rules.ObjectReferencesRule.SetLevel(
+		globalConfig.Container.Rules.ObjectReferencesRule.Impact,
+		configSettings.Container.Impact,
+	)
 
 	// Container-specific rules
 	linterSettings.Container.Rules.NameDuplicatesRule.SetLevel(
@@ -268,6 +303,10 @@ func mapContainerRules(linterSettings *pkg.LintersSettings, configSettings *conf
 		globalConfig.Container.Rules.ImageDigestRule.Impact,
 		configSettings.Container.Impact,
 	)
+	linterSettings.Container.Rules.ImagePolicyRule.SetLevel(
+		globalConfig.Container.Rules.ImagePolicyRule.Impact,
+		configSettings.Container.Impact,
+	)
 	linterSettings.Container.Rules.ImagePullPolicyRule.SetLevel(
 		globalConfig.Container.Rules.ImagePullPolicyRule.Impact,
 		configSettings.Container.Impact,
@@ -292,6 +331,14 @@ func mapContainerRules(linterSettings *pkg.LintersSettings, configSettings *conf
 		globalConfig.Container.Rules.ReadinessRule.Impact,
 		configSettings.Container.Impact,
 	)
+	linterSettings.Container.Rules.ProbeCorrectnessRule.SetLevel(
+		globalConfig.Container.Rules.ProbeCorrectnessRule.Impact,
+		configSettings.Container.Impact,
+	)
+	linterSettings.Container.Rules.CapabilitiesRule.SetLevel(
+		globalConfig.Container.Rules.CapabilitiesRule.Impact,
+		configSettings.Container.Impact,
+	)
 	linterSettings.Container.Rules.MountPointsRule.SetLevel(
 		globalConfig.Container.Rules.MountPointsRule.Impact,
 		configSettings.Container.Impact,
@@ -369,12 +416,16 @@ func mapTemplatesRules(linterSettings *pkg.LintersSettings, configSettings *conf
 
 	rules.VPARule.SetLevel(globalRules.VPARule.Impact, fallbackImpact)
 	rules.PDBRule.SetLevel(globalRules.PDBRule.Impact, fallbackImpact)
+	rules.HighAvailabilityRule.SetLevel(globalRules.HighAvailabilityRule.Impact, fallbackImpact)
 	rules.IngressRule.SetLevel(globalRules.IngressRule.Impact, fallbackImpact)
 	rules.HTTPRouteRule.SetLevel(globalRules.HTTPRouteRule.Impact, fallbackImpact)
 	rules.PrometheusRule.SetLevel(globalRules.PrometheusRule.Impact, fallbackImpact)
 	rules.GrafanaRule.SetLevel(globalRules.GrafanaRule.Impact, fallbackImpact)
 	rules.KubeRBACProxyRule.SetLevel(globalRules.KubeRBACProxyRule.Impact, fallbackImpact)
 	rules.ServicePortRule.SetLevel(globalRules.ServicePortRule.Impact, fallbackImpact)
+	rules.ServiceWiringRule.SetLevel(globalRules.ServiceWiringRule.Impact, fallbackImpact)
+	rules.NetworkPolicyRule.SetLevel(globalRules.NetworkPolicyRule.Impact, fallbackImpact)
+	rules.ResourceRequestsRule.SetLevel(globalRules.ResourceRequestsRule.Impact, fallbackImpact)
 	rules.ClusterDomainRule.SetLevel(globalRules.ClusterDomainRule.Impact, fallbackImpact)
 	rules.RegistryRule.SetLevel(globalRules.RegistryRule.Impact, fallbackImpact)
 	rules.EnabledModulesRule.SetLevel(globalRules.EnabledModulesRule.Impact, fallbackImpact)
@@ -385,6 +436,11 @@ func mapTemplatesRules(linterSettings *pkg.LintersSettings, configSettings *conf
 	// passed explicitly because the linter fallback impact defaults to "error".
 	rules.CRDEnabledModulesRule.SetLevel(globalRules.CRDEnabledModulesRule.Impact, pkg.Warn.String())
 
+	// global-values defaults to warn: without a bundled schema for the module's
+	// Deckhouse version the embedded default schema is used, which may lag behind
+	// the release the module targets.
+	rules.GlobalValuesRule.SetLevel(globalRules.GlobalValuesRule.Impact, pkg.Warn.String())
+
 	rules.MountPointsRule.SetLevel(globalRules.MountPointsRule.Impact, fallbackImpact)
 	rules.WebhookConfigurationRule.SetLevel(globalRules.WebhookConfigurationRule.Impact, fallbackImpact)
 	rules.HelmRenderRule.SetLevel(globalRules.HelmRenderRule.Impact, fallbackImpact)
@@ -446,7 +502,7 @@ func mapExclusionRulesAndSettings(linterSettings *pkg.LintersSettings, configSet
 	// no excluded rules - mapDocumentationExclusionsAndSettings(linterSettings, configSettings)
 }
 
-// mapContainerExclusions maps Container linter exclusion rules
+// mapContainerExclusions maps Container linter exclusion rules and settings
 func mapContainerExclusions(linterSettings *pkg.LintersSettings, configSettings *config.LintersSettings) {
 	excludes := &linterSettings.Container.ExcludeRules
 	configExcludes := &configSettings.Container.ExcludeRules
@@ -455,19 +511,30 @@ func mapContainerExclusions(linterSettings *pkg.LintersSettings, configSettings
 	excludes.NamespaceLabelsRule = configExcludes.NamespaceLabelsRule.Get()
 	excludes.DNSPolicy = configExcludes.DNSPolicy.Get()
 	excludes.PriorityClass = configExcludes.PriorityClass.Get()
+	excludes.PodSecurityStandards = configExcludes.PodSecurityStandards.Get()
+	excludes.ObjectReferences = configExcludes.ObjectReferences.Get()
 	excludes.HostNetworkPorts = configExcludes.HostNetworkPorts.Get()
 	excludes.Ports = configExcludes.Ports.Get()
 	excludes.ReadOnlyRootFilesystem = configExcludes.ReadOnlyRootFilesystem.Get()
 	excludes.ImageDigest = configExcludes.ImageDigest.Get()
+	excludes.ImagePolicy = configExcludes.ImagePolicy.Get()
 	excludes.Resources = configExcludes.Resources.Get()
 	excludes.SecurityContext = configExcludes.SecurityContext.Get()
 	excludes.Liveness = configExcludes.Liveness.Get()
 	excludes.Readiness = configExcludes.Readiness.Get()
+	excludes.ProbeCorrectness = configExcludes.ProbeCorrectness.Get()
+	excludes.Capabilities = configExcludes.Capabilities.Get()
 	excludes.SeccompProfile = configExcludes.SeccompProfile.Get()
 	excludes.NoNewPrivileges = configExcludes.NoNewPrivileges.Get()
 	excludes.SysCgroupMount = configExcludes.SysCgroupMount.Get()
 	excludes.Description = pkg.StringRuleExcludeList(configExcludes.Description)
 	excludes.MountPoints = pkg.StringRuleExcludeList(configExcludes.MountPoints)
+
+	// Additional settings
+	linterSettings.Container.ExternalReferences = configSettings.Container.ExternalReferences.Get()
+	linterSettings.Container.ImagePolicy = configSettings.Container.ImagePolicy.Get()
+	linterSettings.Container.ProbeCorrectness = configSettings.Container.ProbeCorrectness.Get()
+	linterSettings.Container.AllowedCapabilities = configSettings.Container.AllowedCapabilities.Get()
 }
 
 // mapImageExclusionsAndSettings maps Image linter exclusions and additional settings
@@ -510,7 +577,11 @@ func mapTemplatesExclusionsAndSettings(linterSettings *pkg.LintersSettings, conf
 	configExcludes := &configSettings.Templates.ExcludeRules
 	excludes.VPAAbsent = configExcludes.VPAAbsent.Get()
 	excludes.PDBAbsent = configExcludes.PDBAbsent.Get()
+	excludes.HighAvailability = configExcludes.HighAvailability.Get()
 	excludes.ServicePort = configExcludes.ServicePort.Get()
+	excludes.ServiceWiring = configExcludes.ServiceWiring.Get()
+	excludes.NetworkPolicy = configExcludes.NetworkPolicy.Get()
+	excludes.ResourceRequests = configExcludes.ResourceRequests.Get()
 	excludes.KubeRBACProxy = pkg.StringRuleExcludeList(configExcludes.KubeRBACProxy)
 	excludes.Ingress = configExcludes.Ingress.Get()
 	excludes.HTTPRoute = configExcludes.HTTPRoute.Get()
@@ -522,6 +593,8 @@ func mapTemplatesExclusionsAndSettings(linterSettings *pkg.LintersSettings, conf
 	// Additional settings
 	linterSettings.Templates.PrometheusRuleSettings.Disable = configSettings.Templates.PrometheusRules.Disable
 	linterSettings.Templates.GrafanaDashboardsSettings.Disable = configSettings.Templates.GrafanaDashboards.Disable
+	linterSettings.Templates.NetworkPolicySettings.RequireFullCoverage = configSettings.Templates.NetworkPolicies.RequireFullCoverage
+	linterSettings.Templates.ResourceRequestsSettings = configSettings.Templates.ResourceRequests.Get()
 }
 
 // mapRBACExclusions maps RBAC linter exclusion rules
@@ -555,13 +628,34 @@ func mapModuleExclusionsAndSettings(linterSettings *pkg.LintersSettings, configS
 	linterSettings.Module.HelmignoreRuleSettings.Disable = configSettings.Module.Helmignore.Disable
 }
 
-func NewModule(path string, vals *chartutil.Values, globalSchema *spec.Schema, rootConfig *config.RootConfig, errorList *dmtErrors.LintRuleErrorsList) (*Module, error) {
+func NewModule(path string, vals *chartutil.Values, globalSchema *values.GlobalSchema, rootConfig *config.RootConfig, errorList *dmtErrors.LintRuleErrorsList) (*Module, error) {
+	return newModule(path, "", vals, globalSchema, rootConfig, errorList)
+}
+
+// NewEditionModule is like NewModule, but renders the module for one of its
+// editions: values are generated from openapi/values_<edition>.yaml instead of
+// openapi/values.yaml and werf.yaml is rendered with the matching .Env (see
+// werf.EditionEnv).
+func NewEditionModule(path, edition string, vals *chartutil.Values, globalSchema *values.GlobalSchema, rootConfig *config.RootConfig, errorList *dmtErrors.LintRuleErrorsList) (*Module, error) {
+	return newModule(path, edition, vals, globalSchema, rootConfig, errorList)
+}
+
+func newModule(path, edition string, vals *chartutil.Values, globalSchema *values.GlobalSchema, rootConfig *config.RootConfig, errorList *dmtErrors.LintRuleErrorsList) (*Module, error) {
 	module, err := newModuleFromPath(path)
 	if err != nil {
 		return nil, err
 	}
 
-	schemas, err := values.ComposeValuesFromSchemas(module.GetPath(), module.GetName(), globalSchema)
+	module.edition = edition
+
+	valuesFile, werfEnv := "values.yaml", werf.Env()
+	if edition != "" {
+		valuesFile, werfEnv = editionValuesPrefix+edition+editionValuesSuffix, werf.EditionEnv(edition)
+	}
+
+	module.globalSchema = globalSchema
+
+	schemas, err := values.ComposeValuesFromSchemasForValuesFile(module.GetPath(), module.GetName(), globalSchema.Schema, valuesFile)
 	if err != nil {
 		return nil, err
 	}
@@ -580,7 +674,7 @@ func NewModule(path string, vals *chartutil.Values, globalSchema *spec.Schema, r
 	module.objectStore = objectStore
 	module.values = schemas
 
-	werfFile, err := werf.GetWerfConfig(path)
+	werfFile, err := werf.GetWerfConfigForEnv(path, werfEnv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get werf config: %w", err)
 	}
@@ -602,6 +696,12 @@ func NewModule(path string, vals *chartutil.Values, globalSchema *spec.Schema, r
 	return module, nil
 }
 
+// LoadModule reads the module's metadata (name, namespace) and loads its chart
+// without generating values or rendering anything.
+func LoadModule(path string) (*Module, error) {
+	return newModuleFromPath(path)
+}
+
 func newModuleFromPath(path string) (*Module, error) {
 	moduleYamlConfig, err := ParseModuleConfigFile(path)
 	if err != nil {
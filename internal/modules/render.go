@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/go-openapi/spec"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/deckhouse/dmt/internal/modules/render"
 	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/internal/sourcemap"
 	"github.com/deckhouse/dmt/internal/storage"
 	dmtErrors "github.com/deckhouse/dmt/pkg/errors"
 )
@@ -56,6 +58,8 @@ func RunRender(m *Module, vals chartutil.Values, objectStore *storage.Unstructur
 
 	var resultErr error
 
+	sources := newTemplateSources()
+
 	for _, obj := range objects {
 		restoreStrippedNamespace(obj, m.GetNamespace())
 
@@ -71,7 +75,9 @@ func RunRender(m *Module, vals chartutil.Values, objectStore *storage.Unstructur
 			continue
 		}
 
-		if err := objectStore.Put(absPath, obj.FilePath, obj.Object, docBytes); err != nil {
+		source := sources.document(absPath, obj.GetKind(), obj.GetName())
+
+		if err := objectStore.PutWithSource(absPath, obj.FilePath, obj.Object, docBytes, source); err != nil {
 			resultErr = errors.Join(resultErr, err)
 		}
 	}
@@ -84,6 +90,33 @@ func RunRender(m *Module, vals chartutil.Values, objectStore *storage.Unstructur
 	return nil
 }
 
+// templateSources parses each rendered template once to map its objects back to
+// template lines (see sourcemap).
+type templateSources map[string]*sourcemap.Template
+
+func newTemplateSources() templateSources {
+	return templateSources{}
+}
+
+// document returns the template document the object was rendered from, or nil
+// when the template cannot be read or has no matching document.
+func (s templateSources) document(path, kind, name string) *sourcemap.Node {
+	tpl, ok := s[path]
+	if !ok {
+		if src, err := os.ReadFile(path); err == nil {
+			tpl = sourcemap.Parse(src)
+		}
+
+		s[path] = tpl
+	}
+
+	if tpl == nil {
+		return nil
+	}
+
+	return tpl.Document(kind, name)
+}
+
 // clusterScopedKinds are the Kubernetes kinds that have no namespace. A rendered
 // object of any other kind is namespaced; see restoreStrippedNamespace. An unknown
 // custom-resource kind is treated as namespaced — the cross-object linters only key
@@ -126,6 +159,10 @@ func restoreStrippedNamespace(obj render.Object, namespace string) {
 //
 // Image references resolve from dmt's image/registry stubs (digests scanned from
 // images/, injected by values.HelmFormatModuleImages). Suitable for golden-snapshot tests.
+//
+// Unlike the lint render this one is strict: a template that aborts the render
+// (`fail`, `required`, an execution error) fails it, and the error names every
+// such template with its cause, so tests can assert on it.
 func RenderModuleWithValues(modulePath string, userValues map[string]any) (map[string]string, error) {
 	mod, err := newModuleFromPath(modulePath)
 	if err != nil {
@@ -141,7 +178,7 @@ func RenderModuleWithValues(modulePath string, userValues map[string]any) (map[s
 		return nil, fmt.Errorf("prepare render values: %w", err)
 	}
 
-	return renderModuleFiles(mod, renderValues)
+	return renderModuleStrict(mod, renderValues)
 }
 
 // RenderModuleForValuesFile renders the module at modulePath using values
@@ -163,16 +200,113 @@ func RenderModuleForValuesFile(modulePath string, globalSchema *spec.Schema, val
 	return renderModuleFiles(mod, renderValues)
 }
 
-// renderModuleFiles strictly renders the module through nelm and returns the
+// RenderModuleWithConfig renders the module at modulePath with values
+// auto-generated from its openapi schemas (like RenderModuleForValuesFile with
+// "values.yaml"), except that the module's config values are replaced by config.
+// onDrop, if set, is called for every template the tolerant render had to drop
+// (see render.Options.OnDrop).
+func RenderModuleWithConfig(
+	modulePath string,
+	globalSchema *spec.Schema,
+	config map[string]any,
+	onDrop func(templatePath, renderErr string),
+) (map[string]string, error) {
+	mod, err := newModuleFromPath(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	renderValues, err := values.ComposeValuesWithConfig(mod.GetPath(), mod.GetName(), globalSchema, config)
+	if err != nil {
+		return nil, fmt.Errorf("compose values: %w", err)
+	}
+
+	return renderModule(mod, render.Options{
+		Values: renderValues,
+		OnDrop: onDrop,
+	})
+}
+
+// RenderModuleForValues renders the module at modulePath with already composed
+// render values (e.g. the result of values.ComposeValuesFromSchemas) and returns
+// the rendered manifests keyed by chart-relative source file path. Unlike
+// RenderModuleWithValues no stubs are injected, so two revisions of a module can
+// be rendered with exactly the same values.
+func RenderModuleForValues(modulePath string, vals map[string]any) (map[string]string, error) {
+	mod, err := newModuleFromPath(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderModuleFiles(mod, vals)
+}
+
+// RenderModuleWithTemplates renders the module at modulePath with values
+// auto-generated from its openapi schemas (like RenderModuleForValuesFile with
+// "values.yaml"), with the given extra templates (file name -> content) added to
+// templates/ for the duration of the render. onDrop, if set, is called for every
+// template the tolerant render had to drop (see render.Options.OnDrop).
+func RenderModuleWithTemplates(
+	modulePath string,
+	globalSchema *spec.Schema,
+	templates map[string][]byte,
+	onDrop func(templatePath, renderErr string),
+) (map[string]string, error) {
+	mod, err := newModuleFromPath(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	renderValues, err := values.ComposeValuesFromSchemasForValuesFile(mod.GetPath(), mod.GetName(), globalSchema, "values.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("compose values: %w", err)
+	}
+
+	return renderModule(mod, render.Options{
+		Values:         renderValues,
+		OnDrop:         onDrop,
+		ExtraTemplates: templates,
+	})
+}
+
+// renderModuleStrict renders the module like renderModuleFiles but fails when the
+// tolerant render had to drop any template, reporting the dropped templates and
+// their causes in render order.
+func renderModuleStrict(mod *Module, vals map[string]any) (map[string]string, error) {
+	var dropped []error
+
+	files, err := renderModule(mod, render.Options{
+		Values: vals,
+		OnDrop: func(templatePath, renderErr string) {
+			dropped = append(dropped, fmt.Errorf("template %s: %s", templatePath, renderErr))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dropped) > 0 {
+		return nil, fmt.Errorf("render module: %w", errors.Join(dropped...))
+	}
+
+	return files, nil
+}
+
+// renderModuleFiles renders the module through nelm and returns the
 // manifests keyed by chart-relative source path. Multiple documents rendered from
 // one template file are joined with a "---" separator, preserving the previous
 // map[path]->manifests shape its callers expect.
 func renderModuleFiles(mod *Module, vals map[string]any) (map[string]string, error) {
-	objects, err := render.Render(context.Background(), mod.GetNamespace(), mod.GetName(), render.Options{
-		Path:             mod.GetPath(),
-		Values:           vals,
-		ExtraAPIVersions: render.ExtraAPIVersions(),
-	})
+	return renderModule(mod, render.Options{Values: vals})
+}
+
+// renderModule renders the module with opts, filling in the chart path and the
+// extra API versions.
+func renderModule(mod *Module, opts render.Options) (map[string]string, error) {
+	opts.Path = mod.GetPath()
+	opts.ExtraAPIVersions = render.ExtraAPIVersions()
+
+	objects, err := render.Render(context.Background(), mod.GetNamespace(), mod.GetName(), opts)
 	if err != nil {
 		return nil, fmt.Errorf("render module: %w", err)
 	}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// injectTemplates writes the given templates (file name -> content) into
+// chartDir/templates/ and returns a cleanup func the caller must defer. Like the
+// image stub, they are removed once the render is done, leaving the module's
+// source untouched. A name that already exists in templates/ is rejected rather
+// than overwritten.
+func injectTemplates(chartDir string, templates map[string][]byte) (func(), error) {
+	var written []string
+
+	cleanup := func() {
+		for _, path := range written {
+			_ = os.Remove(path)
+		}
+	}
+
+	if len(templates) == 0 {
+		return cleanup, nil
+	}
+
+	templatesDir := filepath.Join(chartDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create templates dir: %w", err)
+	}
+
+	for name, content := range templates {
+		path := filepath.Join(templatesDir, name)
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			cleanup()
+
+			return nil, fmt.Errorf("write extra template %q: %w", name, err)
+		}
+
+		written = append(written, path)
+
+		_, err = f.Write(content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			cleanup()
+
+			return nil, fmt.Errorf("write extra template %q: %w", name, err)
+		}
+	}
+
+	return cleanup, nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/werf/nelm/pkg/action"
 	"github.com/werf/nelm/pkg/common"
 	"github.com/werf/nelm/pkg/helm/pkg/chart/loader"
+	"github.com/werf/nelm/pkg/helm/pkg/engine"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 
@@ -82,6 +83,16 @@ type Options struct {
 	// (e.g. "templates/postgres.yaml") and renderErr is the abort that caused the
 	// drop. Linting callers use it to surface a warning; others may leave it nil.
 	OnDrop func(templatePath, renderErr string)
+	// ExtraTemplates (file name -> content) are written into the chart's
+	// templates/ directory for the duration of the render, so callers can render
+	// chart files (e.g. monitoring rules) in the chart's own context.
+	ExtraTemplates map[string][]byte
+	// DebugOutput, if set, turns on the debug mode of nelm's template engine for
+	// the duration of the render and receives what the templates' printf_debug
+	// and dump_debug calls print. The engine's debug switch and the standard
+	// logger it prints to are process-wide, so such a render must not run
+	// concurrently with other renders.
+	DebugOutput io.Writer
 }
 
 // Render renders the chart at opts.Path with nelm's public action.ChartRender,
@@ -113,6 +124,12 @@ func Render(ctx context.Context, namespace, releaseName string, opts Options) ([
 	}
 	defer cleanupStub()
 
+	cleanupExtra, err := injectTemplates(opts.Path, opts.ExtraTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanupExtra()
+
 	valuesFile, cleanup, err := writeTempValues(opts.Values)
 	if err != nil {
 		return nil, err
@@ -123,6 +140,16 @@ func Render(ctx context.Context, namespace, releaseName string, opts Options) ([
 	neutralizer := newTemplateNeutralizer(opts.Path)
 	defer neutralizer.restore()
 
+	if opts.DebugOutput != nil {
+		engine.Debug = true
+		stdlog.SetOutput(opts.DebugOutput)
+
+		defer func() {
+			engine.Debug = false
+			stdlog.SetOutput(io.Discard)
+		}()
+	}
+
 	var res *action.ChartRenderResultV2
 
 	for {
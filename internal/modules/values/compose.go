@@ -21,6 +21,7 @@ import (
 
 	"dario.cat/mergo"
 	"github.com/go-openapi/spec"
+	"github.com/mohae/deepcopy"
 	"helm.sh/helm/v3/pkg/chartutil"
 
 	"github.com/deckhouse/dmt/internal/modules/values/schema/defaults"
@@ -95,6 +96,49 @@ func ComposeValuesFromSchemas(modulePath, moduleName string, globalSchema *spec.
 // generates the module values from the given openapi values schema file name
 // (e.g. "values_ce.yaml") instead of the default "values.yaml".
 func ComposeValuesFromSchemasForValuesFile(modulePath, moduleName string, globalSchema *spec.Schema, valuesFile string) (chartutil.Values, error) {
+	rawValues, err := generateValues(modulePath, moduleName, globalSchema, valuesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return HelmFormatModuleImages(modulePath, moduleName, rawValues)
+}
+
+// ComposeValuesWithConfig is like ComposeValuesFromSchemas, but the module's
+// config values (the properties of config-values.yaml) are replaced by config,
+// e.g. a documented settings example.
+func ComposeValuesWithConfig(modulePath, moduleName string, globalSchema *spec.Schema, config map[string]any) (chartutil.Values, error) {
+	rawValues, err := generateValues(modulePath, moduleName, globalSchema, "values.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := LoadModuleSchemas(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	moduleValues, _ := rawValues[ModuleCamelName(moduleName)].(map[string]any)
+	if moduleValues == nil {
+		moduleValues = map[string]any{}
+		rawValues[ModuleCamelName(moduleName)] = moduleValues
+	}
+
+	if configSchema := schemas[ConfigValuesSchema]; configSchema != nil {
+		for key := range configSchema.Properties {
+			delete(moduleValues, key)
+		}
+	}
+
+	for key, value := range config {
+		moduleValues[key] = deepcopy.Copy(value)
+	}
+
+	return HelmFormatModuleImages(modulePath, moduleName, rawValues)
+}
+
+// generateValues generates the module and global values from their schemas.
+func generateValues(modulePath, moduleName string, globalSchema *spec.Schema, valuesFile string) (map[string]any, error) {
 	if globalSchema == nil {
 		globalSchema = &spec.Schema{}
 	}
@@ -116,5 +160,5 @@ func ComposeValuesFromSchemasForValuesFile(modulePath, moduleName string, global
 		return nil, fmt.Errorf("generate values: %w", err)
 	}
 
-	return HelmFormatModuleImages(modulePath, moduleName, rawValues)
+	return rawValues, nil
 }
@@ -0,0 +1,261 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/deckhouse/pkg/log"
+)
+
+// globalVersionsDir holds the bundled global schemas, one directory per
+// Deckhouse minor version (e.g. "1.68") with config-values.yaml and values.yaml
+// copied from global-hooks/openapi of that release.
+const globalVersionsDir = "global-openapi/versions"
+
+// maxPatch stands for the last patch release of a bundled minor version.
+const maxPatch = 1<<16 - 1
+
+//go:embed global-openapi/versions
+var globalVersionsFS embed.FS
+
+// GlobalSchema is the global values schema a module is rendered and linted
+// against, together with a description of where it was taken from.
+type GlobalSchema struct {
+	Schema *spec.Schema
+	// Source is a human-readable origin, e.g. "Deckhouse v1.68 (bundled)",
+	// "/path/to/deckhouse" or "embedded default".
+	Source string
+}
+
+// GlobalSchemaResolver picks the global values schema for each module. In order
+// of precedence it uses:
+//
+//  1. the schema at an explicitly given path (the --global-values flag);
+//  2. the schema of the surrounding Deckhouse repository;
+//  3. the bundled schema of the lowest Deckhouse minor version allowed by the
+//     module's requirements.deckhouse constraint;
+//  4. the embedded default schema.
+//
+// Schemas are loaded once and shared between modules.
+type GlobalSchemaResolver struct {
+	path    string
+	rootDir string
+	bundled fs.FS
+
+	mu    sync.Mutex
+	cache map[string]*GlobalSchema
+}
+
+// NewGlobalSchemaResolver returns a resolver. path is a directory with
+// config-values.yaml and values.yaml, or a Deckhouse repository root; rootDir
+// is the Deckhouse repository the modules are in. Either may be empty.
+func NewGlobalSchemaResolver(rootDir, path string) *GlobalSchemaResolver {
+	bundled, err := fs.Sub(globalVersionsFS, globalVersionsDir)
+	if err != nil {
+		panic(err)
+	}
+
+	return newGlobalSchemaResolver(rootDir, path, bundled)
+}
+
+func newGlobalSchemaResolver(rootDir, path string, bundled fs.FS) *GlobalSchemaResolver {
+	return &GlobalSchemaResolver{
+		path:    path,
+		rootDir: rootDir,
+		bundled: bundled,
+		cache:   make(map[string]*GlobalSchema),
+	}
+}
+
+// ForModule returns the global values schema for the module at modulePath.
+func (r *GlobalSchemaResolver) ForModule(modulePath string) (*GlobalSchema, error) {
+	switch {
+	case r.path != "":
+		return r.load(r.path, func() ([]byte, []byte, error) { return readGlobalSchemaDir(r.path) })
+	case r.rootDir != "":
+		return r.load(r.rootDir, func() ([]byte, []byte, error) { return readConfigFiles(r.rootDir) })
+	}
+
+	version, err := r.bundledVersionFor(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		return r.load("embedded default", func() ([]byte, []byte, error) { return globalConfigBytes, globalValuesBytes, nil })
+	}
+
+	return r.load("Deckhouse v"+version+" (bundled)", func() ([]byte, []byte, error) {
+		configBytes, err := fs.ReadFile(r.bundled, path.Join(version, configValuesFileName))
+		if err != nil {
+			return nil, nil, fmt.Errorf("read bundled global schema: %w", err)
+		}
+
+		valuesBytes, err := fs.ReadFile(r.bundled, path.Join(version, "values.yaml"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("read bundled global schema: %w", err)
+		}
+
+		return configBytes, valuesBytes, nil
+	})
+}
+
+func (r *GlobalSchemaResolver) load(source string, read func() ([]byte, []byte, error)) (*GlobalSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if gs, ok := r.cache[source]; ok {
+		return gs, nil
+	}
+
+	configBytes, valuesBytes, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := prepareSchemas(configBytes, valuesBytes)
+	if err != nil {
+		return nil, fmt.Errorf("global values schema from %s: %w", source, err)
+	}
+
+	if values, ok := schemas[ValuesSchema]; !ok || values == nil {
+		return nil, fmt.Errorf("cannot find global values schema in %s", source)
+	}
+
+	log.Info("Using global values", slog.String("source", source))
+
+	gs := &GlobalSchema{Schema: schemas[ValuesSchema], Source: source}
+	r.cache[source] = gs
+
+	return gs, nil
+}
+
+// bundledVersionFor returns the lowest bundled version that satisfies the
+// module's requirements.deckhouse constraint: the oldest Deckhouse the module
+// claims to run on. It returns "" when the module has no (valid) constraint or
+// no bundled version satisfies it.
+func (r *GlobalSchemaResolver) bundledVersionFor(modulePath string) (string, error) {
+	constraintStr, err := readDeckhouseRequirement(modulePath)
+	if err != nil || constraintStr == "" {
+		return "", err
+	}
+
+	constraint, err := semver.NewConstraint(constraintStr)
+	if err != nil {
+		log.Debug("Ignoring invalid requirements.deckhouse", slog.String("path", modulePath), log.Err(err))
+
+		return "", nil
+	}
+
+	// A bundled minor version matches when any of its patch releases does.
+	for _, v := range r.bundledVersions() {
+		lastPatch := semver.New(v.Major(), v.Minor(), maxPatch, "", "")
+		if constraint.Check(v) || constraint.Check(lastPatch) {
+			return v.Original(), nil
+		}
+	}
+
+	log.Debug("No bundled global values schema matches requirements.deckhouse",
+		slog.String("path", modulePath), slog.String("constraint", constraintStr))
+
+	return "", nil
+}
+
+func (r *GlobalSchemaResolver) bundledVersions() []*semver.Version {
+	entries, err := fs.ReadDir(r.bundled, ".")
+	if err != nil {
+		return nil
+	}
+
+	var versions []*semver.Version
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		v, err := semver.NewVersion(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, v)
+	}
+
+	sort.Sort(semver.Collection(versions))
+
+	return versions
+}
+
+// readGlobalSchemaDir reads the global schema files from dir, which is either a
+// directory holding config-values.yaml and values.yaml or a Deckhouse
+// repository root (global-hooks/openapi).
+func readGlobalSchemaDir(dir string) ([]byte, []byte, error) {
+	if _, err := os.Stat(filepath.Join(dir, "global-hooks", "openapi")); err == nil {
+		return readConfigFiles(dir)
+	}
+
+	configBytes, err := os.ReadFile(filepath.Join(dir, configValuesFileName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read config values file: %w", err)
+	}
+
+	valuesBytes, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read values file: %w", err)
+	}
+
+	return configBytes, valuesBytes, nil
+}
+
+// readDeckhouseRequirement returns the requirements.deckhouse constraint from
+// the module's module.yaml, or "" when the file or the field is missing.
+func readDeckhouseRequirement(modulePath string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(modulePath, "module.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("read module.yaml: %w", err)
+	}
+
+	var meta struct {
+		Requirements struct {
+			Deckhouse string `json:"deckhouse"`
+		} `json:"requirements"`
+	}
+
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return "", fmt.Errorf("parse module.yaml: %w", err)
+	}
+
+	return meta.Requirements.Deckhouse, nil
+}
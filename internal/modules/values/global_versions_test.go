@@ -0,0 +1,163 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func bundledSchema(property string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte("type: object\nproperties:\n  " + property + ":\n    type: string\n")}
+}
+
+func writeModuleYAML(t *testing.T, constraint string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	content := "name: test\n"
+	if constraint != "" {
+		content += "requirements:\n  deckhouse: \"" + constraint + "\"\n"
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "module.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestGlobalSchemaResolverBundledVersions(t *testing.T) {
+	bundled := fstest.MapFS{
+		"1.67/config-values.yaml": &fstest.MapFile{Data: []byte("type: object\n")},
+		"1.67/values.yaml":        bundledSchema("onlyIn167"),
+		"1.70/config-values.yaml": &fstest.MapFile{Data: []byte("type: object\n")},
+		"1.70/values.yaml":        bundledSchema("onlyIn170"),
+		"README.md":               &fstest.MapFile{Data: []byte("not a version")},
+	}
+
+	tests := []struct {
+		constraint string
+		source     string
+		property   string
+	}{
+		{constraint: ">= 1.67", source: "Deckhouse v1.67 (bundled)", property: "onlyIn167"},
+		{constraint: ">= 1.68", source: "Deckhouse v1.70 (bundled)", property: "onlyIn170"},
+		{constraint: ">= 1.67.5", source: "Deckhouse v1.67 (bundled)", property: "onlyIn167"},
+		{constraint: ">= 1.71", source: "embedded default"},
+		{constraint: "", source: "embedded default"},
+		{constraint: "not a constraint", source: "embedded default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			r := newGlobalSchemaResolver("", "", bundled)
+
+			gs, err := r.ForModule(writeModuleYAML(t, tt.constraint))
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if gs.Source != tt.source {
+				t.Errorf("expected source %q, got %q", tt.source, gs.Source)
+			}
+
+			if tt.property == "" {
+				return
+			}
+
+			if _, ok := gs.Schema.Properties[tt.property]; !ok {
+				t.Errorf("expected property %q in the %s schema", tt.property, gs.Source)
+			}
+		})
+	}
+}
+
+func TestGlobalSchemaResolverPathOverride(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "config-values.yaml"), []byte("type: object\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "values.yaml"), bundledSchema("fromFlag").Data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newGlobalSchemaResolver("", dir, fstest.MapFS{
+		"1.67/config-values.yaml": &fstest.MapFile{Data: []byte("type: object\n")},
+		"1.67/values.yaml":        bundledSchema("onlyIn167"),
+	})
+
+	gs, err := r.ForModule(writeModuleYAML(t, ">= 1.67"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if _, ok := gs.Schema.Properties["fromFlag"]; !ok {
+		t.Errorf("expected the schema from the given path, got %s", gs.Source)
+	}
+
+	again, err := r.ForModule(writeModuleYAML(t, ""))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if again != gs {
+		t.Errorf("expected the schema to be loaded once and shared")
+	}
+}
+
+func TestNewGlobalSchemaResolverEmbedded(t *testing.T) {
+	gs, err := NewGlobalSchemaResolver("", "").ForModule(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if _, ok := gs.Schema.Properties["modulesImages"]; !ok {
+		t.Errorf("expected the embedded default schema, got %s", gs.Source)
+	}
+}
+
+func TestNewGlobalSchemaResolverBundled(t *testing.T) {
+	r := NewGlobalSchemaResolver("", "")
+
+	gs, err := r.ForModule(writeModuleYAML(t, ">= 1.68"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if gs.Source != "Deckhouse v1.68 (bundled)" {
+		t.Errorf("expected the bundled 1.68 schema, got %s", gs.Source)
+	}
+
+	if _, ok := gs.Schema.Properties["modulesImages"]; !ok {
+		t.Errorf("expected the global values properties in the %s schema", gs.Source)
+	}
+
+	older, err := r.ForModule(writeModuleYAML(t, ">= 1.60"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if older != gs {
+		t.Errorf("expected a constraint allowing 1.68 to resolve the bundled 1.68 schema, got %s", older.Source)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	oaerrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	"github.com/go-openapi/validate/post"
+)
+
+// ValidateValues validates data against the schema with full JSON schema
+// semantics and returns the violations, sorted, or nil when data is valid.
+func ValidateValues(s *spec.Schema, data any) []string {
+	err := validate.AgainstSchema(s, data, strfmt.Default)
+	if err == nil {
+		return nil
+	}
+
+	violations := flattenErrors(err)
+	sort.Strings(violations)
+
+	return violations
+}
+
+// ApplyDefaults sets the schema defaults of the absent fields in data, the way
+// Deckhouse completes module settings. data is changed in place; invalid data
+// is left as is.
+func ApplyDefaults(s *spec.Schema, data any) {
+	res := validate.NewSchemaValidator(s, nil, "", strfmt.Default).Validate(data)
+	if res.IsValid() {
+		post.ApplyDefaults(res)
+	}
+}
+
+func flattenErrors(err error) []string {
+	var composite *oaerrors.CompositeError
+	if !errors.As(err, &composite) {
+		return []string{err.Error()}
+	}
+
+	var messages []string
+	for _, e := range composite.Errors {
+		messages = append(messages, flattenErrors(e)...)
+	}
+
+	return messages
+}
+
+// LoadModuleSchemas loads the module's config-values.yaml and values.yaml
+// schemas the way Deckhouse validates module settings and values. A schema the
+// module does not ship is absent from the result.
+func LoadModuleSchemas(modulePath string) (Schemas, error) {
+	openAPIPath := filepath.Join(modulePath, "openapi")
+
+	configBytes, err := readOpenAPIFile(openAPIPath, configValuesFileName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read openAPI schemas: %w", err)
+	}
+
+	valuesBytes, err := readOpenAPIFile(openAPIPath, "values.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read openAPI schemas: %w", err)
+	}
+
+	return prepareSchemas(configBytes, valuesBytes)
+}
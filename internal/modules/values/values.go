@@ -73,6 +73,18 @@ func loadSchemaFromBytes(openAPIContent []byte) (*spec.Schema, error) {
 	return s, nil
 }
 
+// LoadConfigValuesSchema loads a config-values schema from YAML bytes the way
+// Deckhouse validates module settings: properties without additionalProperties
+// reject unknown keys.
+func LoadConfigValuesSchema(content []byte) (*spec.Schema, error) {
+	schemaObj, err := loadSchemaFromBytes(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformers.Transform(schemaObj, &transformers.AdditionalProperties{}), nil
+}
+
 // prepareSchemas loads schemas for config values, values and helm values.
 func prepareSchemas(configBytes, valuesBytes []byte) (Schemas, error) {
 	schemas := make(Schemas)
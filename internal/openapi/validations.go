@@ -0,0 +1,46 @@
+/*
+Copyright 2025 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"github.com/google/cel-go/cel"
+)
+
+const (
+	// ValidationsKey is the OpenAPI extension that carries Deckhouse CEL
+	// validation rules for a module's config-values / values schema. It is the
+	// dmt-side mirror of deckhouse-controller's
+	// internal/packages/values/schema/cel (const ruleKey).
+	ValidationsKey = "x-deckhouse-validations"
+
+	// SelfVar / OldSelfVar are the variables deckhouse-controller exposes to
+	// x-deckhouse-validations expressions: the validated value and, on update,
+	// its previous value.
+	SelfVar    = "self"
+	OldSelfVar = "oldSelf"
+)
+
+// NewValidationsCELEnv returns the environment deckhouse-controller compiles
+// x-deckhouse-validations expressions in: self and oldSelf, dynamically typed.
+// The openapi linter and the openapi-validations tester share it, so an
+// expression that compiles for one compiles for the other and for Deckhouse.
+func NewValidationsCELEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(SelfVar, cel.DynType),
+		cel.Variable(OldSelfVar, cel.DynType),
+	)
+}
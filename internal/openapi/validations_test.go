@@ -0,0 +1,36 @@
+/*
+Copyright 2025 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidationsCELEnv(t *testing.T) {
+	env, err := NewValidationsCELEnv()
+	require.NoError(t, err)
+
+	_, issues := env.Compile("self.replicas >= oldSelf.replicas")
+	require.NoError(t, issues.Err())
+
+	_, issues = env.Compile("other.replicas > 0")
+	require.Error(t, issues.Err())
+	assert.Contains(t, issues.Err().Error(), "undeclared reference to 'other'")
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promtool
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// memStorage is a minimal in-memory storage.Queryable and storage.Appendable
+// for rule unit tests: the rules engine reads input series from it and writes
+// recording rule results and ALERTS series back. promtool uses a TSDB-backed
+// test storage for this, which dmt cannot afford as a dependency.
+type memStorage struct {
+	mu     sync.RWMutex
+	series map[uint64]*memSeries
+}
+
+type memSeries struct {
+	lset    labels.Labels
+	samples []memSample
+}
+
+// memSample is a float or float histogram sample; it implements chunks.Sample.
+type memSample struct {
+	t  int64
+	f  float64
+	fh *histogram.FloatHistogram
+}
+
+func (s memSample) T() int64                      { return s.t }
+func (s memSample) F() float64                    { return s.f }
+func (s memSample) H() *histogram.Histogram       { return nil }
+func (s memSample) FH() *histogram.FloatHistogram { return s.fh }
+
+func (s memSample) Type() chunkenc.ValueType {
+	if s.fh != nil {
+		return chunkenc.ValFloatHistogram
+	}
+
+	return chunkenc.ValFloat
+}
+
+func (s memSample) Copy() chunks.Sample {
+	c := memSample{t: s.t, f: s.f}
+	if s.fh != nil {
+		c.fh = s.fh.Copy()
+	}
+
+	return c
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{series: make(map[uint64]*memSeries)}
+}
+
+// add appends a sample, replacing an existing one at the same timestamp.
+func (s *memStorage) add(lset labels.Labels, sample memSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := lset.Hash()
+
+	ser, ok := s.series[hash]
+	if !ok {
+		ser = &memSeries{lset: lset.Copy()}
+		s.series[hash] = ser
+	}
+
+	n := len(ser.samples)
+	if n == 0 || ser.samples[n-1].t < sample.t {
+		ser.samples = append(ser.samples, sample)
+		return
+	}
+
+	i := sort.Search(n, func(i int) bool { return ser.samples[i].t >= sample.t })
+	if i < n && ser.samples[i].t == sample.t {
+		ser.samples[i] = sample
+		return
+	}
+
+	ser.samples = append(ser.samples, memSample{})
+	copy(ser.samples[i+1:], ser.samples[i:])
+	ser.samples[i] = sample
+}
+
+func (s *memStorage) Querier(mint, maxt int64) (storage.Querier, error) {
+	return &memQuerier{storage: s, mint: mint, maxt: maxt}, nil
+}
+
+func (s *memStorage) Appender(context.Context) storage.Appender {
+	return &memAppender{storage: s}
+}
+
+type memQuerier struct {
+	storage    *memStorage
+	mint, maxt int64
+}
+
+func (q *memQuerier) Select(_ context.Context, _ bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	mint, maxt := q.mint, q.maxt
+	if hints != nil {
+		mint, maxt = max(mint, hints.Start), min(maxt, hints.End)
+	}
+
+	q.storage.mu.RLock()
+	defer q.storage.mu.RUnlock()
+
+	var set memSeriesSet
+
+	for _, ser := range q.storage.series {
+		if !matchesAll(ser.lset, matchers) {
+			continue
+		}
+
+		var samples []chunks.Sample
+
+		for _, s := range ser.samples {
+			if s.t >= mint && s.t <= maxt {
+				samples = append(samples, s)
+			}
+		}
+
+		if len(samples) > 0 {
+			set.series = append(set.series, storage.NewListSeries(ser.lset, samples))
+		}
+	}
+
+	// The engine expects series sorted by labels.
+	sort.Slice(set.series, func(i, j int) bool {
+		return labels.Compare(set.series[i].Labels(), set.series[j].Labels()) < 0
+	})
+
+	return &set
+}
+
+func (q *memQuerier) LabelValues(_ context.Context, name string, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	q.storage.mu.RLock()
+	defer q.storage.mu.RUnlock()
+
+	seen := map[string]struct{}{}
+
+	for _, ser := range q.storage.series {
+		if v := ser.lset.Get(name); v != "" && matchesAll(ser.lset, matchers) {
+			seen[v] = struct{}{}
+		}
+	}
+
+	return sortedKeys(seen), nil, nil
+}
+
+func (q *memQuerier) LabelNames(_ context.Context, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	q.storage.mu.RLock()
+	defer q.storage.mu.RUnlock()
+
+	seen := map[string]struct{}{}
+
+	for _, ser := range q.storage.series {
+		if matchesAll(ser.lset, matchers) {
+			ser.lset.Range(func(l labels.Label) { seen[l.Name] = struct{}{} })
+		}
+	}
+
+	return sortedKeys(seen), nil, nil
+}
+
+func (*memQuerier) Close() error { return nil }
+
+type memSeriesSet struct {
+	series []storage.Series
+	cur    int
+}
+
+func (s *memSeriesSet) Next() bool {
+	s.cur++
+	return s.cur <= len(s.series)
+}
+
+func (s *memSeriesSet) At() storage.Series              { return s.series[s.cur-1] }
+func (*memSeriesSet) Err() error                        { return nil }
+func (*memSeriesSet) Warnings() annotations.Annotations { return nil }
+
+// memAppender buffers samples until Commit, like a TSDB appender.
+type memAppender struct {
+	storage *memStorage
+	pending []pendingSample
+}
+
+type pendingSample struct {
+	lset   labels.Labels
+	sample memSample
+}
+
+func (a *memAppender) Append(_ storage.SeriesRef, l labels.Labels, t int64, v float64) (storage.SeriesRef, error) {
+	a.pending = append(a.pending, pendingSample{lset: l.Copy(), sample: memSample{t: t, f: v}})
+	return 0, nil
+}
+
+func (a *memAppender) AppendHistogram(_ storage.SeriesRef, l labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	if fh == nil && h != nil {
+		fh = h.ToFloat(nil)
+	}
+
+	a.pending = append(a.pending, pendingSample{lset: l.Copy(), sample: memSample{t: t, fh: fh}})
+
+	return 0, nil
+}
+
+func (*memAppender) AppendExemplar(storage.SeriesRef, labels.Labels, exemplar.Exemplar) (storage.SeriesRef, error) {
+	return 0, nil
+}
+
+func (*memAppender) AppendCTZeroSample(storage.SeriesRef, labels.Labels, int64, int64) (storage.SeriesRef, error) {
+	return 0, errors.New("created timestamps are not supported")
+}
+
+func (*memAppender) AppendHistogramCTZeroSample(storage.SeriesRef, labels.Labels, int64, int64, *histogram.Histogram, *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	return 0, errors.New("created timestamps are not supported")
+}
+
+func (*memAppender) UpdateMetadata(storage.SeriesRef, labels.Labels, metadata.Metadata) (storage.SeriesRef, error) {
+	return 0, nil
+}
+
+func (*memAppender) SetOptions(*storage.AppendOptions) {}
+
+func (a *memAppender) Rollback() error {
+	a.pending = nil
+	return nil
+}
+
+func (a *memAppender) Commit() error {
+	for _, p := range a.pending {
+		a.storage.add(p.lset, p.sample)
+	}
+
+	a.pending = nil
+
+	return nil
+}
+
+func matchesAll(lset labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lset.Get(m.Name)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
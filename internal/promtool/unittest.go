@@ -0,0 +1,599 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promtool
+
+// This file is a port of promtool's rule unit testing (cmd/promtool/unittest.go
+// of prometheus/prometheus) onto an in-memory storage, without the JUnit, diff
+// and debug output.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// UnitTestFile is a promtool rule unit test file.
+type UnitTestFile struct {
+	RuleFiles          []string       `yaml:"rule_files"`
+	EvaluationInterval model.Duration `yaml:"evaluation_interval,omitempty"`
+	GroupEvalOrder     []string       `yaml:"group_eval_order"`
+	Tests              []testGroup    `yaml:"tests"`
+}
+
+// TestFailure is a failed check of a rule unit test.
+type TestFailure struct {
+	// Test is the test group name, or "unnamed#<index>".
+	Test     string
+	Text     string
+	Got      string
+	Expected string
+}
+
+// ParseUnitTestFile parses a promtool rule unit test file. Unknown fields are
+// rejected, as promtool does.
+func ParseUnitTestFile(data []byte) (*UnitTestFile, error) {
+	var f UnitTestFile
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("parse rule unit test file: %w", err)
+	}
+
+	if f.EvaluationInterval == 0 {
+		f.EvaluationInterval = model.Duration(time.Minute)
+	}
+
+	return &f, nil
+}
+
+// Run evaluates the rule files (rule group files as Prometheus loads them, i.e.
+// the already rendered RuleFiles) against every test of the file and returns
+// the failed checks.
+func (f *UnitTestFile) Run(ruleFiles ...string) []TestFailure {
+	groupOrderMap := make(map[string]int)
+
+	for i, gn := range f.GroupEvalOrder {
+		if _, ok := groupOrderMap[gn]; ok {
+			return []TestFailure{{Text: "group name repeated in evaluation order: " + gn}}
+		}
+
+		groupOrderMap[gn] = i
+	}
+
+	var failures []TestFailure
+
+	for i := range f.Tests {
+		tg := &f.Tests[i]
+
+		name := tg.TestGroupName
+		if name == "" {
+			name = fmt.Sprintf("unnamed#%d", i)
+		}
+
+		if tg.Interval == 0 {
+			tg.Interval = f.EvaluationInterval
+		}
+
+		for _, failure := range tg.test(time.Duration(f.EvaluationInterval), groupOrderMap, ruleFiles) {
+			failure.Test = name
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+// testGroup is a group of input series and tests associated with it.
+type testGroup struct {
+	Interval        model.Duration   `yaml:"interval"`
+	InputSeries     []series         `yaml:"input_series"`
+	AlertRuleTests  []alertTestCase  `yaml:"alert_rule_test,omitempty"`
+	PromqlExprTests []promqlTestCase `yaml:"promql_expr_test,omitempty"`
+	ExternalLabels  labels.Labels    `yaml:"external_labels,omitempty"`
+	ExternalURL     string           `yaml:"external_url,omitempty"`
+	TestGroupName   string           `yaml:"name,omitempty"`
+}
+
+// test performs the unit tests of the group.
+func (tg *testGroup) test(evalInterval time.Duration, groupOrderMap map[string]int, ruleFiles []string) []TestFailure {
+	input, err := tg.loadInput()
+	if err != nil {
+		return []TestFailure{{Text: err.Error()}}
+	}
+
+	store := newMemStorage()
+
+	engine := promql.NewEngine(promql.EngineOpts{
+		MaxSamples:               10000,
+		Timeout:                  100 * time.Second,
+		NoStepSubqueryIntervalFn: func(int64) int64 { return evalInterval.Milliseconds() },
+		EnableAtModifier:         true,
+		EnableNegativeOffset:     true,
+		EnableDelayedNameRemoval: true,
+	})
+	defer engine.Close()
+
+	ctx := context.Background()
+
+	m := rules.NewManager(&rules.ManagerOptions{
+		QueryFunc:  rules.EngineQueryFunc(engine, store),
+		Appendable: store,
+		Context:    ctx,
+		NotifyFunc: func(context.Context, string, ...*rules.Alert) {},
+		Logger:     promslog.NewNopLogger(),
+	})
+
+	groupsMap, errs := m.LoadGroups(time.Duration(tg.Interval), tg.ExternalLabels, tg.ExternalURL, nil, false, ruleFiles...)
+	if errs != nil {
+		failures := make([]TestFailure, 0, len(errs))
+		for _, err := range errs {
+			failures = append(failures, TestFailure{Text: "load rules: " + err.Error()})
+		}
+
+		return failures
+	}
+
+	groups := orderedGroups(groupsMap, groupOrderMap)
+
+	// Bounds for evaluating the rules.
+	mint := time.Unix(0, 0).UTC()
+	maxt := mint.Add(tg.maxEvalTime())
+
+	// The eval_time+alertname combinations and the alert tests per eval_time, so
+	// alerts are checked as the rules are evaluated.
+	alertsInTest := make(map[model.Duration]map[string]struct{})
+	alertTests := make(map[model.Duration][]alertTestCase)
+
+	for _, alert := range tg.AlertRuleTests {
+		if alert.Alertname == "" {
+			return []TestFailure{{Text: fmt.Sprintf("an item under alert_rule_test misses required attribute alertname at eval_time %v", alert.EvalTime)}}
+		}
+
+		if _, ok := alertsInTest[alert.EvalTime]; !ok {
+			alertsInTest[alert.EvalTime] = make(map[string]struct{})
+		}
+
+		alertsInTest[alert.EvalTime][alert.Alertname] = struct{}{}
+		alertTests[alert.EvalTime] = append(alertTests[alert.EvalTime], alert)
+	}
+
+	alertEvalTimes := make([]model.Duration, 0, len(alertTests))
+	for t := range alertTests {
+		alertEvalTimes = append(alertEvalTimes, t)
+	}
+
+	sort.Slice(alertEvalTimes, func(i, j int) bool { return alertEvalTimes[i] < alertEvalTimes[j] })
+
+	for _, g := range groups {
+		for _, r := range g.Rules() {
+			if alertRule, ok := r.(*rules.AlertingRule); ok {
+				// Mark alerting rules as restored, to ensure the ALERTS timeseries is
+				// created when they run.
+				alertRule.SetRestored(true)
+			}
+		}
+	}
+
+	var failures []TestFailure
+
+	// Current index in alertEvalTimes.
+	curr := 0
+
+	for ts := mint; !ts.After(maxt); ts = ts.Add(evalInterval) {
+		input.appendTill(store, ts.Sub(mint).Milliseconds())
+
+		var evalFailures []TestFailure
+
+		for _, g := range groups {
+			g.Eval(ctx, ts)
+
+			for _, r := range g.Rules() {
+				if r.LastError() != nil {
+					evalFailures = append(evalFailures, TestFailure{
+						Text: fmt.Sprintf("rule: %s, time: %s, err: %s", r.Name(), ts.Sub(mint), r.LastError()),
+					})
+				}
+			}
+		}
+
+		// Only stop at evaluation errors, not at test failures collected so far.
+		if len(evalFailures) > 0 {
+			return append(failures, evalFailures...)
+		}
+
+		// Alerts with ts <= eval_time < ts+evalInterval are checked against this
+		// evaluation.
+		for curr < len(alertEvalTimes) && ts.Sub(mint) <= time.Duration(alertEvalTimes[curr]) &&
+			time.Duration(alertEvalTimes[curr]) < ts.Add(evalInterval).Sub(mint) {
+			t := alertEvalTimes[curr]
+			got := firingAlerts(groups, alertsInTest[t])
+
+			for _, testcase := range alertTests[t] {
+				if f := testcase.check(got[testcase.Alertname]); f != nil {
+					failures = append(failures, *f)
+				}
+			}
+
+			curr++
+		}
+	}
+
+	for _, testCase := range tg.PromqlExprTests {
+		if f := testCase.check(ctx, mint, engine, store); f != nil {
+			failures = append(failures, *f)
+		}
+	}
+
+	return failures
+}
+
+// firingAlerts collects the firing alerts of the named alerting rules. The same
+// alert name can be present in multiple groups.
+func firingAlerts(groups []*rules.Group, names map[string]struct{}) map[string]labelsAndAnnotations {
+	got := make(map[string]labelsAndAnnotations)
+
+	for _, g := range groups {
+		for _, r := range g.Rules() {
+			ar, ok := r.(*rules.AlertingRule)
+			if !ok {
+				continue
+			}
+
+			if _, ok := names[ar.Name()]; !ok {
+				continue
+			}
+
+			var alerts labelsAndAnnotations
+
+			for _, a := range ar.ActiveAlerts() {
+				if a.State == rules.StateFiring {
+					alerts = append(alerts, labelAndAnnotation{
+						Labels:      a.Labels.Copy(),
+						Annotations: a.Annotations.Copy(),
+					})
+				}
+			}
+
+			got[ar.Name()] = append(got[ar.Name()], alerts...)
+		}
+	}
+
+	return got
+}
+
+func (tc *alertTestCase) check(gotAlerts labelsAndAnnotations) *TestFailure {
+	var expAlerts labelsAndAnnotations
+
+	for _, a := range tc.ExpAlerts {
+		// The alertname label is added by Prometheus during Eval, so it is not
+		// part of the expected labels the user gives.
+		expLabels := make(map[string]string, len(a.ExpLabels)+1)
+		for k, v := range a.ExpLabels {
+			expLabels[k] = v
+		}
+
+		expLabels[labels.AlertName] = tc.Alertname
+
+		expAlerts = append(expAlerts, labelAndAnnotation{
+			Labels:      labels.FromMap(expLabels),
+			Annotations: labels.FromMap(a.ExpAnnotations),
+		})
+	}
+
+	sort.Sort(gotAlerts)
+	sort.Sort(expAlerts)
+
+	if cmp.Equal(expAlerts, gotAlerts, cmp.Comparer(labels.Equal)) {
+		return nil
+	}
+
+	return &TestFailure{
+		Text:     fmt.Sprintf("alertname: %s, time: %s", tc.Alertname, tc.EvalTime),
+		Got:      gotAlerts.String(),
+		Expected: expAlerts.String(),
+	}
+}
+
+func (tc *promqlTestCase) check(ctx context.Context, mint time.Time, engine *promql.Engine, qu storage.Queryable) *TestFailure {
+	fail := func(err error) *TestFailure {
+		return &TestFailure{Text: fmt.Sprintf("expr: %q, time: %s, err: %s", tc.Expr, tc.EvalTime, err)}
+	}
+
+	got, err := query(ctx, tc.Expr, mint.Add(time.Duration(tc.EvalTime)), engine, qu)
+	if err != nil {
+		return fail(err)
+	}
+
+	gotSamples := make([]parsedSample, 0, len(got))
+	for _, s := range got {
+		gotSamples = append(gotSamples, parsedSample{
+			Labels:    s.Metric.Copy(),
+			Value:     s.F,
+			Histogram: histogramTestExpression(s.H),
+		})
+	}
+
+	expSamples := make([]parsedSample, 0, len(tc.ExpSamples))
+
+	for _, s := range tc.ExpSamples {
+		lb, err := parser.ParseMetric(s.Labels)
+		if err != nil {
+			return fail(fmt.Errorf("labels %q: %w", s.Labels, err))
+		}
+
+		var hist *histogram.FloatHistogram
+
+		if s.Histogram != "" {
+			_, values, err := parser.ParseSeriesDesc("{} " + s.Histogram)
+
+			switch {
+			case err != nil:
+			case len(values) != 1:
+				err = fmt.Errorf("expected 1 value, got %d", len(values))
+			case values[0].Histogram == nil:
+				err = fmt.Errorf("expected histogram, got %v", values[0])
+			default:
+				hist = values[0].Histogram
+			}
+
+			if err != nil {
+				return fail(fmt.Errorf("labels %q: %w", s.Labels, err))
+			}
+		}
+
+		expSamples = append(expSamples, parsedSample{
+			Labels:    lb,
+			Value:     s.Value,
+			Histogram: histogramTestExpression(hist),
+		})
+	}
+
+	sort.Slice(expSamples, func(i, j int) bool { return labels.Compare(expSamples[i].Labels, expSamples[j].Labels) <= 0 })
+	sort.Slice(gotSamples, func(i, j int) bool { return labels.Compare(gotSamples[i].Labels, gotSamples[j].Labels) <= 0 })
+
+	if cmp.Equal(expSamples, gotSamples, cmp.Comparer(labels.Equal)) {
+		return nil
+	}
+
+	return &TestFailure{
+		Text:     fmt.Sprintf("expr: %q, time: %s", tc.Expr, tc.EvalTime),
+		Got:      parsedSamplesString(gotSamples),
+		Expected: parsedSamplesString(expSamples),
+	}
+}
+
+// inputSeries holds the samples of the input series not yet appended to the
+// storage. Like promtool, samples are appended lazily as evaluation advances.
+type inputSeries struct {
+	lsets   []labels.Labels
+	samples [][]memSample
+}
+
+// loadInput expands the input series: the n-th value of a series is at n times
+// the group interval, omitted values ("_") leave a gap.
+func (tg *testGroup) loadInput() (*inputSeries, error) {
+	in := &inputSeries{}
+	step := time.Duration(tg.Interval).Milliseconds()
+
+	for _, is := range tg.InputSeries {
+		lset, values, err := parser.ParseSeriesDesc(is.Series + " " + is.Values)
+		if err != nil {
+			return nil, fmt.Errorf("input series %q: %w", is.Series, err)
+		}
+
+		var samples []memSample
+
+		for i, v := range values {
+			if v.Omitted {
+				continue
+			}
+
+			samples = append(samples, memSample{t: int64(i) * step, f: v.Value, fh: v.Histogram})
+		}
+
+		in.lsets = append(in.lsets, lset)
+		in.samples = append(in.samples, samples)
+	}
+
+	return in, nil
+}
+
+// appendTill moves the samples up to ts (in milliseconds) into the storage.
+func (in *inputSeries) appendTill(store *memStorage, ts int64) {
+	for i, samples := range in.samples {
+		n := 0
+		for n < len(samples) && samples[n].t <= ts {
+			store.add(in.lsets[i], samples[n])
+			n++
+		}
+
+		in.samples[i] = samples[n:]
+	}
+}
+
+// orderedGroups returns the groups ordered by groupOrderMap. Groups that are not
+// mentioned come first. NOTE: This is partial ordering.
+func orderedGroups(groupsMap map[string]*rules.Group, groupOrderMap map[string]int) []*rules.Group {
+	groups := make([]*rules.Group, 0, len(groupsMap))
+	for _, g := range groupsMap {
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groupOrderMap[groups[i].Name()] < groupOrderMap[groups[j].Name()]
+	})
+
+	return groups
+}
+
+// maxEvalTime returns the max eval time among all alert and promql unit tests.
+func (tg *testGroup) maxEvalTime() time.Duration {
+	var maxd model.Duration
+
+	for _, alert := range tg.AlertRuleTests {
+		maxd = max(maxd, alert.EvalTime)
+	}
+
+	for _, pet := range tg.PromqlExprTests {
+		maxd = max(maxd, pet.EvalTime)
+	}
+
+	return time.Duration(maxd)
+}
+
+func query(ctx context.Context, qs string, t time.Time, engine *promql.Engine, qu storage.Queryable) (promql.Vector, error) {
+	q, err := engine.NewInstantQuery(ctx, qu, nil, qs, t)
+	if err != nil {
+		return nil, err
+	}
+
+	res := q.Exec(ctx)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	switch v := res.Value.(type) {
+	case promql.Vector:
+		return v, nil
+	case promql.Scalar:
+		return promql.Vector{promql.Sample{T: v.T, F: v.V, Metric: labels.Labels{}}}, nil
+	default:
+		return nil, errors.New("rule result is not a vector or scalar")
+	}
+}
+
+func histogramTestExpression(h *histogram.FloatHistogram) string {
+	if h == nil {
+		return ""
+	}
+
+	return h.TestExpression()
+}
+
+// indentLines prefixes each line but the first with indent.
+func indentLines(lines, indent string) string {
+	return strings.ReplaceAll(lines, "\n", "\n"+indent)
+}
+
+type labelsAndAnnotations []labelAndAnnotation
+
+func (la labelsAndAnnotations) Len() int      { return len(la) }
+func (la labelsAndAnnotations) Swap(i, j int) { la[i], la[j] = la[j], la[i] }
+func (la labelsAndAnnotations) Less(i, j int) bool {
+	diff := labels.Compare(la[i].Labels, la[j].Labels)
+	if diff != 0 {
+		return diff < 0
+	}
+
+	return labels.Compare(la[i].Annotations, la[j].Annotations) < 0
+}
+
+func (la labelsAndAnnotations) String() string {
+	if len(la) == 0 {
+		return "[]"
+	}
+
+	s := "[\n0:" + indentLines("\n"+la[0].String(), "  ")
+	for i, l := range la[1:] {
+		s += ",\n" + strconv.Itoa(i+1) + ":" + indentLines("\n"+l.String(), "  ")
+	}
+
+	return s + "\n]"
+}
+
+type labelAndAnnotation struct {
+	Labels      labels.Labels
+	Annotations labels.Labels
+}
+
+func (la *labelAndAnnotation) String() string {
+	return "Labels:" + la.Labels.String() + "\nAnnotations:" + la.Annotations.String()
+}
+
+type series struct {
+	Series string `yaml:"series"`
+	Values string `yaml:"values"`
+}
+
+type alertTestCase struct {
+	EvalTime  model.Duration `yaml:"eval_time"`
+	Alertname string         `yaml:"alertname"`
+	ExpAlerts []alert        `yaml:"exp_alerts"`
+}
+
+type alert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+type promqlTestCase struct {
+	Expr       string         `yaml:"expr"`
+	EvalTime   model.Duration `yaml:"eval_time"`
+	ExpSamples []sample       `yaml:"exp_samples"`
+}
+
+type sample struct {
+	Labels    string  `yaml:"labels"`
+	Value     float64 `yaml:"value"`
+	Histogram string  `yaml:"histogram"` // A non-empty string means Value is ignored.
+}
+
+// parsedSample is a sample with parsed Labels.
+type parsedSample struct {
+	Labels    labels.Labels
+	Value     float64
+	Histogram string // TestExpression() of histogram.FloatHistogram
+}
+
+func parsedSamplesString(pss []parsedSample) string {
+	if len(pss) == 0 {
+		return "nil"
+	}
+
+	s := pss[0].String()
+	for _, ps := range pss[1:] {
+		s += ", " + ps.String()
+	}
+
+	return s
+}
+
+func (ps *parsedSample) String() string {
+	if ps.Histogram != "" {
+		return ps.Labels.String() + " " + ps.Histogram
+	}
+
+	return ps.Labels.String() + " " + strconv.FormatFloat(ps.Value, 'E', -1, 64)
+}
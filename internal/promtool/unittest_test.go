@@ -0,0 +1,132 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promtool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unitTestRules = `
+groups:
+- name: test
+  rules:
+  - record: job:up:sum
+    expr: sum by (job) (up)
+  - alert: TargetDown
+    expr: up == 0
+    for: 5m
+    labels:
+      severity: warning
+    annotations:
+      summary: "{{ $labels.instance }} is down"
+`
+
+func writeRules(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(unitTestRules), 0o600))
+
+	return path
+}
+
+func TestUnitTestFileRun(t *testing.T) {
+	f, err := ParseUnitTestFile([]byte(`
+rule_files: [rules.yaml]
+evaluation_interval: 1m
+tests:
+- name: target down
+  interval: 1m
+  input_series:
+  - series: 'up{job="app", instance="a"}'
+    values: '1 1 0x10'
+  - series: 'up{job="app", instance="b"}'
+    values: '1x12'
+  alert_rule_test:
+  - eval_time: 5m
+    alertname: TargetDown
+  - eval_time: 8m
+    alertname: TargetDown
+    exp_alerts:
+    - exp_labels:
+        severity: warning
+        job: app
+        instance: a
+      exp_annotations:
+        summary: a is down
+  promql_expr_test:
+  - expr: job:up:sum
+    eval_time: 10m
+    exp_samples:
+    - labels: 'job:up:sum{job="app"}'
+      value: 1
+`))
+	require.NoError(t, err)
+
+	assert.Empty(t, f.Run(writeRules(t)))
+}
+
+func TestUnitTestFileRunFailures(t *testing.T) {
+	f, err := ParseUnitTestFile([]byte(`
+rule_files: [rules.yaml]
+tests:
+- input_series:
+  - series: 'up{job="app", instance="a"}'
+    values: '0x10'
+  alert_rule_test:
+  - eval_time: 10m
+    alertname: TargetDown
+    exp_alerts:
+    - exp_labels:
+        severity: critical
+        job: app
+        instance: a
+  promql_expr_test:
+  - expr: job:up:sum
+    eval_time: 10m
+    exp_samples:
+    - labels: 'job:up:sum{job="app"}'
+      value: 1
+`))
+	require.NoError(t, err)
+
+	failures := f.Run(writeRules(t))
+	require.Len(t, failures, 2)
+
+	assert.Equal(t, "unnamed#0", failures[0].Test)
+	assert.Equal(t, "alertname: TargetDown, time: 10m", failures[0].Text)
+	assert.Contains(t, failures[0].Expected, `severity="critical"`)
+	assert.Contains(t, failures[0].Got, `severity="warning"`)
+
+	assert.Equal(t, `expr: "job:up:sum", time: 10m`, failures[1].Text)
+	assert.Equal(t, `{__name__="job:up:sum", job="app"} 1E+00`, failures[1].Expected)
+	assert.Equal(t, `{__name__="job:up:sum", job="app"} 0E+00`, failures[1].Got)
+}
+
+func TestParseUnitTestFile(t *testing.T) {
+	_, err := ParseUnitTestFile([]byte("rule_files: [a.yaml]\nunknown: true\n"))
+	require.Error(t, err)
+
+	f, err := ParseUnitTestFile([]byte("rule_files: [a.yaml]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "1m", f.EvaluationInterval.String(), "evaluation interval defaults to a minute")
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rendercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/deckhouse/pkg/log"
+
+	"github.com/deckhouse/dmt/internal/coverage"
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/moduleloader"
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/modules/render"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/pkg/testers/templates"
+)
+
+// testValuesFile is the values file of a templates-tests case.
+const testValuesFile = "values.yaml"
+
+// Coverage discovers all modules under dir and reports which template lines,
+// branches (if/else, with, range) and defines are exercised by the module's
+// values scenarios: values generated from openapi/values.yaml, from every
+// openapi/values_<edition>.yaml, and the values.yaml of every templates-tests
+// case. The module's templates are instrumented only for the duration of each
+// render, like the image stub render injects; the report goes to output
+// (stdout when empty) in the given format (text, json or cobertura).
+// globalValuesPath, when set, is the global values schema to render with (see
+// values.NewGlobalSchemaResolver).
+func Coverage(dir, format, output, globalValuesPath string) error {
+	if err := coverage.CheckFormat(format); err != nil {
+		return err
+	}
+
+	expandedDir, err := fsutils.ExpandDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to expand directory: %w", err)
+	}
+
+	paths, err := moduleloader.GetModulePaths(expandedDir)
+	if err != nil {
+		return fmt.Errorf("failed to get module paths: %w", err)
+	}
+
+	globalSchemas := values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(expandedDir), globalValuesPath)
+
+	report := &coverage.Report{Modules: []coverage.ModuleReport{}}
+
+	for _, modulePath := range paths {
+		if !fsutils.IsDir(filepath.Join(modulePath, templatesDirName)) {
+			log.Debug("Skipping path without templates", slog.String("path", modulePath))
+
+			continue
+		}
+
+		log.Info("Collecting template coverage", slog.String("path", modulePath))
+
+		globalValues, err := globalSchemas.ForModule(modulePath)
+		if err != nil {
+			return fmt.Errorf("module %q: %w", modulePath, err)
+		}
+
+		moduleReport, err := moduleCoverage(modulePath, globalValues.Schema)
+		if err != nil {
+			return fmt.Errorf("module %q: %w", modulePath, err)
+		}
+
+		report.Modules = append(report.Modules, moduleReport)
+	}
+
+	return writeCoverage(report, format, output)
+}
+
+// moduleCoverage renders every values scenario of one module, instrumented by a
+// single collector, so the report shows what all of them together cover. A
+// scenario whose templates fail to execute still contributes the hits collected
+// up to the failure; the failure is recorded in the report.
+func moduleCoverage(modulePath string, globalSchema *spec.Schema) (coverage.ModuleReport, error) {
+	mod, err := modules.LoadModule(modulePath)
+	if err != nil {
+		return coverage.ModuleReport{}, err
+	}
+
+	scenarios, err := coverageScenarios(mod, globalSchema)
+	if err != nil {
+		return coverage.ModuleReport{}, err
+	}
+
+	collector := coverage.NewCollector()
+	if err := collector.Instrument(modulePath); err != nil {
+		return coverage.ModuleReport{}, err
+	}
+
+	results := make([]coverage.Scenario, 0, len(scenarios))
+
+	for _, s := range scenarios {
+		result := coverage.Scenario{Name: s.name}
+
+		if s.err != nil {
+			result.Error = s.err.Error()
+			results = append(results, result)
+
+			continue
+		}
+
+		var renderErrs []string
+
+		err := collector.Render(context.Background(), mod.GetNamespace(), mod.GetName(), render.Options{
+			Path:             modulePath,
+			Values:           s.values,
+			ExtraAPIVersions: render.ExtraAPIVersions(),
+			OnDrop: func(templatePath, renderErr string) {
+				renderErrs = append(renderErrs, fmt.Sprintf("template %s dropped: %s", templatePath, firstLine(renderErr)))
+			},
+		})
+		if err != nil {
+			renderErrs = append(renderErrs, err.Error())
+		}
+
+		result.Error = strings.Join(renderErrs, "; ")
+
+		results = append(results, result)
+	}
+
+	return coverage.NewModuleReport(mod.GetName(), modulePath, results, collector), nil
+}
+
+// coverageScenario is one set of values to run the templates with. err is set
+// when the values could not be composed (e.g. a module without openapi
+// schemas); such a scenario is reported but not run.
+type coverageScenario struct {
+	name   string
+	values map[string]any
+	err    error
+}
+
+// coverageScenarios returns the values to render the module with, in a stable
+// order: the default values, the edition values and the templates-tests cases.
+func coverageScenarios(mod *modules.Module, globalSchema *spec.Schema) ([]coverageScenario, error) {
+	defaultValues, err := values.ComposeValuesFromSchemas(mod.GetPath(), mod.GetName(), globalSchema)
+	scenarios := []coverageScenario{{name: defaultValuesFile, values: defaultValues, err: err}}
+
+	editions, err := modules.DiscoverEditions(mod.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	editionNames := make([]string, 0, len(editions))
+	for edition := range editions {
+		editionNames = append(editionNames, edition)
+	}
+
+	sort.Strings(editionNames)
+
+	for _, edition := range editionNames {
+		editionValues, err := values.ComposeValuesFromSchemasForValuesFile(mod.GetPath(), mod.GetName(), globalSchema, editions[edition])
+		scenarios = append(scenarios, coverageScenario{name: editions[edition], values: editionValues, err: err})
+	}
+
+	testScenarios, err := templatesTestsScenarios(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(scenarios, testScenarios...), nil
+}
+
+// templatesTestsScenarios returns one scenario per templates-tests case, with
+// the case's values prepared the way the templates tester renders them.
+func templatesTestsScenarios(mod *modules.Module) ([]coverageScenario, error) {
+	testsDir := filepath.Join(mod.GetPath(), templates.TestsDirName)
+
+	entries, err := os.ReadDir(testsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read templates tests dir: %w", err)
+	}
+
+	var scenarios []coverageScenario
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		userValues := map[string]any{}
+
+		data, err := os.ReadFile(filepath.Join(testsDir, entry.Name(), testValuesFile))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("read values of case %q: %w", entry.Name(), err)
+		}
+
+		if err := yaml.Unmarshal(data, &userValues); err != nil {
+			return nil, fmt.Errorf("parse values of case %q: %w", entry.Name(), err)
+		}
+
+		caseValues, err := values.HelmFormatModuleImages(mod.GetPath(), mod.GetName(), userValues)
+		if err != nil {
+			return nil, fmt.Errorf("prepare values of case %q: %w", entry.Name(), err)
+		}
+
+		scenarios = append(scenarios, coverageScenario{
+			name:   filepath.ToSlash(filepath.Join(templates.TestsDirName, entry.Name())),
+			values: caseValues,
+		})
+	}
+
+	return scenarios, nil
+}
+
+func writeCoverage(report *coverage.Report, format, output string) error {
+	var w io.Writer = os.Stdout
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("create coverage output: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := coverage.Write(w, report, format); err != nil {
+		return fmt.Errorf("write coverage report: %w", err)
+	}
+
+	return nil
+}
+
+// firstLine returns the first line of a render error; nelm appends hints and,
+// in debug mode, the template source on the following ones.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+
+	return line
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rendercmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/manifestdiff"
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/modules/values"
+)
+
+// Diff renders the module at modulePath twice — as it is at the git revision
+// baseRef and as it is in the working tree — and writes an object-level diff of
+// the two renders to w as Markdown.
+//
+// Both sides are rendered with the very same values, generated once from the
+// working tree's openapi schemas, so the diff reflects template changes only.
+// The base revision is read from the git object database; the working tree is
+// left untouched. A module that does not exist at baseRef is diffed against an
+// empty render. globalValuesPath, when set, is the global values schema to
+// render with (see values.NewGlobalSchemaResolver).
+func Diff(modulePath, baseRef, globalValuesPath string, w io.Writer) error {
+	expandedPath, err := fsutils.ExpandDir(modulePath)
+	if err != nil {
+		return fmt.Errorf("failed to expand directory: %w", err)
+	}
+
+	if !fsutils.IsDir(filepath.Join(expandedPath, templatesDirName)) {
+		return fmt.Errorf("%q is not a module: no %s directory", modulePath, templatesDirName)
+	}
+
+	globalValues, err := values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(expandedPath), globalValuesPath).ForModule(expandedPath)
+	if err != nil {
+		return fmt.Errorf("failed to get global values: %w", err)
+	}
+
+	name := moduleName(expandedPath)
+
+	vals, err := values.ComposeValuesFromSchemas(expandedPath, name, globalValues.Schema)
+	if err != nil {
+		return fmt.Errorf("compose values: %w", err)
+	}
+
+	headFiles, err := modules.RenderModuleForValues(expandedPath, vals)
+	if err != nil {
+		return fmt.Errorf("render working tree: %w", err)
+	}
+
+	baseFiles, err := renderRevision(expandedPath, baseRef, vals)
+	if err != nil {
+		return err
+	}
+
+	baseObjects, err := manifestdiff.ParseFiles(baseFiles)
+	if err != nil {
+		return fmt.Errorf("parse %s render: %w", baseRef, err)
+	}
+
+	headObjects, err := manifestdiff.ParseFiles(headFiles)
+	if err != nil {
+		return fmt.Errorf("parse working tree render: %w", err)
+	}
+
+	res := manifestdiff.Compare(baseObjects, headObjects)
+	title := fmt.Sprintf("Render diff for `%s` (%s → working tree)", name, baseRef)
+
+	return manifestdiff.WriteMarkdown(w, title, &res)
+}
+
+// renderRevision renders the module as it is at ref with vals. A module absent
+// at ref (or present without templates) renders to nothing.
+func renderRevision(modulePath, ref string, vals map[string]any) (map[string]string, error) {
+	baseDir, cleanup, err := exportRevision(modulePath, ref)
+	if errors.Is(err, errNotInRevision) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	if !fsutils.IsDir(filepath.Join(baseDir, templatesDirName)) {
+		return nil, nil
+	}
+
+	files, err := modules.RenderModuleForValues(baseDir, vals)
+	if err != nil {
+		return nil, fmt.Errorf("render %s: %w", ref, err)
+	}
+
+	return files, nil
+}
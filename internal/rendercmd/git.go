@@ -0,0 +1,236 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rendercmd
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/gitutils"
+)
+
+// errNotInRevision is returned by exportRevision when the module directory does
+// not exist at the requested revision (e.g. the module is new on this branch).
+var errNotInRevision = errors.New("module does not exist at revision")
+
+// exportRevision extracts the module directory at modulePath, as it was at the
+// git revision ref, into a fresh temporary directory and returns the path of the
+// extracted module plus a cleanup func the caller must defer. The content is
+// read straight from the object database with `git archive`, so the working tree
+// and the index are never touched.
+//
+// Modules commonly symlink shared code from elsewhere in the repository (e.g.
+// charts/helm_lib). The extraction mirrors the repository layout, and every
+// symlink that points outside what was already extracted pulls its target from
+// the same revision too, so relative links resolve exactly as in a checkout.
+func exportRevision(modulePath, ref string) (string, func(), error) {
+	absModule, err := filepath.Abs(modulePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve module path: %w", err)
+	}
+
+	topLevel, err := gitutils.Run(absModule, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("find git repository: %w", err)
+	}
+
+	topLevel = strings.TrimSpace(topLevel)
+
+	rel, err := repoRelativePath(topLevel, absModule)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := gitutils.Run(topLevel, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return "", nil, fmt.Errorf("unknown revision %q", ref)
+	}
+
+	if !existsAtRevision(topLevel, ref, rel) {
+		return "", nil, fmt.Errorf("%w %s: %s", errNotInRevision, ref, rel)
+	}
+
+	tmpRoot, err := os.MkdirTemp("", "dmt-render-diff-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp dir: %w", err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(tmpRoot) }
+
+	exported := []string{rel}
+	queue := []string{rel}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		links, err := extractPath(topLevel, ref, current, tmpRoot)
+		if err != nil {
+			cleanup()
+
+			return "", nil, err
+		}
+
+		for _, target := range links {
+			if isCovered(exported, target) || !existsAtRevision(topLevel, ref, target) {
+				continue
+			}
+
+			exported = append(exported, target)
+			queue = append(queue, target)
+		}
+	}
+
+	return filepath.Join(tmpRoot, filepath.FromSlash(rel)), cleanup, nil
+}
+
+// repoRelativePath returns absPath relative to the repository top level, in the
+// forward-slashed form git expects. Symlinks are resolved on both sides so a
+// module reached through a symlinked directory still maps into the repository.
+func repoRelativePath(topLevel, absPath string) (string, error) {
+	resolvedTop, err := filepath.EvalSymlinks(topLevel)
+	if err != nil {
+		return "", fmt.Errorf("resolve repository root: %w", err)
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve module path: %w", err)
+	}
+
+	rel, err := filepath.Rel(resolvedTop, resolvedPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("module %q is outside of git repository %q", absPath, topLevel)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// extractPath writes the tree at repoPath (repository-relative) from revision
+// ref into destRoot, mirroring the repository layout. It returns the
+// repository-relative targets of the symlinks it created that stay inside the
+// repository.
+func extractPath(topLevel, ref, repoPath, destRoot string) ([]string, error) {
+	archive, err := gitutils.Run(topLevel, "archive", "--format=tar", ref, "--", repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %q at %s: %w", repoPath, ref, err)
+	}
+
+	var links []string
+
+	tr := tar.NewReader(strings.NewReader(archive))
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read archive of %q: %w", repoPath, err)
+		}
+
+		target := filepath.Join(destRoot, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destRoot)+string(filepath.Separator)) {
+			return nil, fmt.Errorf("archive entry %q escapes the destination", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return nil, fmt.Errorf("create directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return nil, err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return nil, fmt.Errorf("create directory: %w", err)
+			}
+
+			if err := os.Symlink(hdr.Linkname, target); err != nil && !os.IsExist(err) {
+				return nil, fmt.Errorf("create symlink: %w", err)
+			}
+
+			if linkTarget, ok := symlinkRepoTarget(hdr.Name, hdr.Linkname); ok {
+				links = append(links, linkTarget)
+			}
+		}
+	}
+
+	return links, nil
+}
+
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+
+	_, copyErr := io.Copy(f, r)
+	closeErr := f.Close()
+
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		return fmt.Errorf("write file %q: %w", target, err)
+	}
+
+	return nil
+}
+
+// symlinkRepoTarget resolves a relative symlink found at entryName
+// (repository-relative) to the repository-relative path it points to. Absolute
+// links and links leaving the repository are not followed.
+func symlinkRepoTarget(entryName, linkname string) (string, bool) {
+	if path.IsAbs(linkname) {
+		return "", false
+	}
+
+	resolved := path.Clean(path.Join(path.Dir(strings.TrimSuffix(entryName, "/")), linkname))
+	if resolved == ".." || strings.HasPrefix(resolved, "../") || resolved == "." {
+		return "", false
+	}
+
+	return resolved, true
+}
+
+// isCovered reports whether repoPath equals or lies under one of the already
+// extracted paths.
+func isCovered(exported []string, repoPath string) bool {
+	for _, p := range exported {
+		if repoPath == p || strings.HasPrefix(repoPath, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func existsAtRevision(topLevel, ref, repoPath string) bool {
+	_, err := gitutils.Run(topLevel, "cat-file", "-e", ref+":"+repoPath)
+
+	return err == nil
+}
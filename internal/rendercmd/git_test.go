@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rendercmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/gitutils"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "test"},
+	} {
+		_, err := gitutils.Run(repo, args...)
+		require.NoError(t, err)
+	}
+
+	return repo
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func commitAll(t *testing.T, repo string) {
+	t.Helper()
+
+	_, err := gitutils.Run(repo, "add", "-A")
+	require.NoError(t, err)
+	_, err = gitutils.Run(repo, "commit", "-q", "-m", "commit")
+	require.NoError(t, err)
+}
+
+func TestExportRevision(t *testing.T) {
+	repo := initRepo(t)
+	module := filepath.Join(repo, "modules", "010-test")
+
+	writeFile(t, filepath.Join(repo, "helm_lib", "lib.tpl"), "base-lib")
+	writeFile(t, filepath.Join(module, "templates", "cm.yaml"), "base")
+	require.NoError(t, os.Symlink("../../helm_lib", filepath.Join(module, "lib")))
+	commitAll(t, repo)
+
+	_, err := gitutils.Run(repo, "tag", "base")
+	require.NoError(t, err)
+
+	writeFile(t, filepath.Join(module, "templates", "cm.yaml"), "head")
+	writeFile(t, filepath.Join(repo, "helm_lib", "lib.tpl"), "head-lib")
+	commitAll(t, repo)
+	writeFile(t, filepath.Join(module, "templates", "cm.yaml"), "dirty")
+
+	dir, cleanup, err := exportRevision(module, "base")
+	require.NoError(t, err)
+
+	defer cleanup()
+
+	data, err := os.ReadFile(filepath.Join(dir, "templates", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "base", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "lib", "lib.tpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "base-lib", string(data), "symlinked code must come from the same revision")
+
+	data, err = os.ReadFile(filepath.Join(module, "templates", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "dirty", string(data), "working tree must not be touched")
+}
+
+func TestExportRevisionMissingModule(t *testing.T) {
+	repo := initRepo(t)
+
+	writeFile(t, filepath.Join(repo, "README.md"), "readme")
+	commitAll(t, repo)
+
+	module := filepath.Join(repo, "modules", "020-new")
+	writeFile(t, filepath.Join(module, "templates", "cm.yaml"), "new")
+
+	_, _, err := exportRevision(module, "HEAD")
+	require.ErrorIs(t, err, errNotInRevision)
+
+	_, _, err = exportRevision(module, "no-such-ref")
+	require.ErrorContains(t, err, "unknown revision")
+}
+
+func TestSymlinkRepoTarget(t *testing.T) {
+	target, ok := symlinkRepoTarget("modules/010-test/lib", "../../helm_lib")
+	assert.True(t, ok)
+	assert.Equal(t, "helm_lib", target)
+
+	_, ok = symlinkRepoTarget("modules/lib", "../../outside")
+	assert.False(t, ok)
+
+	_, ok = symlinkRepoTarget("modules/lib", "/abs/path")
+	assert.False(t, ok)
+}
@@ -40,8 +40,6 @@ import (
 const (
 	// RenderedDirName is the per-module directory that receives the rendered output.
 	RenderedDirName = "rendered"
-	// openAPIDirName is the per-module directory holding the values schemas.
-	openAPIDirName = "openapi"
 	// templatesDirName is the per-module directory holding the templates to
 	// render. A directory without it is not a renderable module.
 	templatesDirName = "templates"
@@ -50,10 +48,6 @@ const (
 	// defaultEditionName is the directory name used for the base (non-edition)
 	// render when edition-specific values files are present.
 	defaultEditionName = "default"
-	// editionValuesPrefix / editionValuesSuffix bound the edition name in an
-	// edition-specific values schema file (e.g. "values_ce.yaml" -> "ce").
-	editionValuesPrefix = "values_"
-	editionValuesSuffix = ".yaml"
 )
 
 // Render discovers all modules under dir (including subdirectories) and renders
@@ -62,8 +56,9 @@ const (
 // When outputDir is empty, every module is rendered into a 'rendered' directory
 // at its own root. When outputDir is set, all modules are rendered into a shared
 // '<outputDir>/rendered/<module-name>/<edition>/' tree instead; outputDir must
-// be an existing directory.
-func Render(dir, outputDir string) error {
+// be an existing directory. globalValuesPath, when set, is the global values
+// schema to render with (see values.NewGlobalSchemaResolver).
+func Render(dir, outputDir, globalValuesPath string) error {
 	expandedDir, err := fsutils.ExpandDir(dir)
 	if err != nil {
 		return fmt.Errorf("failed to expand directory: %w", err)
@@ -84,10 +79,7 @@ func Render(dir, outputDir string) error {
 		return nil
 	}
 
-	globalValues, err := values.GetGlobalValues(getRootDirectory(expandedDir))
-	if err != nil {
-		return fmt.Errorf("failed to get global values: %w", err)
-	}
+	globalSchemas := values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(expandedDir), globalValuesPath)
 
 	var hasErrors bool
 
@@ -107,11 +99,13 @@ func Render(dir, outputDir string) error {
 
 		log.Info("Rendering module", slog.String("module", moduleName), slog.String("path", modulePath))
 
-		var renderErr error
-		if baseRenderedDir != "" {
-			renderErr = renderModuleToOutput(modulePath, globalValues, moduleName, baseRenderedDir)
-		} else {
-			renderErr = renderModule(modulePath, globalValues)
+		globalValues, renderErr := globalSchemas.ForModule(modulePath)
+		if renderErr == nil {
+			if baseRenderedDir != "" {
+				renderErr = renderModuleToOutput(modulePath, globalValues.Schema, moduleName, baseRenderedDir)
+			} else {
+				renderErr = renderModule(modulePath, globalValues.Schema)
+			}
 		}
 
 		if renderErr != nil {
@@ -175,7 +169,7 @@ func prepareOutputDir(outputDir string) (string, error) {
 //
 // Otherwise the manifests are written directly under 'rendered'.
 func renderModule(modulePath string, globalSchema *spec.Schema) error {
-	editions, err := discoverEditions(modulePath)
+	editions, err := modules.DiscoverEditions(modulePath)
 	if err != nil {
 		return err
 	}
@@ -208,7 +202,7 @@ func renderModule(modulePath string, globalSchema *spec.Schema) error {
 // openapi/values.yaml) is always rendered; any 'openapi/values_<edition>.yaml'
 // files add further editions. The module's subtree is recreated on every run.
 func renderModuleToOutput(modulePath string, globalSchema *spec.Schema, moduleName, baseRenderedDir string) error {
-	editions, err := discoverEditions(modulePath)
+	editions, err := modules.DiscoverEditions(modulePath)
 	if err != nil {
 		return err
 	}
@@ -260,42 +254,6 @@ func renderEdition(modulePath string, globalSchema *spec.Schema, valuesFile, tar
 	return nil
 }
 
-// discoverEditions returns the edition-specific values schema files found in the
-// module's openapi directory, keyed by edition name (e.g. "ce" ->
-// "values_ce.yaml").
-func discoverEditions(modulePath string) (map[string]string, error) {
-	entries, err := os.ReadDir(filepath.Join(modulePath, openAPIDirName))
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-
-		return nil, fmt.Errorf("read openapi directory: %w", err)
-	}
-
-	editions := make(map[string]string)
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		name := entry.Name()
-		if !strings.HasPrefix(name, editionValuesPrefix) || !strings.HasSuffix(name, editionValuesSuffix) {
-			continue
-		}
-
-		edition := strings.TrimSuffix(strings.TrimPrefix(name, editionValuesPrefix), editionValuesSuffix)
-		if edition == "" {
-			continue
-		}
-
-		editions[edition] = name
-	}
-
-	return editions, nil
-}
-
 // moduleName returns the module's name taken from its 'module.yaml' (falling
 // back to 'Chart.yaml' and finally to the directory name).
 func moduleName(modulePath string) string {
@@ -315,26 +273,3 @@ func moduleName(modulePath string) string {
 
 	return filepath.Base(modulePath)
 }
-
-// getRootDirectory walks up from dir looking for a deckhouse repository root
-// (one that ships global-hooks/openapi values). When found, those global values
-// are used; otherwise the embedded defaults are used.
-func getRootDirectory(dir string) string {
-	for {
-		if fsutils.IsDir(filepath.Join(dir, "global-hooks", "openapi")) &&
-			fsutils.IsDir(filepath.Join(dir, "modules")) &&
-			fsutils.IsFile(filepath.Join(dir, "global-hooks", "openapi", "config-values.yaml")) &&
-			fsutils.IsFile(filepath.Join(dir, "global-hooks", "openapi", "values.yaml")) {
-			return dir
-		}
-
-		parent := filepath.Dir(dir)
-		if dir == parent || parent == "" {
-			break
-		}
-
-		dir = parent
-	}
-
-	return ""
-}
@@ -0,0 +1,351 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sourcemap locates the fields of rendered objects in the Helm
+// templates they were rendered from.
+//
+// nelm returns parsed objects, not the rendered text, so the map is built from
+// the template source: its YAML skeleton (the literal keys, list items and
+// scalars, positioned by indentation) is parsed into a tree per document,
+// skipping template actions. A field of a rendered object is then located by
+// walking that tree; a field produced by an action (an include, toYaml, a
+// range over values) resolves to the nearest literal ancestor, which is the
+// template line that emits it.
+//
+// Findings are located at two levels only: an object resolves to its "kind:"
+// line and a container to its list item. An object whose whole document is
+// emitted by an action (e.g. an include'd helper) has no literal document to
+// match and stays at line 0.
+package sourcemap
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Node is a position in a template: a document, a map key or a list item.
+type Node struct {
+	// Key is the map key; empty for documents and list items.
+	Key string
+	// Value is the literal scalar of a key, e.g. "Deployment" for "kind:".
+	Value string
+	// Line is the 1-based template line of the node.
+	Line int
+
+	children []*Node
+	items    []*Node
+	// name is the "name" value of a list item, used to match "[name=x]".
+	name string
+}
+
+// Template is the parsed skeleton of a template file.
+type Template struct {
+	docs []*Node
+}
+
+var keyRe = regexp.MustCompile(`^([^\s#'"{}\[\],&*!|>%@` + "`" + `-][^:#{}]*?|"[^"]*"|'[^']*'):(?:\s+(.*))?$`)
+
+type frame struct {
+	indent int
+	node   *Node
+	item   bool
+}
+
+// Parse parses the skeleton of a template.
+func Parse(src []byte) *Template {
+	t := &Template{}
+
+	var (
+		doc   *Node
+		stack []frame
+		// blockIndent is the indentation of the key whose block scalar is being
+		// skipped, or -1.
+		blockIndent = -1
+	)
+
+	newDoc := func() {
+		doc = &Node{}
+		t.docs = append(t.docs, doc)
+		stack = stack[:0]
+	}
+
+	for idx, raw := range strings.Split(string(src), "\n") {
+		line := idx + 1
+		text := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(text)
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				continue
+			}
+
+			blockIndent = -1
+		}
+
+		switch {
+		case trimmed == "---" || strings.HasPrefix(trimmed, "--- "):
+			newDoc()
+			continue
+		case trimmed == "", strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "{{"):
+			// Blank lines, comments and action-only lines carry no fields.
+			continue
+		}
+
+		if doc == nil {
+			newDoc()
+		}
+
+		if doc.Line == 0 {
+			doc.Line = line
+		}
+
+		content := trimmed
+		isItem := false
+
+		if content == "-" || strings.HasPrefix(content, "- ") {
+			isItem = true
+
+			// Pop deeper frames and sibling items; a key at the dash column
+			// with an empty value is the parent of a compact list.
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if top.indent > indent || top.indent == indent && (top.item || top.node.Value != "") {
+					stack = stack[:len(stack)-1]
+					continue
+				}
+
+				break
+			}
+
+			parent := doc
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1].node
+			}
+
+			item := &Node{Line: line}
+			parent.items = append(parent.items, item)
+			stack = append(stack, frame{indent: indent, node: item, item: true})
+
+			rest := strings.TrimSpace(strings.TrimPrefix(content, "-"))
+			if rest == "" || strings.HasPrefix(rest, "{{") || !keyRe.MatchString(rest) {
+				item.Value = rest
+				continue
+			}
+
+			indent += len(content) - len(rest)
+			content = rest
+		}
+
+		m := keyRe.FindStringSubmatch(content)
+		if m == nil {
+			// A scalar continuation or a templated key.
+			continue
+		}
+
+		if !isItem {
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		parent := doc
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1].node
+		}
+
+		key := &Node{Key: unquote(m[1]), Value: unquote(stripComment(m[2])), Line: line}
+		parent.children = append(parent.children, key)
+
+		if key.Key == "name" && stackTopIsItem(stack) {
+			parent.name = key.Value
+		}
+
+		if strings.HasPrefix(key.Value, "|") || strings.HasPrefix(key.Value, ">") {
+			blockIndent = indent
+		}
+
+		stack = append(stack, frame{indent: indent, node: key})
+	}
+
+	return t
+}
+
+func stackTopIsItem(stack []frame) bool {
+	return len(stack) > 0 && stack[len(stack)-1].item
+}
+
+func stripComment(value string) string {
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+
+	if idx := strings.Index(value, " #"); idx >= 0 && !strings.Contains(value[:idx], "{{") {
+		value = value[:idx]
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+func templated(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+// Document returns the document the object of the kind and name was rendered
+// from, or nil when no document of the template can produce it. Literal kinds
+// and names are preferred over templated ones.
+func (t *Template) Document(kind, name string) *Node {
+	var (
+		best      *Node
+		bestScore int
+	)
+
+	for _, doc := range t.docs {
+		score := matchScore(doc.child("kind"), kind)
+		if score == 0 {
+			continue
+		}
+
+		var nameNode *Node
+		if metadata := doc.child("metadata"); metadata != nil {
+			nameNode = metadata.child("name")
+		}
+
+		nameScore := 1
+		if nameNode != nil {
+			nameScore = matchScore(nameNode, name)
+		}
+
+		if nameScore == 0 {
+			continue
+		}
+
+		if score += nameScore; score > bestScore {
+			best, bestScore = doc, score
+		}
+	}
+
+	return best
+}
+
+// matchScore is 2 for a literal match, 1 for a templated value and 0 for a
+// mismatch or a missing key.
+func matchScore(n *Node, value string) int {
+	switch {
+	case n == nil:
+		return 0
+	case n.Value == value:
+		return 2
+	case templated(n.Value):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (n *Node) child(key string) *Node {
+	for _, c := range n.children {
+		if c.Key == key {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Locate returns the template line of the field at path, or of its nearest
+// literal ancestor. Path elements are map keys, list indexes "[0]" and list
+// items selected by name "[name=app]". A document resolves to its "kind:"
+// line.
+func (n *Node) Locate(path ...string) int {
+	if n == nil {
+		return 0
+	}
+
+	line := n.Line
+	if kind := n.child("kind"); kind != nil && n.Key == "" {
+		line = kind.Line
+	}
+
+	cur := n
+
+	for _, seg := range path {
+		next := cur.next(seg, false)
+		if next == nil {
+			break
+		}
+
+		cur, line = next, next.Line
+	}
+
+	return line
+}
+
+// Find returns the node at path, or nil when the template has no literal
+// field there. Unlike Locate, "[name=x]" only matches an item named x.
+func (n *Node) Find(path ...string) *Node {
+	cur := n
+
+	for _, seg := range path {
+		if cur == nil {
+			return nil
+		}
+
+		cur = cur.next(seg, true)
+	}
+
+	return cur
+}
+
+// next returns the child of n at seg. Unless exact is set, an item selected by
+// a name no item has falls back to the first item with a templated name.
+func (n *Node) next(seg string, exact bool) *Node {
+	if !strings.HasPrefix(seg, "[") || !strings.HasSuffix(seg, "]") {
+		return n.child(seg)
+	}
+
+	sel := seg[1 : len(seg)-1]
+
+	if name, ok := strings.CutPrefix(sel, "name="); ok {
+		var fallback *Node
+
+		for _, item := range n.items {
+			switch {
+			case item.name == name:
+				return item
+			case !exact && fallback == nil && (item.name == "" || templated(item.name)):
+				fallback = item
+			}
+		}
+
+		return fallback
+	}
+
+	if idx, err := strconv.Atoi(sel); err == nil && idx >= 0 && idx < len(n.items) {
+		return n.items[idx]
+	}
+
+	return nil
+}
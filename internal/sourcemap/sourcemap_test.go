@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcemap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const template = `{{- $name := "app" }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ $name }}-config
+data:
+  config.yaml: |
+    kind: Deployment
+    spec:
+      replicas: 3
+---
+# Source: the workload
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-{{ .Chart.Name }}
+  {{- include "helm_lib_module_labels" (list . (dict "app" "app")) | nindent 2 }}
+spec:
+  replicas: 1
+  template:
+    spec:
+      {{- include "helm_lib_priority_class" (tuple . "cluster-medium") | nindent 6 }}
+      securityContext:
+        runAsNonRoot: true # nobody
+      containers:
+      - name: init-like
+        image: {{ .Values.image }}
+        ports:
+          - name: http
+            containerPort: 8080
+      - image: {{ .Values.image }}
+        name: "app"
+        securityContext:
+          {{- include "helm_lib_module_container_security_context_read_only_root_filesystem" . | nindent 10 }}
+        args:
+          - --flag
+          - --other
+{{- range $i := until 2 }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc-{{ $i }}
+{{- end }}
+`
+
+func TestDocument(t *testing.T) {
+	tpl := Parse([]byte(template))
+
+	doc := tpl.Document("Deployment", "app")
+	require.NotNil(t, doc)
+	assert.Equal(t, 14, doc.Line, "document starts at its first field")
+	assert.Equal(t, 15, doc.Locate(), "document resolves to its kind")
+
+	assert.Equal(t, 4, tpl.Document("ConfigMap", "app-config").Locate(), "templated names match")
+	assert.Equal(t, 43, tpl.Document("Service", "svc-1").Locate(), "ranged documents match")
+	assert.Nil(t, tpl.Document("Deployment", "other"))
+	assert.Nil(t, tpl.Document("Secret", "app"))
+}
+
+func TestLocate(t *testing.T) {
+	doc := Parse([]byte(template)).Document("Deployment", "app")
+
+	tests := []struct {
+		name string
+		path []string
+		line int
+	}{
+		{name: "literal field", path: []string{"spec", "replicas"}, line: 21},
+		{name: "field with comment", path: []string{"spec", "template", "spec", "securityContext", "runAsNonRoot"}, line: 26},
+		{name: "included field resolves to the include's parent", path: []string{"spec", "template", "spec", "priorityClassName"}, line: 23},
+		{name: "included labels resolve to metadata", path: []string{"metadata", "labels", "app"}, line: 16},
+		{name: "compact list item by name", path: []string{"spec", "template", "spec", "containers", "[name=init-like]"}, line: 28},
+		{name: "list item named after its first key", path: []string{"spec", "template", "spec", "containers", "[name=app]", "securityContext"}, line: 35},
+		{name: "nested list item", path: []string{"spec", "template", "spec", "containers", "[name=init-like]", "ports", "[name=http]", "containerPort"}, line: 32},
+		{name: "list item by index", path: []string{"spec", "template", "spec", "containers", "[1]", "args", "[1]"}, line: 39},
+		{name: "missing item", path: []string{"spec", "template", "spec", "containers", "[name=sidecar]"}, line: 27},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.line, doc.Locate(tt.path...))
+		})
+	}
+}
+
+func TestBlockScalarsAreNotParsed(t *testing.T) {
+	tpl := Parse([]byte(template))
+
+	assert.Nil(t, tpl.Document("Deployment", ""), "the Deployment inside the ConfigMap data is not a document")
+
+	cm := tpl.Document("ConfigMap", "app-config")
+	assert.Equal(t, 8, cm.Locate("data", "config.yaml", "spec"))
+}
+
+func TestFind(t *testing.T) {
+	doc := Parse([]byte(template)).Document("Deployment", "app")
+
+	item := doc.Find("spec", "template", "spec", "containers", "[name=app]")
+	require.NotNil(t, item)
+	assert.Equal(t, 33, item.Line)
+
+	assert.Nil(t, doc.Find("spec", "template", "spec", "containers", "[name=sidecar]"))
+	assert.Nil(t, doc.Find("spec", "template", "spec", "priorityClassName"))
+}
+
+func TestNilNode(t *testing.T) {
+	var n *Node
+	assert.Equal(t, 0, n.Locate("spec"))
+	assert.Nil(t, n.Find("spec"))
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/deckhouse/dmt/internal/flags"
+	"github.com/deckhouse/dmt/internal/sourcemap"
 )
 
 const (
@@ -59,6 +60,9 @@ type StoreObject struct {
 	shortPath    string
 	Hash         string
 	Unstructured unstructured.Unstructured
+	// Source is the template document the object was rendered from; nil when
+	// it is unknown (see sourcemap).
+	Source *sourcemap.Node
 }
 
 func GetResourceIndex(object StoreObject) ResourceIndex {
@@ -357,6 +361,81 @@ func (s *StoreObject) IsHostNetwork() (bool, error) {
 	return false, nil
 }
 
+// GetPodSpec returns the pod spec of a pod or pod controller (the pod template
+// of a Deployment, DaemonSet, StatefulSet, ReplicaSet, Job or CronJob). It
+// returns nil for any other kind.
+func (s *StoreObject) GetPodSpec() (*v1.PodSpec, error) {
+	converter := runtime.DefaultUnstructuredConverter
+
+	switch s.Unstructured.GetKind() {
+	case deploymentString:
+		deployment := new(appsv1.Deployment)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), deployment)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to Deployment failed: %w", err)
+		}
+
+		return &deployment.Spec.Template.Spec, nil
+	case daemonSetString:
+		daemonSet := new(appsv1.DaemonSet)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), daemonSet)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to DaemonSet failed: %w", err)
+		}
+
+		return &daemonSet.Spec.Template.Spec, nil
+	case statefulSetString:
+		statefulSet := new(appsv1.StatefulSet)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), statefulSet)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to StatefulSet failed: %w", err)
+		}
+
+		return &statefulSet.Spec.Template.Spec, nil
+	case podString:
+		pod := new(v1.Pod)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), pod)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to Pod failed: %w", err)
+		}
+
+		return &pod.Spec, nil
+	case jobString:
+		job := new(batchv1.Job)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), job)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to Job failed: %w", err)
+		}
+
+		return &job.Spec.Template.Spec, nil
+	case cronJobString:
+		cronJob := new(batchv1.CronJob)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), cronJob)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to CronJob failed: %w", err)
+		}
+
+		return &cronJob.Spec.JobTemplate.Spec.Template.Spec, nil
+	case replicaSetString:
+		replicaSet := new(appsv1.ReplicaSet)
+
+		err := converter.FromUnstructured(s.Unstructured.UnstructuredContent(), replicaSet)
+		if err != nil {
+			return nil, fmt.Errorf("convert Unstructured to ReplicaSet failed: %w", err)
+		}
+
+		return &replicaSet.Spec.Template.Spec, nil
+	}
+
+	return nil, nil
+}
+
 func (s *StoreObject) GetPath() string {
 	if flags.AbsPath {
 		return s.AbsPath
@@ -381,6 +460,44 @@ func (s *StoreObject) Identity() string {
 	return fmt.Sprintf("kind = %s ; name = %s ; namespace = %s", kind, name, namespace)
 }
 
+// LineNumber returns the template line of the object's "kind:", or 0 when the
+// source of the object is unknown.
+func (s *StoreObject) LineNumber() int {
+	return s.Source.Locate()
+}
+
+// ContainerLineNumber returns the template line of the named container or init
+// container. When it is not a literal list item, the line of the first item
+// with a templated name, the "containers:" key, the pod spec or the object is
+// returned instead.
+func (s *StoreObject) ContainerLineNumber(name string) int {
+	podSpec := podSpecPath(s.Unstructured.GetKind())
+	if podSpec == nil {
+		return s.LineNumber()
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		if item := s.Source.Find(append(append([]string{}, podSpec...), field, "[name="+name+"]")...); item != nil {
+			return item.Line
+		}
+	}
+
+	return s.Source.Locate(append(podSpec, "containers", "[name="+name+"]")...)
+}
+
+func podSpecPath(kind string) []string {
+	switch kind {
+	case deploymentString, daemonSetString, statefulSetString, jobString, replicaSetString:
+		return []string{"spec", "template", "spec"}
+	case cronJobString:
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	case podString:
+		return []string{"spec"}
+	default:
+		return nil
+	}
+}
+
 type UnstructuredObjectStore struct {
 	Storage map[ResourceIndex]StoreObject
 }
@@ -390,10 +507,16 @@ func NewUnstructuredObjectStore() *UnstructuredObjectStore {
 }
 
 func (s *UnstructuredObjectStore) Put(path, shortPath string, object map[string]any, raw []byte) error {
+	return s.PutWithSource(path, shortPath, object, raw, nil)
+}
+
+// PutWithSource stores the object together with the template document it was
+// rendered from.
+func (s *UnstructuredObjectStore) PutWithSource(path, shortPath string, object map[string]any, raw []byte, source *sourcemap.Node) error {
 	var u unstructured.Unstructured
 	u.SetUnstructuredContent(object)
 
-	storeObject := StoreObject{AbsPath: path, shortPath: shortPath, Unstructured: u, Hash: NewSHA256(raw)}
+	storeObject := StoreObject{AbsPath: path, shortPath: shortPath, Unstructured: u, Hash: NewSHA256(raw), Source: source}
 
 	var err error
 
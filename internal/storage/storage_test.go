@@ -573,3 +573,72 @@ func TestStoreObject_IsHostNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreObject_GetPodSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		object        map[string]any
+		expectNil     bool
+		expectAccount string
+	}{
+		{
+			name: "Deployment",
+			object: map[string]any{
+				"kind": "Deployment",
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{"serviceAccountName": "deployment-sa"},
+					},
+				},
+			},
+			expectAccount: "deployment-sa",
+		},
+		{
+			name: "CronJob",
+			object: map[string]any{
+				"kind": "CronJob",
+				"spec": map[string]any{
+					"jobTemplate": map[string]any{
+						"spec": map[string]any{
+							"template": map[string]any{
+								"spec": map[string]any{"serviceAccountName": "cronjob-sa"},
+							},
+						},
+					},
+				},
+			},
+			expectAccount: "cronjob-sa",
+		},
+		{
+			name: "Pod",
+			object: map[string]any{
+				"kind": "Pod",
+				"spec": map[string]any{"serviceAccountName": "pod-sa"},
+			},
+			expectAccount: "pod-sa",
+		},
+		{
+			name:      "ConfigMap",
+			object:    map[string]any{"kind": "ConfigMap"},
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := StoreObject{Unstructured: unstructured.Unstructured{Object: tt.object}}
+
+			spec, err := obj.GetPodSpec()
+			require.NoError(t, err)
+
+			if tt.expectNil {
+				assert.Nil(t, spec)
+
+				return
+			}
+
+			require.NotNil(t, spec)
+			assert.Equal(t, tt.expectAccount, spec.ServiceAccountName)
+		})
+	}
+}
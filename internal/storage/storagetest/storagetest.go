@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storagetest builds stored objects from YAML manifests for rule
+// tests.
+package storagetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/storage"
+)
+
+// Object parses a single-document manifest.
+func Object(t testing.TB, manifest string) storage.StoreObject {
+	t.Helper()
+
+	obj := map[string]any{}
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &obj))
+
+	return storage.StoreObject{Unstructured: unstructured.Unstructured{Object: obj}}
+}
+
+// Objects parses the manifests, each of which may hold several "---"
+// separated documents, into an object storage.
+func Objects(t testing.TB, manifests ...string) map[storage.ResourceIndex]storage.StoreObject {
+	t.Helper()
+
+	objects := map[storage.ResourceIndex]storage.StoreObject{}
+
+	for _, manifest := range manifests {
+		for _, doc := range strings.Split(manifest, "\n---\n") {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+
+			object := Object(t, doc)
+			objects[storage.GetResourceIndex(object)] = object
+		}
+	}
+
+	return objects
+}
+
+// Patch replaces the first occurrence of old in manifest with new. The test
+// fails when manifest does not contain old, so a fixture edit cannot silently
+// turn a case into a copy of the unmodified manifest.
+func Patch(t testing.TB, manifest, old, new string) string {
+	t.Helper()
+
+	require.Contains(t, manifest, old, "the manifest to patch does not contain the replaced text")
+
+	return strings.Replace(manifest, old, new, 1)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// runFilter selects testcases by `<tester>/<module>/<case>`, the way
+// `go test -run` selects subtests: the expression is split by '/' and each
+// element is an unanchored regular expression matched against the
+// corresponding level. Missing levels match everything, and the last element
+// matches the rest of the case name, so case names may contain '/'.
+type runFilter struct {
+	levels []*regexp.Regexp
+}
+
+const (
+	levelTester = iota
+	levelModule
+	levelCase
+	levelCount
+)
+
+func parseRunFilter(expr string) (*runFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	f := &runFilter{}
+
+	for i, part := range strings.SplitN(expr, "/", levelCount) {
+		re, err := regexp.Compile(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --run expression %q, element %d: %w", expr, i, err)
+		}
+
+		f.levels = append(f.levels, re)
+	}
+
+	return f, nil
+}
+
+func (f *runFilter) match(level int, name string) bool {
+	if f == nil || level >= len(f.levels) {
+		return true
+	}
+
+	return f.levels[level].MatchString(name)
+}
+
+func (f *runFilter) matchTester(tester string) bool {
+	return f.match(levelTester, tester)
+}
+
+func (f *runFilter) matchModule(tester, module string) bool {
+	return f.matchTester(tester) && f.match(levelModule, module)
+}
+
+func (f *runFilter) matchCase(tester, module, name string) bool {
+	return f.matchModule(tester, module) && f.match(levelCase, name)
+}
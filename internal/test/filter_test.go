@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFilter(t *testing.T) {
+	f, err := parseRunFilter("")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+	assert.True(t, f.matchCase("templates", "app", "default"), "no filter selects everything")
+
+	f, err = parseRunFilter("templates")
+	require.NoError(t, err)
+	assert.True(t, f.matchTester("templates"))
+	assert.False(t, f.matchTester("conversions"))
+	assert.True(t, f.matchCase("templates", "app", "default"))
+
+	f, err = parseRunFilter("^templates$/^app$/ha|edge")
+	require.NoError(t, err)
+	assert.True(t, f.matchModule("templates", "app"))
+	assert.False(t, f.matchModule("templates", "app-2"))
+	assert.True(t, f.matchCase("templates", "app", "ha-mode"))
+	assert.True(t, f.matchCase("templates", "app", "edge"))
+	assert.False(t, f.matchCase("templates", "app", "default"))
+
+	f, err = parseRunFilter("hooks//pkg/Test")
+	require.NoError(t, err)
+	assert.True(t, f.matchCase("hooks", "app", "hooks/pkg/TestHook"), "the case element may contain '/'")
+
+	_, err = parseRunFilter("templates/(")
+	require.Error(t, err)
+}
@@ -20,34 +20,46 @@ import (
 	"bytes"
 	"cmp"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/kyokomi/emoji"
 	"github.com/mitchellh/go-wordwrap"
 
+	"github.com/deckhouse/dmt/internal/fsutils"
 	"github.com/deckhouse/dmt/internal/moduleloader"
+	"github.com/deckhouse/dmt/internal/modules/values"
 	"github.com/deckhouse/dmt/pkg"
 	"github.com/deckhouse/dmt/pkg/config"
 	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
 	"github.com/deckhouse/dmt/pkg/testers"
 	conversions "github.com/deckhouse/dmt/pkg/testers/conversions"
+	"github.com/deckhouse/dmt/pkg/testers/hooks"
+	"github.com/deckhouse/dmt/pkg/testers/openapiexamples"
+	"github.com/deckhouse/dmt/pkg/testers/openapivalidations"
+	"github.com/deckhouse/dmt/pkg/testers/prometheusrules"
 	templatestester "github.com/deckhouse/dmt/pkg/testers/templates"
 )
 
 type moduleResult struct {
-	name    string
-	tester  string
-	failed  bool
-	skipped bool
+	name     string
+	tester   string
+	failed   bool
+	skipped  bool
+	duration time.Duration
 }
 
 type Manager struct {
-	cfg     *config.RootConfig
-	modules []string
+	cfg      *config.RootConfig
+	modules  []string
+	parallel int
+	filter   *runFilter
 
 	errors  *pkgerrors.TestErrorsList
 	testers []testers.Tester
@@ -57,6 +69,11 @@ type Manager struct {
 type managerOptions struct {
 	enabled         map[string]bool
 	updateSnapshots bool
+	hooksTimeout    time.Duration
+	parallel        int
+	run             string
+	failFast        bool
+	globalValues    string
 }
 
 // Option customizes which testers a Manager runs and how.
@@ -81,25 +98,72 @@ func WithUpdateSnapshots(update bool) Option {
 	}
 }
 
+// WithHooksTimeout bounds each `go test` run of the hooks tester.
+func WithHooksTimeout(timeout time.Duration) Option {
+	return func(o *managerOptions) {
+		o.hooksTimeout = timeout
+	}
+}
+
+// WithParallel sets the number of modules tested concurrently (default: 1).
+func WithParallel(parallel int) Option {
+	return func(o *managerOptions) {
+		o.parallel = parallel
+	}
+}
+
+// WithRun restricts the run to the testcases matching a `go test -run` like
+// expression over `<tester>/<module>/<case>`.
+func WithRun(expr string) Option {
+	return func(o *managerOptions) {
+		o.run = expr
+	}
+}
+
+// WithFailFast stops the run after the first failure.
+func WithFailFast(failFast bool) Option {
+	return func(o *managerOptions) {
+		o.failFast = failFast
+	}
+}
+
+// WithGlobalValues renders the modules with the global values schema at path
+// instead of the one picked for each module (see values.GlobalSchemaResolver).
+func WithGlobalValues(path string) Option {
+	return func(o *managerOptions) {
+		o.globalValues = path
+	}
+}
+
 func NewManager(dir string, rootConfig *config.RootConfig, opts ...Option) (*Manager, error) {
 	options := &managerOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
 
-	m := &Manager{
-		cfg:    rootConfig,
-		errors: pkgerrors.NewTestErrorsList(),
+	filter, err := parseRunFilter(options.run)
+	if err != nil {
+		return nil, err
 	}
 
-	var err error
+	listOpts := []pkgerrors.TestErrorsListOption{pkgerrors.WithFailFast(options.failFast)}
+	if filter != nil {
+		listOpts = append(listOpts, pkgerrors.WithCaseFilter(filter.matchCase))
+	}
+
+	m := &Manager{
+		cfg:      rootConfig,
+		parallel: max(options.parallel, 1),
+		filter:   filter,
+		errors:   pkgerrors.NewTestErrorsList(listOpts...),
+	}
 
 	m.modules, err = moduleloader.GetModulePaths(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get module paths: %w", err)
 	}
 
-	m.registerTesters(options)
+	m.registerTesters(options, values.NewGlobalSchemaResolver(fsutils.GetRootDirectory(dir), options.globalValues))
 
 	return m, nil
 }
@@ -110,21 +174,48 @@ func (m *Manager) Run() {
 		return
 	}
 
-	for _, modulePath := range m.modules {
-		result := m.runModuleTests(modulePath)
-		m.results = append(m.results, result)
+	m.results = make([]moduleResult, len(m.modules))
+
+	wg := new(sync.WaitGroup)
+	processingCh := make(chan struct{}, m.parallel)
+
+	for idx, modulePath := range m.modules {
+		processingCh <- struct{}{}
+
+		if m.errors.Stopped() {
+			<-processingCh
+
+			m.results[idx] = moduleResult{name: extractModuleName(modulePath), skipped: true}
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-processingCh
+				wg.Done()
+			}()
+
+			m.results[idx] = m.runModuleTests(modulePath)
+		}()
 	}
+
+	wg.Wait()
 }
 
 func (m *Manager) runModuleTests(modulePath string) moduleResult {
 	moduleName := extractModuleName(modulePath)
+
+	start := time.Now()
 	failed, testerName := m.runTesters(modulePath, moduleName)
 
 	if !failed && testerName == "" {
 		return moduleResult{name: moduleName, skipped: true}
 	}
 
-	return moduleResult{name: moduleName, tester: testerName, failed: failed}
+	return moduleResult{name: moduleName, tester: testerName, failed: failed, duration: time.Since(start)}
 }
 
 func (m *Manager) runTesters(modulePath, moduleName string) (bool, string) {
@@ -134,6 +225,14 @@ func (m *Manager) runTesters(modulePath, moduleName string) (bool, string) {
 	)
 
 	for _, t := range m.testers {
+		if m.errors.Stopped() {
+			break
+		}
+
+		if !m.filter.matchModule(t.Name(), moduleName) {
+			continue
+		}
+
 		lastTesterName = t.Name()
 
 		applicable := t.Run(modulePath)
@@ -162,18 +261,55 @@ func (m *Manager) runTesters(modulePath, moduleName string) (bool, string) {
 func (m *Manager) PrintResult() {
 	green := color.New(color.FgGreen).SprintFunc()
 
+	tested := 0
+
 	for _, result := range m.results {
 		if result.skipped {
 			continue
 		}
 
+		tested++
+
 		if result.failed {
-			fmt.Fprintf(os.Stderr, "❌ [%s] %s\n", result.tester, result.name)
+			fmt.Fprintf(os.Stderr, "❌ [%s] %s (%s)\n", result.tester, result.name, formatDuration(result.duration))
+			m.printModuleCases(os.Stderr, result.name)
 			m.printModuleErrors(result.name)
 		} else {
-			fmt.Printf("%s [%s] %s\n", green("✅"), result.tester, result.name)
+			fmt.Printf("%s [%s] %s (%s)\n", green("✅"), result.tester, result.name, formatDuration(result.duration))
+			m.printModuleCases(os.Stdout, result.name)
 		}
 	}
+
+	if tested == 0 && m.filter != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ No tests match --run\n")
+	}
+
+	if m.errors.Stopped() {
+		fmt.Fprintf(os.Stderr, "⚠️ Stopped after the first failure (--fail-fast)\n")
+	}
+}
+
+// printModuleCases prints the testcases run for the module with their
+// timings, by `<tester>/<module>/<case>` as selected by --run.
+func (m *Manager) printModuleCases(w io.Writer, moduleName string) {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	for _, c := range m.errors.GetCases() {
+		if c.ModuleID != moduleName {
+			continue
+		}
+
+		mark := green("✓")
+		if c.Failed {
+			mark = color.New(color.FgRed).Sprint("✗")
+		}
+
+		fmt.Fprintf(w, "    %s %s/%s/%s (%s)\n", mark, c.TestID, c.ModuleID, c.TestName, formatDuration(c.Duration))
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
 }
 
 func (m *Manager) printModuleErrors(moduleName string) {
@@ -245,6 +381,43 @@ func printErrorBody(w *tabwriter.Writer, err *pkg.TestError) {
 	if err.Got != "" {
 		fmt.Fprintf(w, "\t%s\t\t%s\n", "Got:", prepareString(strings.TrimRight(err.Got, "\n")))
 	}
+
+	if err.Diff != "" {
+		lines := strings.Split(strings.TrimRight(err.Diff, "\n"), "\n")
+
+		fmt.Fprintf(w, "\t%s\t\t%s\n", "Diff:", colorizeDiffLine(lines[0]))
+
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "\t\t\t%s\n", colorizeDiffLine(line))
+		}
+	}
+
+	if err.Output != "" {
+		lines := strings.Split(strings.TrimRight(err.Output, "\n"), "\n")
+
+		fmt.Fprintf(w, "\t%s\t\t%s\n", "Output:", lines[0])
+
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "\t\t\t%s\n", line)
+		}
+	}
+}
+
+// colorizeDiffLine colors a unified diff line: headers bold, hunk ranges cyan,
+// removals red and additions green.
+func colorizeDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		return color.New(color.Bold).Sprint(line)
+	case strings.HasPrefix(line, "@@"):
+		return color.New(color.FgCyan).Sprint(line)
+	case strings.HasPrefix(line, "-"):
+		return color.New(color.FgRed).Sprint(line)
+	case strings.HasPrefix(line, "+"):
+		return color.New(color.FgGreen).Sprint(line)
+	default:
+		return line
+	}
 }
 
 func (m *Manager) HasCriticalErrors() bool {
@@ -258,13 +431,21 @@ func (m *Manager) GetErrors() []pkg.TestError {
 	return m.errors.GetErrors()
 }
 
-func (m *Manager) registerTesters(options *managerOptions) {
+func (m *Manager) registerTesters(options *managerOptions, globalSchemas *values.GlobalSchemaResolver) {
 	all := []testers.Tester{
 		conversions.New(m.errors),
 		templatestester.New(m.errors, options.updateSnapshots),
+		prometheusrules.New(m.errors, globalSchemas),
+		hooks.New(m.errors, options.hooksTimeout),
+		openapivalidations.New(m.errors),
+		openapiexamples.New(m.errors, globalSchemas),
 	}
 
 	for _, t := range all {
+		if !m.filter.matchTester(t.Name()) {
+			continue
+		}
+
 		if options.enabled == nil || options.enabled[t.Name()] {
 			m.testers = append(m.testers, t)
 		}
@@ -41,11 +41,36 @@ import (
 
 const (
 	werfFileName = "werf.yaml"
+
+	// EnvVar selects the Deckhouse edition (.Env) werf.yaml is rendered for.
+	EnvVar = "WERF_ENV"
+	// defaultEnv is the edition werf.yaml is rendered for when WERF_ENV is unset.
+	defaultEnv = "EE"
 )
 
 var defaultWerfConfigTemplatesDirName = ".werf"
 
+// Env returns the edition selected through the WERF_ENV environment variable,
+// falling back to "EE" like the Deckhouse build does.
+func Env() string {
+	return cmp.Or(os.Getenv(EnvVar), defaultEnv)
+}
+
+// EditionEnv maps a module edition, as named by its openapi/values_<edition>.yaml
+// schema (e.g. "ce"), to the werf .Env value that edition is built with ("CE").
+func EditionEnv(edition string) string {
+	return strings.ToUpper(edition)
+}
+
+// GetWerfConfig renders the werf.yaml governing dir for the edition selected by
+// WERF_ENV (see Env).
 func GetWerfConfig(dir string) (string, error) {
+	return GetWerfConfigForEnv(dir, Env())
+}
+
+// GetWerfConfigForEnv renders the werf.yaml governing dir with .Env set to env,
+// so edition-conditional image definitions resolve as in that edition's build.
+func GetWerfConfigForEnv(dir, env string) (string, error) {
 	werfFile := getRootWerfFile(dir)
 	if werfFile == "" {
 		return "", nil
@@ -72,7 +97,7 @@ func GetWerfConfig(dir string) (string, error) {
 
 	templateData := make(map[string]any)
 	templateData["Files"] = NewFiles(werfFile, dir)
-	templateData["Env"] = cmp.Or(os.Getenv("WERF_ENV"), "EE")
+	templateData["Env"] = env
 
 	templateData["Commit"] = map[string]any{
 		"Hash": "hash",
@@ -146,3 +146,34 @@ func TestGetWerfConfigWithToYamlFromYamlRoundTrip(t *testing.T) {
 		t.Fatalf("GetWerfConfig() config = %q, want %q", config, "EE")
 	}
 }
+
+func TestGetWerfConfigForEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	werfConfig := `{{- if eq .Env "CE" }}ce-only{{ else }}other{{ end -}}`
+
+	err := os.WriteFile(filepath.Join(tmpDir, "werf.yaml"), []byte(werfConfig), 0o644)
+	if err != nil {
+		t.Fatalf("write werf.yaml: %v", err)
+	}
+
+	config, err := GetWerfConfigForEnv(tmpDir, EditionEnv("ce"))
+	if err != nil {
+		t.Fatalf("GetWerfConfigForEnv() error = %v", err)
+	}
+
+	if config != "ce-only" {
+		t.Fatalf("GetWerfConfigForEnv() config = %q, want %q", config, "ce-only")
+	}
+
+	t.Setenv("WERF_ENV", "CE")
+
+	config, err = GetWerfConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("GetWerfConfig() error = %v", err)
+	}
+
+	if config != "ce-only" {
+		t.Fatalf("GetWerfConfig() with WERF_ENV=CE config = %q, want %q", config, "ce-only")
+	}
+}
@@ -134,20 +134,27 @@ type TemplatesLinterConfig struct {
 	ExcludeRules              TemplatesExcludeRules
 	PrometheusRuleSettings    PrometheusRuleSettings
 	GrafanaDashboardsSettings GrafanaDashboardsSettings
+	NetworkPolicySettings     NetworkPolicySettings
+	ResourceRequestsSettings  ResourceRequestsSettings
 }
 type TemplatesLinterRules struct {
 	VPARule                  RuleConfig
 	PDBRule                  RuleConfig
+	HighAvailabilityRule     RuleConfig
 	IngressRule              RuleConfig
 	PrometheusRule           RuleConfig
 	GrafanaRule              RuleConfig
 	KubeRBACProxyRule        RuleConfig
 	ServicePortRule          RuleConfig
+	ServiceWiringRule        RuleConfig
+	NetworkPolicyRule        RuleConfig
+	ResourceRequestsRule     RuleConfig
 	ClusterDomainRule        RuleConfig
 	RegistryRule             RuleConfig
 	HTTPRouteRule            RuleConfig
 	EnabledModulesRule       RuleConfig
 	CRDEnabledModulesRule    RuleConfig
+	GlobalValuesRule         RuleConfig
 	WebhookConfigurationRule RuleConfig
 	MountPointsRule          RuleConfig
 	HelmRenderRule           RuleConfig
@@ -160,10 +167,33 @@ type PrometheusRuleSettings struct {
 type GrafanaDashboardsSettings struct {
 	Disable bool
 }
+
+type NetworkPolicySettings struct {
+	// RequireFullCoverage requires every pod controller and container port of
+	// the module to be covered by a NetworkPolicy.
+	RequireFullCoverage bool
+}
+
+// ResourceRequestsSettings are the bounds the CPU and memory requests of the
+// module containers must stay within.
+type ResourceRequestsSettings struct {
+	CPU    ResourceBounds
+	Memory ResourceBounds
+}
+
+// ResourceBounds are resource quantities; an empty value is not checked.
+type ResourceBounds struct {
+	Min string
+	Max string
+}
 type TemplatesExcludeRules struct {
 	VPAAbsent            KindRuleExcludeList
 	PDBAbsent            KindRuleExcludeList
+	HighAvailability     KindRuleExcludeList
 	ServicePort          ServicePortExcludeList
+	ServiceWiring        KindRuleExcludeList
+	NetworkPolicy        KindRuleExcludeList
+	ResourceRequests     KindRuleExcludeList
 	KubeRBACProxy        StringRuleExcludeList
 	Ingress              KindRuleExcludeList
 	HTTPRoute            KindRuleExcludeList
@@ -277,6 +307,51 @@ type ContainerLinterConfig struct {
 	LinterConfig
 	Rules        ContainerLinterRules
 	ExcludeRules ContainerExcludeRules
+	// ExternalReferences are the objects pod specs may reference although the
+	// module does not render them.
+	ExternalReferences []ExternalReference
+	ImagePolicy        ImagePolicySettings
+	ProbeCorrectness   ProbeCorrectnessSettings
+	// AllowedCapabilities are the capabilities containers of the module may
+	// add, each with the justification why.
+	AllowedCapabilities []AllowedCapability
+}
+
+type ExternalReference struct {
+	Kind string
+	Name string
+	// Namespace limits the reference to one namespace; empty matches any.
+	Namespace string
+}
+
+type AllowedCapability struct {
+	Capability    string
+	Kind          string
+	Name          string
+	Container     string
+	Justification string
+}
+
+// ProbeCorrectnessSettings are the bounds of the probe timings, in seconds; zero
+// keeps the default.
+type ProbeCorrectnessSettings struct {
+	// MinLivenessFailureSeconds is the shortest failure period after which a
+	// liveness probe may restart a container.
+	MinLivenessFailureSeconds int32
+	// MaxDetectionSeconds is the longest time a liveness or readiness probe
+	// may take to detect a failure.
+	MaxDetectionSeconds int32
+	// MaxStartupSeconds is the longest start a startupProbe may allow.
+	MaxStartupSeconds int32
+}
+
+type ImagePolicySettings struct {
+	// AllowedRegistries are the registry hosts or repository prefixes images
+	// may be hardcoded from instead of global.modulesImages.
+	AllowedRegistries []string
+	// RequireDigest requires images of the allowed registries to be pinned by
+	// digest too.
+	RequireDigest bool
 }
 
 type ImageLinterConfig struct {
@@ -339,6 +414,8 @@ type ContainerLinterRules struct {
 	DNSPolicyRule                 RuleConfig
 	ControllerSecurityContextRule RuleConfig
 	NewRevisionHistoryLimitRule   RuleConfig
+	PodSecurityStandardsRule      RuleConfig
+	ObjectReferencesRule          RuleConfig
 
 	// Container-specific rules
 	NameDuplicatesRule           RuleConfig
@@ -348,6 +425,7 @@ type ContainerLinterRules struct {
 	HostNetworkPortsRule         RuleConfig
 	EnvVariablesDuplicatesRule   RuleConfig
 	ImageDigestRule              RuleConfig
+	ImagePolicyRule              RuleConfig
 	ContainerImageNameRule       RuleConfig
 	ImagePullPolicyRule          RuleConfig
 	ResourcesRule                RuleConfig
@@ -355,6 +433,8 @@ type ContainerLinterRules struct {
 	PortsRule                    RuleConfig
 	LivenessRule                 RuleConfig
 	ReadinessRule                RuleConfig
+	ProbeCorrectnessRule         RuleConfig
+	CapabilitiesRule             RuleConfig
 	MountPointsRule              RuleConfig
 	SysCgroupMountRule           RuleConfig
 }
@@ -364,6 +444,8 @@ type ContainerExcludeRules struct {
 	NamespaceLabelsRule       KindRuleExcludeList
 	DNSPolicy                 KindRuleExcludeList
 	PriorityClass             KindRuleExcludeList
+	PodSecurityStandards      KindRuleExcludeList
+	ObjectReferences          KindRuleExcludeList
 
 	HostNetworkPorts       ContainerRuleExcludeList
 	Ports                  ContainerRuleExcludeList
@@ -371,11 +453,14 @@ type ContainerExcludeRules struct {
 	NoNewPrivileges        ContainerRuleExcludeList
 	SeccompProfile         ContainerRuleExcludeList
 	ImageDigest            ContainerRuleExcludeList
+	ImagePolicy            ContainerRuleExcludeList
 	ContainerImageName     ContainerRuleExcludeList
 	Resources              ContainerRuleExcludeList
 	SecurityContext        ContainerRuleExcludeList
 	Liveness               ContainerRuleExcludeList
 	Readiness              ContainerRuleExcludeList
+	ProbeCorrectness       ContainerRuleExcludeList
+	Capabilities           ContainerRuleExcludeList
 	SysCgroupMount         ContainerRuleExcludeList
 
 	Description StringRuleExcludeList
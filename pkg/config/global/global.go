@@ -52,6 +52,8 @@ type ContainerRules struct {
 	DNSPolicyRule                 RuleConfig `mapstructure:"dns-policy"`
 	ControllerSecurityContextRule RuleConfig `mapstructure:"controller-security-context"`
 	NewRevisionHistoryLimitRule   RuleConfig `mapstructure:"revision-history-limit"`
+	PodSecurityStandardsRule      RuleConfig `mapstructure:"pod-security-standards"`
+	ObjectReferencesRule          RuleConfig `mapstructure:"object-references"`
 
 	// Container-specific rules
 	NameDuplicatesRule           RuleConfig `mapstructure:"name-duplicates"`
@@ -61,12 +63,15 @@ type ContainerRules struct {
 	HostNetworkPortsRule         RuleConfig `mapstructure:"host-network-ports"`
 	EnvVariablesDuplicatesRule   RuleConfig `mapstructure:"env-variables-duplicates"`
 	ImageDigestRule              RuleConfig `mapstructure:"image-digest"`
+	ImagePolicyRule              RuleConfig `mapstructure:"image-policy"`
 	ImagePullPolicyRule          RuleConfig `mapstructure:"image-pull-policy"`
 	ResourcesRule                RuleConfig `mapstructure:"resources"`
 	ContainerSecurityContextRule RuleConfig `mapstructure:"container-security-context"`
 	PortsRule                    RuleConfig `mapstructure:"ports"`
 	LivenessRule                 RuleConfig `mapstructure:"liveness-probe"`
 	ReadinessRule                RuleConfig `mapstructure:"readiness-probe"`
+	ProbeCorrectnessRule         RuleConfig `mapstructure:"probe-correctness"`
+	CapabilitiesRule             RuleConfig `mapstructure:"capabilities"`
 	MountPointsRule              RuleConfig `mapstructure:"mount-points"`
 	SysCgroupMountRule           RuleConfig `mapstructure:"sys-cgroup-mount"`
 }
@@ -139,16 +144,21 @@ type TemplatesLinterConfig struct {
 type TemplatesLinterRules struct {
 	VPARule                  RuleConfig `mapstructure:"vpa"`
 	PDBRule                  RuleConfig `mapstructure:"pdb"`
+	HighAvailabilityRule     RuleConfig `mapstructure:"high-availability"`
 	IngressRule              RuleConfig `mapstructure:"ingress"`
 	HTTPRouteRule            RuleConfig `mapstructure:"httproute"`
 	PrometheusRule           RuleConfig `mapstructure:"prometheus-rules"`
 	GrafanaRule              RuleConfig `mapstructure:"grafana-dashboards"`
 	KubeRBACProxyRule        RuleConfig `mapstructure:"kube-rbac-proxy"`
 	ServicePortRule          RuleConfig `mapstructure:"service-port"`
+	ServiceWiringRule        RuleConfig `mapstructure:"service-wiring"`
+	NetworkPolicyRule        RuleConfig `mapstructure:"network-policy"`
+	ResourceRequestsRule     RuleConfig `mapstructure:"resource-requests"`
 	ClusterDomainRule        RuleConfig `mapstructure:"cluster-domain"`
 	RegistryRule             RuleConfig `mapstructure:"registry"`
 	EnabledModulesRule       RuleConfig `mapstructure:"enabled-modules"`
 	CRDEnabledModulesRule    RuleConfig `mapstructure:"crd-enabled-modules"`
+	GlobalValuesRule         RuleConfig `mapstructure:"global-values"`
 	WebhookConfigurationRule RuleConfig `mapstructure:"webhook-configuration-annotations"`
 	MountPointsRule          RuleConfig `mapstructure:"mount-points"`
 	HelmRenderRule           RuleConfig `mapstructure:"helm-render"`
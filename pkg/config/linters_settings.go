@@ -50,7 +50,11 @@ func (cfg *LintersSettings) MergeGlobal(lcfg *global.Linters) {
 }
 
 type ContainerSettings struct {
-	ExcludeRules ContainerExcludeRules `mapstructure:"exclude-rules"`
+	ExcludeRules        ContainerExcludeRules `mapstructure:"exclude-rules"`
+	ExternalReferences  ExternalReferenceList `mapstructure:"external-references"`
+	ImagePolicy         ImagePolicySettings   `mapstructure:"image-policy"`
+	ProbeCorrectness    ProbeCorrectness      `mapstructure:"probe-correctness"`
+	AllowedCapabilities AllowedCapabilityList `mapstructure:"allowed-capabilities"`
 
 	Impact string `mapstructure:"impact"`
 }
@@ -60,6 +64,8 @@ type ContainerExcludeRules struct {
 	NamespaceLabelsRule       KindRuleExcludeList `mapstructure:"object-namespace-labels"`
 	DNSPolicy                 KindRuleExcludeList `mapstructure:"dns-policy"`
 	PriorityClass             KindRuleExcludeList `mapstructure:"priority-class"`
+	PodSecurityStandards      KindRuleExcludeList `mapstructure:"pod-security-standards"`
+	ObjectReferences          KindRuleExcludeList `mapstructure:"object-references"`
 
 	HostNetworkPorts       ContainerRuleExcludeList `mapstructure:"host-network-ports"`
 	Ports                  ContainerRuleExcludeList `mapstructure:"ports"`
@@ -67,10 +73,13 @@ type ContainerExcludeRules struct {
 	NoNewPrivileges        ContainerRuleExcludeList `mapstructure:"no-new-privileges"`
 	SeccompProfile         ContainerRuleExcludeList `mapstructure:"seccomp-profile"`
 	ImageDigest            ContainerRuleExcludeList `mapstructure:"image-digest"`
+	ImagePolicy            ContainerRuleExcludeList `mapstructure:"image-policy"`
 	Resources              ContainerRuleExcludeList `mapstructure:"resources"`
 	SecurityContext        ContainerRuleExcludeList `mapstructure:"security-context"`
 	Liveness               ContainerRuleExcludeList `mapstructure:"liveness-probe"`
 	Readiness              ContainerRuleExcludeList `mapstructure:"readiness-probe"`
+	ProbeCorrectness       ContainerRuleExcludeList `mapstructure:"probe-correctness"`
+	Capabilities           ContainerRuleExcludeList `mapstructure:"capabilities"`
 	SysCgroupMount         ContainerRuleExcludeList `mapstructure:"sys-cgroup-mount"`
 
 	Description StringRuleExcludeList `mapstructure:"description"`
@@ -216,6 +225,8 @@ type TemplatesSettings struct {
 	ExcludeRules      TemplatesExcludeRules        `mapstructure:"exclude-rules"`
 	GrafanaDashboards GrafanaDashboardsExcludeList `mapstructure:"grafana-dashboards"`
 	PrometheusRules   PrometheusRulesExcludeList   `mapstructure:"prometheus-rules"`
+	NetworkPolicies   NetworkPolicySettings        `mapstructure:"network-policies"`
+	ResourceRequests  ResourceRequestsSettings     `mapstructure:"resource-requests"`
 	Rules             TemplatesLinterRules         `mapstructure:"rules"`
 
 	Impact string `mapstructure:"impact"`
@@ -224,16 +235,21 @@ type TemplatesSettings struct {
 type TemplatesLinterRules struct {
 	VPARule                  RuleConfig `mapstructure:"vpa"`
 	PDBRule                  RuleConfig `mapstructure:"pdb"`
+	HighAvailabilityRule     RuleConfig `mapstructure:"high-availability"`
 	IngressRule              RuleConfig `mapstructure:"ingress"`
 	HTTPRouteRule            RuleConfig `mapstructure:"httproute"`
 	PrometheusRule           RuleConfig `mapstructure:"prometheus-rules"`
 	GrafanaRule              RuleConfig `mapstructure:"grafana-dashboards"`
 	KubeRBACProxyRule        RuleConfig `mapstructure:"kube-rbac-proxy"`
 	ServicePortRule          RuleConfig `mapstructure:"service-port"`
+	ServiceWiringRule        RuleConfig `mapstructure:"service-wiring"`
+	NetworkPolicyRule        RuleConfig `mapstructure:"network-policy"`
+	ResourceRequestsRule     RuleConfig `mapstructure:"resource-requests"`
 	ClusterDomainRule        RuleConfig `mapstructure:"cluster-domain"`
 	RegistryRule             RuleConfig `mapstructure:"registry"`
 	EnabledModulesRule       RuleConfig `mapstructure:"enabled-modules"`
 	CRDEnabledModulesRule    RuleConfig `mapstructure:"crd-enabled-modules"`
+	GlobalValuesRule         RuleConfig `mapstructure:"global-values"`
 	WebhookConfigurationRule RuleConfig `mapstructure:"webhook-configuration-annotations"`
 	MountPointsRule          RuleConfig `mapstructure:"mount-points"`
 	HelmRenderRule           RuleConfig `mapstructure:"helm-render"`
@@ -242,7 +258,11 @@ type TemplatesLinterRules struct {
 type TemplatesExcludeRules struct {
 	VPAAbsent            KindRuleExcludeList       `mapstructure:"vpa"`
 	PDBAbsent            KindRuleExcludeList       `mapstructure:"pdb"`
+	HighAvailability     KindRuleExcludeList       `mapstructure:"high-availability"`
 	ServicePort          ServicePortExcludeList    `mapstructure:"service-port"`
+	ServiceWiring        KindRuleExcludeList       `mapstructure:"service-wiring"`
+	NetworkPolicy        KindRuleExcludeList       `mapstructure:"network-policy"`
+	ResourceRequests     KindRuleExcludeList       `mapstructure:"resource-requests"`
 	KubeRBACProxy        StringRuleExcludeList     `mapstructure:"kube-rbac-proxy"`
 	Ingress              KindRuleExcludeList       `mapstructure:"ingress"`
 	HTTPRoute            KindRuleExcludeList       `mapstructure:"httproute"`
@@ -264,6 +284,27 @@ type PrometheusRulesExcludeList struct {
 	Disable bool `mapstructure:"disable"`
 }
 
+type NetworkPolicySettings struct {
+	RequireFullCoverage bool `mapstructure:"require-full-coverage"`
+}
+
+type ResourceRequestsSettings struct {
+	CPU    ResourceBounds `mapstructure:"cpu"`
+	Memory ResourceBounds `mapstructure:"memory"`
+}
+
+func (s ResourceRequestsSettings) Get() pkg.ResourceRequestsSettings {
+	return pkg.ResourceRequestsSettings{
+		CPU:    pkg.ResourceBounds{Min: s.CPU.Min, Max: s.CPU.Max},
+		Memory: pkg.ResourceBounds{Min: s.Memory.Min, Max: s.Memory.Max},
+	}
+}
+
+type ResourceBounds struct {
+	Min string `mapstructure:"min"`
+	Max string `mapstructure:"max"`
+}
+
 type ServicePortExcludeList []ServicePortExclude
 
 func (l ServicePortExcludeList) Get() []pkg.ServicePortExclude {
@@ -336,6 +377,73 @@ func (l ContainerRuleExcludeList) Get() []pkg.ContainerRuleExclude {
 	return result
 }
 
+type ExternalReferenceList []ExternalReference
+
+func (l ExternalReferenceList) Get() []pkg.ExternalReference {
+	result := make([]pkg.ExternalReference, 0, len(l))
+
+	for idx := range l {
+		result = append(result, pkg.ExternalReference{Kind: l[idx].Kind, Name: l[idx].Name, Namespace: l[idx].Namespace})
+	}
+
+	return result
+}
+
+type ExternalReference struct {
+	Kind      string `mapstructure:"kind"`
+	Name      string `mapstructure:"name"`
+	Namespace string `mapstructure:"namespace"`
+}
+
+type AllowedCapabilityList []AllowedCapability
+
+func (l AllowedCapabilityList) Get() []pkg.AllowedCapability {
+	result := make([]pkg.AllowedCapability, 0, len(l))
+
+	for idx := range l {
+		result = append(result, pkg.AllowedCapability{
+			Capability:    l[idx].Capability,
+			Kind:          l[idx].Kind,
+			Name:          l[idx].Name,
+			Container:     l[idx].Container,
+			Justification: l[idx].Justification,
+		})
+	}
+
+	return result
+}
+
+type AllowedCapability struct {
+	Capability    string `mapstructure:"capability"`
+	Kind          string `mapstructure:"kind"`
+	Name          string `mapstructure:"name"`
+	Container     string `mapstructure:"container"`
+	Justification string `mapstructure:"justification"`
+}
+
+type ProbeCorrectness struct {
+	MinLivenessFailureSeconds int32 `mapstructure:"min-liveness-failure-seconds"`
+	MaxDetectionSeconds       int32 `mapstructure:"max-detection-seconds"`
+	MaxStartupSeconds         int32 `mapstructure:"max-startup-seconds"`
+}
+
+func (s ProbeCorrectness) Get() pkg.ProbeCorrectnessSettings {
+	return pkg.ProbeCorrectnessSettings{
+		MinLivenessFailureSeconds: s.MinLivenessFailureSeconds,
+		MaxDetectionSeconds:       s.MaxDetectionSeconds,
+		MaxStartupSeconds:         s.MaxStartupSeconds,
+	}
+}
+
+type ImagePolicySettings struct {
+	AllowedRegistries []string `mapstructure:"allowed-registries"`
+	RequireDigest     bool     `mapstructure:"require-digest"`
+}
+
+func (s ImagePolicySettings) Get() pkg.ImagePolicySettings {
+	return pkg.ImagePolicySettings{AllowedRegistries: s.AllowedRegistries, RequireDigest: s.RequireDigest}
+}
+
 type KindRuleExclude struct {
 	Kind string `mapstructure:"kind"`
 	Name string `mapstructure:"name"`
@@ -16,6 +16,8 @@ limitations under the License.
 
 package pkg
 
+import "time"
+
 type LinterError struct {
 	LinterID    string
 	ModuleID    string
@@ -27,6 +29,11 @@ type LinterError struct {
 	LineNumber  int
 	Level       Level
 
+	// Edition is the module edition (openapi/values_<edition>.yaml) whose render
+	// produced the finding; empty for the default render. Findings reported for
+	// several editions carry a comma-separated list.
+	Edition string
+
 	// FixError is set when this finding carried an automatic fix that was run
 	// under --fix but failed. The finding is still reported as unresolved.
 	FixError error
@@ -43,4 +50,15 @@ type TestError struct {
 	TestName string // e.g., "should delete auth.password on 1 to 2"
 	Got      string // actual conversion result (YAML)
 	Expected string // expected conversion result (YAML)
+	Diff     string // unified diff of expected vs. actual, shown instead of Got/Expected
+	Output   string // verbatim output of the failed test (e.g. `go test` log)
+}
+
+// TestCaseResult is the outcome of a single testcase run by a tester.
+type TestCaseResult struct {
+	TestID   string
+	ModuleID string
+	TestName string
+	Duration time.Duration
+	Failed   bool
 }
@@ -38,6 +38,7 @@ type lintRuleError struct {
 	Text        string
 	FilePath    string
 	LineNumber  int
+	Edition     string
 	Level       pkg.Level
 	Fixed       bool
 	FixError    error
@@ -51,7 +52,8 @@ func (l *lintRuleError) EqualsTo(candidate lintRuleError) bool { //nolint:gocrit
 	return l.LinterID == candidate.LinterID &&
 		l.Text == candidate.Text &&
 		l.ObjectID == candidate.ObjectID &&
-		l.ModuleID == candidate.ModuleID
+		l.ModuleID == candidate.ModuleID &&
+		l.Edition == candidate.Edition
 }
 
 type errStorage struct {
@@ -97,6 +99,7 @@ type LintRuleErrorsList struct {
 	value      any
 	filePath   string
 	lineNumber int
+	edition    string
 	fix        AutofixFunc
 
 	maxLevel *pkg.Level
@@ -130,6 +133,12 @@ func (l *LintRuleErrorsList) WithMaxLevel(level *pkg.Level) *LintRuleErrorsList
 	return list
 }
 
+// Ignored reports whether the findings added to the list are all downgraded to
+// pkg.Ignored, so a rule can skip work nobody would see the result of.
+func (l *LintRuleErrorsList) Ignored() bool {
+	return l.maxLevel != nil && *l.maxLevel == pkg.Ignored
+}
+
 func (l *LintRuleErrorsList) WithLinterID(linterID string) *LintRuleErrorsList {
 	list := l.copy()
 	list.linterID = linterID
@@ -179,6 +188,15 @@ func (l *LintRuleErrorsList) WithLineNumber(lineNumber int) *LintRuleErrorsList
 	return list
 }
 
+// WithEdition tags every finding emitted through the list with the module
+// edition (e.g. "ce") whose render produced it.
+func (l *LintRuleErrorsList) WithEdition(edition string) *LintRuleErrorsList {
+	list := l.copy()
+	list.edition = edition
+
+	return list
+}
+
 // WithFix attaches an automatic fix to the next emitted finding.
 // Findings that carry a fix are resolved through GetFixes when dmt runs with --fix.
 func (l *LintRuleErrorsList) WithFix(fix AutofixFunc) *LintRuleErrorsList {
@@ -221,6 +239,7 @@ func (l *LintRuleErrorsList) add(str string, level pkg.Level) *LintRuleErrorsLis
 		ObjectValue: l.value,
 		FilePath:    l.filePath,
 		LineNumber:  l.lineNumber,
+		Edition:     l.edition,
 		Text:        str,
 		Level:       level,
 		fix:         l.fix,
@@ -236,10 +255,12 @@ func (l *LintRuleErrorsList) GetErrors() []pkg.LinterError {
 }
 
 // GetFixes returns one closure per collected finding that carries an automatic
-// fix; findings without a fix are skipped. Each closure runs its fix and records
-// the outcome back onto the stored finding: on success the finding is marked
-// Fixed (so GetErrors drops it), on failure the error is stored in FixError (so
-// GetErrors reports that the autofix could not be applied).
+// fix; findings without a fix are skipped. The same finding reported by the
+// default render and by the per-edition renders is fixed once: its copies share
+// one closure. Each closure runs its fix and records the outcome back onto every
+// copy of the finding: on success they are marked Fixed (so GetErrors drops
+// them), on failure the error is stored in FixError (so GetErrors reports that
+// the autofix could not be applied).
 //
 // The closures are returned rather than executed so the caller — Manager.ApplyFixes,
 // the single --fix entry point — controls when and in what order they run.
@@ -251,31 +272,97 @@ func (l *LintRuleErrorsList) GetFixes() []func() {
 	l.storage.mu.Lock()
 	defer l.storage.mu.Unlock()
 
-	var fixes []func()
+	var groups [][]int
+
+	groupIdx := make(map[fixKey]int)
 
 	for idx := range l.storage.errList {
 		if l.storage.errList[idx].fix == nil {
 			continue
 		}
 
+		key := newFixKey(&l.storage.errList[idx])
+		if pos, ok := groupIdx[key]; ok {
+			groups[pos] = append(groups[pos], idx)
+
+			continue
+		}
+
+		groupIdx[key] = len(groups)
+		groups = append(groups, []int{idx})
+	}
+
+	fixes := make([]func(), 0, len(groups))
+
+	for _, group := range groups {
 		fixes = append(fixes, func() {
 			l.storage.mu.Lock()
 			defer l.storage.mu.Unlock()
 
-			e := &l.storage.errList[idx]
+			err := l.storage.errList[group[0]].fix()
 
-			if err := e.fix(); err != nil {
-				e.FixError = err
-				return
-			}
+			for _, idx := range group {
+				e := &l.storage.errList[idx]
+				if err != nil {
+					e.FixError = err
+
+					continue
+				}
 
-			e.Fixed = true
+				e.Fixed = true
+			}
 		})
 	}
 
 	return fixes
 }
 
+// fixKey identifies a finding independently of the edition it was reported for.
+type fixKey struct {
+	linterID, moduleID, ruleID, objectID, text, filePath string
+	lineNumber                                           int
+}
+
+func newFixKey(e *lintRuleError) fixKey {
+	return fixKey{
+		linterID:   e.LinterID,
+		moduleID:   e.ModuleID,
+		ruleID:     e.RuleID,
+		objectID:   e.ObjectID,
+		text:       e.Text,
+		filePath:   e.FilePath,
+		lineNumber: e.LineNumber,
+	}
+}
+
+// Locate sets the line number of the module's findings reported for an object
+// without one. locate maps the finding's object ID to the object's file path
+// and template line (0 when unknown); findings reported for another file keep
+// their location.
+func (l *LintRuleErrorsList) Locate(moduleID, edition string, locate func(objectID string) (string, int)) {
+	if l.storage == nil {
+		return
+	}
+
+	l.storage.mu.Lock()
+	defer l.storage.mu.Unlock()
+
+	for idx := range l.storage.errList {
+		e := &l.storage.errList[idx]
+		if e.ModuleID != moduleID || e.Edition != edition || e.ObjectID == "" || e.LineNumber != 0 {
+			continue
+		}
+
+		filePath, line := locate(e.ObjectID)
+		if line == 0 || e.FilePath != "" && e.FilePath != filePath {
+			continue
+		}
+
+		e.FilePath = filePath
+		e.LineNumber = line
+	}
+}
+
 func (l *LintRuleErrorsList) ContainsErrors() bool {
 	if l.storage == nil {
 		l.storage = &errStorage{}
@@ -313,6 +400,7 @@ func remapErrorToLinterError(err *lintRuleError) *pkg.LinterError {
 		ObjectValue: err.ObjectValue,
 		FilePath:    err.FilePath,
 		LineNumber:  err.LineNumber,
+		Edition:     err.Edition,
 		Text:        err.Text,
 		Level:       err.Level,
 		FixError:    err.FixError,
@@ -19,6 +19,7 @@ package errors
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/deckhouse/dmt/pkg"
 )
@@ -32,11 +33,35 @@ type TestErrorsList struct {
 	testName string
 }
 
-func NewTestErrorsList() *TestErrorsList {
+// TestErrorsListOption configures how a TestErrorsList runs testcases.
+type TestErrorsListOption func(*testErrStorage)
+
+// WithCaseFilter makes RunCase skip the testcases for which selectCase returns
+// false. selectCase gets the test group, module and testcase name.
+func WithCaseFilter(selectCase func(group, moduleID, testName string) bool) TestErrorsListOption {
+	return func(s *testErrStorage) {
+		s.selectCase = selectCase
+	}
+}
+
+// WithFailFast makes RunCase skip all testcases once an error is reported.
+func WithFailFast(failFast bool) TestErrorsListOption {
+	return func(s *testErrStorage) {
+		s.failFast = failFast
+	}
+}
+
+func NewTestErrorsList(opts ...TestErrorsListOption) *TestErrorsList {
+	storage := &testErrStorage{
+		errList: make([]pkg.TestError, 0),
+	}
+
+	for _, opt := range opts {
+		opt(storage)
+	}
+
 	return &TestErrorsList{
-		storage: &testErrStorage{
-			errList: make([]pkg.TestError, 0),
-		},
+		storage: storage,
 	}
 }
 
@@ -71,6 +96,59 @@ func (l *TestErrorsList) WithTestName(testName string) *TestErrorsList {
 	return list
 }
 
+// RunCase runs the testcase name of the list's group and module, passing run
+// the list scoped to the testcase, and records its duration and outcome. The
+// testcase is skipped when the case filter does not select it or when an
+// error has stopped the run (see WithFailFast).
+func (l *TestErrorsList) RunCase(name string, run func(errorList *TestErrorsList)) {
+	l.runCase(name, nil, run)
+}
+
+// RecordCase is RunCase for a testcase that ran outside dmt, e.g. a Go test run
+// by a `go test` process: run only reports the testcase's errors, and elapsed is
+// recorded as the testcase's duration.
+func (l *TestErrorsList) RecordCase(name string, elapsed time.Duration, run func(errorList *TestErrorsList)) {
+	l.runCase(name, &elapsed, run)
+}
+
+// runCase runs a testcase; a nil elapsed means the duration of run.
+func (l *TestErrorsList) runCase(name string, elapsed *time.Duration, run func(errorList *TestErrorsList)) {
+	if l.storage == nil {
+		l.storage = &testErrStorage{}
+	}
+
+	group := strings.ToLower(l.group)
+
+	if l.Stopped() || (l.storage.selectCase != nil && !l.storage.selectCase(group, l.moduleID, name)) {
+		return
+	}
+
+	// A module's testcases of a group run sequentially, so the errors added
+	// meanwhile for the group and module belong to this testcase.
+	before := l.storage.countErrors(group, l.moduleID)
+	start := time.Now()
+
+	run(l.WithTestName(name))
+
+	duration := time.Since(start)
+	if elapsed != nil {
+		duration = *elapsed
+	}
+
+	l.storage.addCase(&pkg.TestCaseResult{
+		TestID:   group,
+		ModuleID: l.moduleID,
+		TestName: name,
+		Duration: duration,
+		Failed:   l.storage.countErrors(group, l.moduleID) > before,
+	})
+}
+
+// Stopped reports whether an error has stopped the run (see WithFailFast).
+func (l *TestErrorsList) Stopped() bool {
+	return l.storage != nil && l.storage.stopped.Load()
+}
+
 func (l *TestErrorsList) Error(str string) *TestErrorsList {
 	return l.add(str, pkg.Error)
 }
@@ -100,6 +178,48 @@ func (l *TestErrorsList) AddTestResult(text, got, expected string) *TestErrorsLi
 	return l
 }
 
+// AddTestDiff adds a structured test failure described by a unified diff of
+// the expected and the actual output.
+func (l *TestErrorsList) AddTestDiff(text, diff string) *TestErrorsList {
+	if l.storage == nil {
+		l.storage = &testErrStorage{}
+	}
+
+	e := pkg.TestError{
+		TestID:   strings.ToLower(l.group),
+		ModuleID: l.moduleID,
+		TestName: l.testName,
+		Text:     text,
+		Level:    pkg.Error,
+		Diff:     diff,
+	}
+
+	l.storage.add(&e)
+
+	return l
+}
+
+// AddTestOutput adds a test failure together with the verbatim output of the
+// failed test.
+func (l *TestErrorsList) AddTestOutput(text, output string) *TestErrorsList {
+	if l.storage == nil {
+		l.storage = &testErrStorage{}
+	}
+
+	e := pkg.TestError{
+		TestID:   strings.ToLower(l.group),
+		ModuleID: l.moduleID,
+		TestName: l.testName,
+		Text:     text,
+		Level:    pkg.Error,
+		Output:   output,
+	}
+
+	l.storage.add(&e)
+
+	return l
+}
+
 func (l *TestErrorsList) add(str string, level pkg.Level) *TestErrorsList {
 	if l.storage == nil {
 		l.storage = &testErrStorage{}
@@ -122,6 +242,11 @@ func (l *TestErrorsList) GetErrors() []pkg.TestError {
 	return l.storage.GetErrors()
 }
 
+// GetCases returns the results of the testcases run through RunCase.
+func (l *TestErrorsList) GetCases() []pkg.TestCaseResult {
+	return l.storage.GetCases()
+}
+
 func (l *TestErrorsList) ContainsErrors() bool {
 	if l.storage == nil {
 		l.storage = &testErrStorage{}
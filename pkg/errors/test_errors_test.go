@@ -0,0 +1,103 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TestErrorsRunCase(t *testing.T) {
+	list := NewTestErrorsList(WithCaseFilter(func(group, moduleID, testName string) bool {
+		return group == "templates" && moduleID == "moduleID" && testName != "skipped"
+	})).WithTestGroup("Templates").WithModule("moduleID")
+
+	var ran []string
+
+	run := func(name string, fail bool) {
+		list.RunCase(name, func(caseErrors *TestErrorsList) {
+			ran = append(ran, name)
+
+			require.Equal(t, name, caseErrors.testName)
+
+			if fail {
+				caseErrors.Error("failed")
+			}
+		})
+	}
+
+	run("passed", false)
+	run("skipped", true)
+	run("failed", true)
+
+	require.Equal(t, []string{"passed", "failed"}, ran)
+
+	cases := list.GetCases()
+	require.Len(t, cases, 2)
+	require.Equal(t, "templates", cases[0].TestID)
+	require.Equal(t, "moduleID", cases[0].ModuleID)
+	require.Equal(t, "passed", cases[0].TestName)
+	require.False(t, cases[0].Failed)
+	require.Equal(t, "failed", cases[1].TestName)
+	require.True(t, cases[1].Failed)
+
+	errs := list.GetErrors()
+	require.Len(t, errs, 1)
+	require.Equal(t, "failed", errs[0].TestName)
+	require.False(t, list.Stopped())
+}
+
+func Test_TestErrorsFailFast(t *testing.T) {
+	list := NewTestErrorsList(WithFailFast(true)).WithTestGroup("conversions").WithModule("moduleID")
+
+	ran := 0
+
+	for range 3 {
+		list.RunCase("case", func(caseErrors *TestErrorsList) {
+			ran++
+
+			caseErrors.Error("failed")
+		})
+	}
+
+	require.Equal(t, 1, ran)
+	require.True(t, list.Stopped())
+	require.Len(t, list.GetCases(), 1)
+}
+
+func Test_TestErrorsRecordCase(t *testing.T) {
+	list := NewTestErrorsList().WithTestGroup("hooks").WithModule("moduleID")
+
+	list.RecordCase("pkg.TestA", 1500*time.Millisecond, func(caseErrors *TestErrorsList) {
+		caseErrors.Error("failed")
+	})
+	list.RecordCase("pkg.TestB", 0, func(*TestErrorsList) {})
+
+	cases := list.GetCases()
+	require.Len(t, cases, 2)
+	require.Equal(t, "pkg.TestA", cases[0].TestName)
+	require.Equal(t, 1500*time.Millisecond, cases[0].Duration)
+	require.True(t, cases[0].Failed)
+	require.Equal(t, time.Duration(0), cases[1].Duration)
+	require.False(t, cases[1].Failed)
+
+	errs := list.GetErrors()
+	require.Len(t, errs, 1)
+	require.Equal(t, "pkg.TestA", errs[0].TestName)
+}
@@ -18,6 +18,7 @@ package errors
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/deckhouse/dmt/pkg"
 )
@@ -25,6 +26,12 @@ import (
 type testErrStorage struct {
 	mu      sync.Mutex
 	errList []pkg.TestError
+	cases   []pkg.TestCaseResult
+
+	// selectCase filters the testcases run through RunCase; nil runs all.
+	selectCase func(group, moduleID, testName string) bool
+	failFast   bool
+	stopped    atomic.Bool
 }
 
 func (s *testErrStorage) GetErrors() []pkg.TestError {
@@ -41,4 +48,40 @@ func (s *testErrStorage) add(err *pkg.TestError) {
 	s.mu.Lock()
 	s.errList = append(s.errList, *err)
 	s.mu.Unlock()
+
+	if s.failFast && err.Level == pkg.Error {
+		s.stopped.Store(true)
+	}
+}
+
+// countErrors returns the number of errors reported by the group for the module.
+func (s *testErrStorage) countErrors(group, moduleID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+
+	for idx := range s.errList {
+		if s.errList[idx].TestID == group && s.errList[idx].ModuleID == moduleID && s.errList[idx].Level == pkg.Error {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s *testErrStorage) addCase(result *pkg.TestCaseResult) {
+	s.mu.Lock()
+	s.cases = append(s.cases, *result)
+	s.mu.Unlock()
+}
+
+func (s *testErrStorage) GetCases() []pkg.TestCaseResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]pkg.TestCaseResult, 0, len(s.cases))
+	result = append(result, s.cases...)
+
+	return result
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/deckhouse/dmt/internal/modules"
 	"github.com/deckhouse/dmt/pkg"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters/container/rules"
 )
 
 const (
@@ -57,6 +58,11 @@ func (l *Container) Run(m *modules.Module) {
 	for _, object := range storage {
 		l.applyContainerRules(object, storage, errorList)
 	}
+
+	rules.NewImagePolicyRule(l.cfg.ExcludeRules.ImagePolicy.Get(), l.cfg.ImagePolicy).
+		ModuleImageReferences(l.modulePath, errorList.WithMaxLevel(l.cfg.Rules.ImagePolicyRule.GetLevel()))
+	rules.NewCapabilitiesRule(l.cfg.ExcludeRules.Capabilities.Get(), l.cfg.AllowedCapabilities).
+		AllowedCapabilitiesJustified(errorList.WithMaxLevel(l.cfg.Rules.CapabilitiesRule.GetLevel()))
 }
 
 func (l *Container) Name() string {
@@ -37,6 +37,14 @@ func (l *Container) applyContainerRules(object storage.StoreObject, storageMap m
 	rules.NewControllerSecurityContextRule(l.cfg.ExcludeRules.ControllerSecurityContext.Get()).
 		ControllerSecurityContext(object, errorList.WithMaxLevel(l.cfg.Rules.ControllerSecurityContextRule.GetLevel()))
 	rules.NewRevisionHistoryLimitRule().ObjectRevisionHistoryLimit(object, errorList.WithMaxLevel(l.cfg.Rules.NewRevisionHistoryLimitRule.GetLevel()))
+	rules.NewPodSecurityStandardsRule(l.cfg.ExcludeRules.PodSecurityStandards.Get()).
+		ObjectPodSecurityStandards(object, storageMap, errorList.WithMaxLevel(l.cfg.Rules.PodSecurityStandardsRule.GetLevel()))
+	rules.NewObjectReferencesRule(l.cfg.ExcludeRules.ObjectReferences.Get(), l.cfg.ExternalReferences).
+		ObjectReferences(object, storageMap, errorList.WithMaxLevel(l.cfg.Rules.ObjectReferencesRule.GetLevel()))
+	rules.NewProbeCorrectnessRule(l.cfg.ExcludeRules.ProbeCorrectness.Get(), l.cfg.ProbeCorrectness).
+		CheckProbeCorrectness(object, errorList.WithMaxLevel(l.cfg.Rules.ProbeCorrectnessRule.GetLevel()))
+	rules.NewCapabilitiesRule(l.cfg.ExcludeRules.Capabilities.Get(), l.cfg.AllowedCapabilities).
+		ContainerCapabilities(object, errorList.WithMaxLevel(l.cfg.Rules.CapabilitiesRule.GetLevel()))
 
 	allContainers, err := object.GetAllContainers()
 	if err != nil {
@@ -73,7 +81,12 @@ func (l *Container) applyContainerRules(object storage.StoreObject, storageMap m
 			rules.NewEnvVariablesDuplicatesRule().ContainerEnvVariablesDuplicates(object, containers, errorList.WithMaxLevel(l.cfg.Rules.EnvVariablesDuplicatesRule.GetLevel()))
 		},
 		func(object storage.StoreObject, containers []corev1.Container, errorList *errors.LintRuleErrorsList) {
-			rules.NewImageDigestRule(l.cfg.ExcludeRules.ImageDigest.Get()).ContainerImageDigestCheck(object, containers, errorList.WithMaxLevel(l.cfg.Rules.ImageDigestRule.GetLevel()))
+			rules.NewImageDigestRule(l.cfg.ExcludeRules.ImageDigest.Get()).
+				ContainerImageDigestCheck(object, containers, errorList.WithMaxLevel(l.cfg.Rules.ImageDigestRule.GetLevel()))
+		},
+		func(object storage.StoreObject, containers []corev1.Container, errorList *errors.LintRuleErrorsList) {
+			rules.NewImagePolicyRule(l.cfg.ExcludeRules.ImagePolicy.Get(), l.cfg.ImagePolicy).
+				ContainerImagePolicy(object, containers, errorList.WithMaxLevel(l.cfg.Rules.ImagePolicyRule.GetLevel()))
 		},
 		func(object storage.StoreObject, containers []corev1.Container, errorList *errors.LintRuleErrorsList) {
 			rules.NewContainerImageNameRule(l.cfg.ExcludeRules.ContainerImageName.Get()).ContainerImageNameCheck(object, containers, errorList.WithMaxLevel(l.cfg.Rules.ContainerImageNameRule.GetLevel()))
@@ -0,0 +1,252 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	CapabilitiesRuleName = "capabilities"
+)
+
+// dangerousCapabilities are the capabilities reported with the reason they are
+// dangerous when added without being allowed.
+var dangerousCapabilities = map[string]string{
+	"SYS_ADMIN": "is nearly equivalent to root on the node",
+	"NET_RAW":   "allows crafting raw packets and spoofing traffic",
+}
+
+// sensitiveHostPaths are the host paths a hostPath volume must not mount. The
+// paths ending with a slash also cover everything below them.
+var sensitiveHostPaths = []string{
+	"/",
+	"/etc/",
+	"/var/run/docker.sock",
+	"/run/docker.sock",
+	"/run/containerd/containerd.sock",
+}
+
+func NewCapabilitiesRule(excludeRules []pkg.ContainerRuleExclude, allowedCapabilities []pkg.AllowedCapability) *CapabilitiesRule {
+	return &CapabilitiesRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: CapabilitiesRuleName,
+		},
+		ContainerRule: pkg.ContainerRule{
+			ExcludeRules: excludeRules,
+		},
+		allowedCapabilities: allowedCapabilities,
+	}
+}
+
+type CapabilitiesRule struct {
+	pkg.RuleMeta
+	pkg.ContainerRule
+
+	allowedCapabilities []pkg.AllowedCapability
+}
+
+// privilegedFeature is a privilege a pod spec requests. Container is empty for
+// the pod-level features, Capability is set for added capabilities and Volume
+// for hostPath volumes.
+type privilegedFeature struct {
+	Container  string
+	Feature    string
+	Capability string
+	Volume     string
+}
+
+// ContainerCapabilities checks that every container drops ALL capabilities and
+// adds only the capabilities allowed for the module, and reports privileged
+// containers, the host PID and IPC namespaces and hostPath volumes of sensitive
+// host paths.
+func (r *CapabilitiesRule) ContainerCapabilities(object storage.StoreObject, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	if !isSecurityContextSupportedKind(object.Unstructured.GetKind()) {
+		return
+	}
+
+	podSpec, err := object.GetPodSpec()
+	if err != nil {
+		errorList.WithObjectID(object.Identity()).
+			Errorf("GetPodSpec failed: %v", err)
+
+		return
+	}
+
+	if podSpec == nil {
+		return
+	}
+
+	for _, c := range podContainers(podSpec) {
+		if !r.Enabled(object, &c) {
+			continue
+		}
+
+		sc := c.SecurityContext
+		if sc == nil || sc.Capabilities == nil || !slices.Contains(sc.Capabilities.Drop, "ALL") {
+			errorList.WithObjectID(object.Identity() + " ; container = " + c.Name).
+				Error("Container does not drop ALL capabilities")
+		}
+	}
+
+	for _, feature := range privilegedFeatures(podSpec) {
+		objectID := object.Identity()
+		if feature.Container != "" {
+			objectID += " ; container = " + feature.Container
+		}
+
+		if !r.Enabled(object, &corev1.Container{Name: feature.Container}) {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(objectID)
+
+		switch {
+		case feature.Capability != "":
+			if r.isAllowed(object, feature.Container, feature.Capability) {
+				continue
+			}
+
+			if reason, ok := dangerousCapabilities[feature.Capability]; ok {
+				errorListObj.WithValue(feature.Capability).
+					Errorf("Container adds the capability %q, which %s: allow it in allowed-capabilities with a justification", feature.Capability, reason)
+
+				continue
+			}
+
+			errorListObj.WithValue(feature.Capability).
+				Errorf("Container adds the capability %q, which is not in allowed-capabilities", feature.Capability)
+		case feature.Container != "":
+			errorListObj.Errorf("Container runs %s", feature.Feature)
+		default:
+			if feature.Volume != "" {
+				errorListObj = errorListObj.WithValue(feature.Volume)
+			}
+
+			errorListObj.Errorf("Pod uses %s", feature.Feature)
+		}
+	}
+}
+
+// AllowedCapabilitiesJustified reports the allowed-capabilities entries without
+// a justification. The privileged surface of the module as a whole is listed by
+// the inventory command.
+func (r *CapabilitiesRule) AllowedCapabilitiesJustified(errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	for _, allowed := range r.allowedCapabilities {
+		if strings.TrimSpace(allowed.Justification) == "" {
+			errorList.WithValue(allowed.Capability).
+				Errorf("allowed-capabilities entry for %q has no justification", allowed.Capability)
+		}
+	}
+}
+
+// isAllowed reports whether an allowed-capabilities entry with a justification
+// allows the container of the object to add the capability.
+func (r *CapabilitiesRule) isAllowed(object storage.StoreObject, container, capability string) bool {
+	return slices.ContainsFunc(r.allowedCapabilities, func(allowed pkg.AllowedCapability) bool {
+		return normalizeCapability(allowed.Capability) == capability &&
+			strings.TrimSpace(allowed.Justification) != "" &&
+			(allowed.Kind == "" || allowed.Kind == object.Unstructured.GetKind()) &&
+			(allowed.Name == "" || allowed.Name == object.Unstructured.GetName()) &&
+			(allowed.Container == "" || allowed.Container == container)
+	})
+}
+
+// privilegedFeatures returns the privileges the pod spec requests.
+func privilegedFeatures(spec *corev1.PodSpec) []privilegedFeature {
+	var features []privilegedFeature
+
+	if spec.HostPID {
+		features = append(features, privilegedFeature{Feature: "the host PID namespace (hostPID)"})
+	}
+
+	if spec.HostIPC {
+		features = append(features, privilegedFeature{Feature: "the host IPC namespace (hostIPC)"})
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil && isSensitiveHostPath(volume.HostPath.Path) {
+			features = append(features, privilegedFeature{
+				Feature: fmt.Sprintf("hostPath volume %q of the sensitive host path %s", volume.Name, volume.HostPath.Path),
+				Volume:  volume.HostPath.Path,
+			})
+		}
+	}
+
+	for _, c := range podContainers(spec) {
+		sc := c.SecurityContext
+		if sc == nil {
+			continue
+		}
+
+		if sc.Privileged != nil && *sc.Privileged {
+			features = append(features, privilegedFeature{Container: c.Name, Feature: "privileged"})
+		}
+
+		if sc.Capabilities == nil {
+			continue
+		}
+
+		for _, capability := range sc.Capabilities.Add {
+			name := normalizeCapability(string(capability))
+			features = append(features, privilegedFeature{Container: c.Name, Feature: "capability " + name, Capability: name})
+		}
+	}
+
+	return features
+}
+
+func isSensitiveHostPath(hostPath string) bool {
+	cleaned := path.Clean("/" + hostPath)
+
+	return slices.ContainsFunc(sensitiveHostPaths, func(sensitive string) bool {
+		if prefix, ok := strings.CutSuffix(sensitive, "/"); ok && prefix != "" {
+			return cleaned == prefix || strings.HasPrefix(cleaned, sensitive)
+		}
+
+		return cleaned == sensitive
+	})
+}
+
+// normalizeCapability returns the capability name without the CAP_ prefix,
+// the form Kubernetes expects.
+func normalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
+
+func (r *CapabilitiesRule) Enabled(object storage.StoreObject, container *corev1.Container) bool {
+	for _, rule := range r.ExcludeRules {
+		if !rule.Enabled(object, container) {
+			return false
+		}
+	}
+
+	return true
+}
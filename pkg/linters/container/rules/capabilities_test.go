@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const capabilitiesDaemonSet = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: init
+          securityContext:
+            capabilities:
+              drop: [ALL]
+      containers:
+        - name: agent
+          image: agent
+          securityContext:
+            capabilities:
+              drop: [ALL]
+              add: [NET_ADMIN]
+`
+
+var netAdminAllowed = []pkg.AllowedCapability{
+	{Capability: "NET_ADMIN", Kind: "DaemonSet", Name: "agent", Justification: "configures the node routes"},
+}
+
+func capabilitiesErrors(t *testing.T, manifest string, allowed []pkg.AllowedCapability, excludes ...pkg.ContainerRuleExclude) []string {
+	t.Helper()
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewCapabilitiesRule(excludes, allowed).ContainerCapabilities(storagetest.Object(t, manifest), errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.ObjectID+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestCapabilitiesAllowed(t *testing.T) {
+	assert.Empty(t, capabilitiesErrors(t, capabilitiesDaemonSet, netAdminAllowed))
+
+	prefixed := storagetest.Patch(t, capabilitiesDaemonSet, "add: [NET_ADMIN]", "add: [CAP_NET_ADMIN]")
+	assert.Empty(t, capabilitiesErrors(t, prefixed, netAdminAllowed), "the CAP_ prefix is ignored")
+}
+
+func TestCapabilitiesNotAllowed(t *testing.T) {
+	const id = "kind = DaemonSet ; name = agent ; namespace = d8-test ; container = agent"
+
+	assert.Equal(t, []string{
+		id + `: Container adds the capability "NET_ADMIN", which is not in allowed-capabilities`,
+	}, capabilitiesErrors(t, capabilitiesDaemonSet, nil))
+
+	unjustified := []pkg.AllowedCapability{{Capability: "NET_ADMIN"}}
+	assert.Len(t, capabilitiesErrors(t, capabilitiesDaemonSet, unjustified), 1, "an entry without a justification allows nothing")
+
+	otherContainer := []pkg.AllowedCapability{{Capability: "NET_ADMIN", Container: "init", Justification: "routes"}}
+	assert.Len(t, capabilitiesErrors(t, capabilitiesDaemonSet, otherContainer), 1)
+
+	dangerous := storagetest.Patch(t, capabilitiesDaemonSet, "add: [NET_ADMIN]", "add: [SYS_ADMIN, NET_RAW]")
+	assert.Equal(t, []string{
+		id + `: Container adds the capability "NET_RAW", which allows crafting raw packets and spoofing traffic: allow it in allowed-capabilities with a justification`,
+		id + `: Container adds the capability "SYS_ADMIN", which is nearly equivalent to root on the node: allow it in allowed-capabilities with a justification`,
+	}, capabilitiesErrors(t, dangerous, nil))
+}
+
+func TestCapabilitiesDropAll(t *testing.T) {
+	manifest := storagetest.Patch(t, capabilitiesDaemonSet, "              drop: [ALL]\n      containers:", "              drop: [NET_RAW]\n      containers:")
+
+	assert.Equal(t, []string{
+		"kind = DaemonSet ; name = agent ; namespace = d8-test ; container = init: Container does not drop ALL capabilities",
+	}, capabilitiesErrors(t, manifest, netAdminAllowed))
+}
+
+func TestCapabilitiesPrivileges(t *testing.T) {
+	const id = "kind = DaemonSet ; name = agent ; namespace = d8-test"
+
+	manifest := storagetest.Patch(t, capabilitiesDaemonSet, "    spec:\n", `    spec:
+      hostPID: true
+      hostIPC: true
+      volumes:
+        - name: docker
+          hostPath:
+            path: /var/run/docker.sock
+        - name: kubernetes
+          hostPath:
+            path: /etc/kubernetes/
+        - name: logs
+          hostPath:
+            path: /var/log
+`)
+	manifest = storagetest.Patch(t, manifest, "          securityContext:\n            capabilities:\n              drop: [ALL]\n              add:", "          securityContext:\n            privileged: true\n            capabilities:\n              drop: [ALL]\n              add:")
+
+	assert.Equal(t, []string{
+		id + ` ; container = agent: Container runs privileged`,
+		id + `: Pod uses hostPath volume "docker" of the sensitive host path /var/run/docker.sock`,
+		id + `: Pod uses hostPath volume "kubernetes" of the sensitive host path /etc/kubernetes/`,
+		id + `: Pod uses the host IPC namespace (hostIPC)`,
+		id + `: Pod uses the host PID namespace (hostPID)`,
+	}, capabilitiesErrors(t, manifest, netAdminAllowed))
+
+	assert.Len(t, capabilitiesErrors(t, manifest, netAdminAllowed, pkg.ContainerRuleExclude{Kind: "DaemonSet", Name: "agent", Container: "agent"}), 4,
+		"a container exclusion keeps the pod-level findings")
+	assert.Empty(t, capabilitiesErrors(t, manifest, nil, pkg.ContainerRuleExclude{Kind: "DaemonSet", Name: "agent"}))
+}
+
+func TestCapabilitiesSensitiveHostPaths(t *testing.T) {
+	for hostPath, sensitive := range map[string]bool{
+		"/":                               true,
+		"/etc":                            true,
+		"/etc/ssl/certs":                  true,
+		"/etcd":                           false,
+		"/var/run/docker.sock":            true,
+		"/run/containerd/containerd.sock": true,
+		"/var/lib/kubelet":                false,
+	} {
+		assert.Equal(t, sensitive, isSensitiveHostPath(hostPath), hostPath)
+	}
+}
+
+func TestCapabilitiesAllowedCapabilitiesJustified(t *testing.T) {
+	allowed := append([]pkg.AllowedCapability{{Capability: "SYS_TIME"}}, netAdminAllowed...)
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewCapabilitiesRule(nil, allowed).AllowedCapabilitiesJustified(errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Level.String()+": "+e.Text)
+	}
+
+	assert.Equal(t, []string{
+		`error: allowed-capabilities entry for "SYS_TIME" has no justification`,
+	}, texts)
+}
@@ -49,6 +49,9 @@ type ImageDigestRule struct {
 	pkg.ContainerRule
 }
 
+// ContainerImageDigestCheck checks that the repository of every container image
+// can be parsed from its digest reference. The registry the image comes from is
+// checked by the image-policy rule.
 func (r *ImageDigestRule) ContainerImageDigestCheck(object storage.StoreObject, containers []corev1.Container, errorList *errors.LintRuleErrorsList) {
 	errorList = errorList.WithRule(r.GetName())
 
@@ -68,19 +71,9 @@ func (r *ImageDigestRule) ContainerImageDigestCheck(object storage.StoreObject,
 			continue
 		}
 
-		repo, err := name.NewRepository(match[0])
-		if err != nil {
+		if _, err := name.NewRepository(match[0]); err != nil {
 			errorList.WithObjectID(object.Identity()+"; container = "+c.Name).
 				Errorf("Cannot parse repository from image: %s", c.Image)
-
-			continue
-		}
-
-		if repo.Name() != defaultRegistry {
-			errorList.WithObjectID(object.Identity()+"; container = "+c.Name).
-				Errorf("All images must be deployed from the same default registry: %s current: %s", defaultRegistry, repo.RepositoryStr())
-
-			continue
 		}
 	}
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func imageDigestErrors(t *testing.T, image string) []string {
+	t.Helper()
+
+	object := storagetest.Object(t, storagetest.Patch(t, imagePolicyDeployment,
+		"registry.example.com/deckhouse@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc", image))
+
+	containers, err := object.GetContainers()
+	require.NoError(t, err)
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImageDigestRule(nil).ContainerImageDigestCheck(object, containers, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	return texts
+}
+
+func TestImageDigest(t *testing.T) {
+	assert.Empty(t, imageDigestErrors(t, "registry.example.com/deckhouse@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc"))
+
+	assert.Equal(t, []string{
+		"Cannot parse repository from image: registry.example.com/deckhouse:v1.0.0",
+	}, imageDigestErrors(t, "registry.example.com/deckhouse:v1.0.0"))
+}
+
+func TestImageDigestLeavesRegistryToImagePolicy(t *testing.T) {
+	image := "quay.io/vendor/agent@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc"
+
+	assert.Empty(t, imageDigestErrors(t, image))
+	assert.Equal(t, []string{
+		`Image "` + image + `" is hardcoded: render it from global.modulesImages with helm_lib_module_image or add its registry to allowed-registries`,
+	}, imagePolicyErrors(t, image, pkg.ImagePolicySettings{}), "a hardcoded image is reported once, by image-policy")
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/internal/werf"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	ImagePolicyRuleName = "image-policy"
+
+	latestTag = "latest"
+)
+
+func NewImagePolicyRule(excludeRules []pkg.ContainerRuleExclude, settings pkg.ImagePolicySettings) *ImagePolicyRule {
+	return &ImagePolicyRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: ImagePolicyRuleName,
+		},
+		ContainerRule: pkg.ContainerRule{
+			ExcludeRules: excludeRules,
+		},
+		settings: settings,
+	}
+}
+
+type ImagePolicyRule struct {
+	pkg.RuleMeta
+	pkg.ContainerRule
+
+	settings pkg.ImagePolicySettings
+}
+
+// ContainerImagePolicy checks the rendered image references of the containers.
+// Images of the module registry (global.modulesImages) must be pinned by the
+// digest helm_lib_module_image renders; any other image is hardcoded in the
+// template and must come from an allowed registry and must not use the latest
+// tag; with require-digest it must be pinned by digest as well.
+func (r *ImagePolicyRule) ContainerImagePolicy(object storage.StoreObject, containers []corev1.Container, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	for i := range containers {
+		c := &containers[i]
+
+		if !r.Enabled(object, c) {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(object.Identity() + " ; container = " + c.Name).WithValue(c.Image)
+
+		ref, err := name.ParseReference(c.Image)
+		if err != nil {
+			errorListObj.Errorf("Cannot parse image reference %q: %s", c.Image, err)
+
+			continue
+		}
+
+		_, pinned := ref.(name.Digest)
+
+		if ref.Context().Name() == defaultRegistry {
+			if !pinned {
+				errorListObj.Errorf("Image %q of the module registry is not pinned by digest: render it with helm_lib_module_image", c.Image)
+			}
+
+			continue
+		}
+
+		switch {
+		case !isAllowedRegistry(ref.Context(), r.settings.AllowedRegistries):
+			errorListObj.Errorf("Image %q is hardcoded: render it from global.modulesImages with helm_lib_module_image or add its registry to allowed-registries", c.Image)
+		case r.settings.RequireDigest && !pinned:
+			errorListObj.Errorf("Image %q of an allowed registry is not pinned by digest", c.Image)
+		}
+
+		if tag, ok := ref.(name.Tag); ok && tag.TagStr() == latestTag {
+			errorListObj.Errorf("Image %q uses the latest tag", c.Image)
+		}
+	}
+}
+
+// moduleImageRefRegex matches the image name passed to helm_lib_module_image
+// (or helm_lib_module_image_no_fail) as a literal, wherever the call is: in an
+// image field, a helper, an env value or an annotation. Depending on the form
+// of the call the name is captured by one of the groups:
+//
+//	{{ include "helm_lib_module_image" (list . "imageName") }}
+//	{{ include "helm_lib_module_image" . "imageName" }}
+//	{{ list . "imageName" | include "helm_lib_module_image" }}
+var moduleImageRefRegex = regexp.MustCompile(
+	`"helm_lib_module_image(?:_no_fail)?"\s+(?:\(\s*list\s+[^()"|]*"([^"]+)"\s*\)|[$.\w]+\s+"([^"]+)")` +
+		`|\blist\s+[^()"|]*"([^"]+)"\s*\|\s*include\s+"helm_lib_module_image(?:_no_fail)?"`,
+)
+
+// moduleImageRef returns the image name of a moduleImageRefRegex match.
+func moduleImageRef(match []string) string {
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+
+	return ""
+}
+
+// ModuleImageReferences checks that every image the module templates reference
+// with helm_lib_module_image is built by the module, i.e. is declared by one of
+// its images/<name>/werf.inc.yaml files. Every file under templates/ is
+// scanned, including helpers and templates that did not render.
+func (r *ImagePolicyRule) ModuleImageReferences(modulePath string, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	imageFiles, err := werf.GetModuleImagesWerfFiles(modulePath)
+	if err != nil {
+		errorList.WithFilePath(modulePath).Errorf("Failed to read images werf files: %s", err)
+
+		return
+	}
+
+	// Templates reference an image by its directory name or by an image the
+	// werf.inc.yaml declares (e.g. a distroless alias), both also in the
+	// camelCase form the digests are keyed by.
+	built := map[string]bool{}
+
+	for _, imageFile := range imageFiles {
+		names := []string{path.Base(path.Dir(imageFile.RelPath))}
+
+		for _, manifest := range fsutils.SplitManifests(imageFile.Content) {
+			jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+			if err != nil {
+				continue
+			}
+
+			if image := gjson.GetBytes(jsonData, "image").String(); image != "" {
+				names = append(names, path.Base(image))
+			}
+		}
+
+		for _, imageName := range names {
+			built[imageName] = true
+			built[values.ModuleCamelName(imageName)] = true
+		}
+	}
+
+	templatePaths := fsutils.GetFiles(filepath.Join(modulePath, "templates"), false)
+	slices.Sort(templatePaths)
+
+	for _, templatePath := range templatePaths {
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			errorList.WithFilePath(fsutils.Rel(modulePath, templatePath)).Errorf("Failed to read template file: %s", err)
+
+			continue
+		}
+
+		// An image referenced several times in a file is reported once.
+		reported := map[string]bool{}
+
+		for i, line := range strings.Split(string(content), "\n") {
+			for _, match := range moduleImageRefRegex.FindAllStringSubmatch(line, -1) {
+				image := moduleImageRef(match)
+				if built[image] || reported[image] {
+					continue
+				}
+
+				reported[image] = true
+
+				errorList.WithFilePath(fsutils.Rel(modulePath, templatePath)).WithLineNumber(i+1).WithValue(image).
+					Errorf("Image %q referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it", image)
+			}
+		}
+	}
+}
+
+// isAllowedRegistry reports whether the repository belongs to one of the
+// allowed registries. An entry is a registry host (e.g. "ghcr.io") or a
+// repository prefix (e.g. "quay.io/org").
+func isAllowedRegistry(repo name.Repository, allowed []string) bool {
+	return slices.ContainsFunc(allowed, func(entry string) bool {
+		entry = strings.TrimSuffix(entry, "/")
+
+		return entry == repo.RegistryStr() || entry == repo.Name() || strings.HasPrefix(repo.Name(), entry+"/")
+	})
+}
+
+func (r *ImagePolicyRule) Enabled(object storage.StoreObject, container *corev1.Container) bool {
+	for _, rule := range r.ExcludeRules {
+		if !rule.Enabled(object, container) {
+			return false
+		}
+	}
+
+	return true
+}
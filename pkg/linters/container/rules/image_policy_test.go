@@ -0,0 +1,179 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const imagePolicyDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: registry.example.com/deckhouse@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc
+`
+
+func imagePolicyErrors(t *testing.T, image string, settings pkg.ImagePolicySettings, excludes ...pkg.ContainerRuleExclude) []string {
+	t.Helper()
+
+	manifest := imagePolicyDeployment
+	if image != "" {
+		manifest = storagetest.Patch(t, manifest, "registry.example.com/deckhouse@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc", image)
+	}
+
+	object := storagetest.Object(t, manifest)
+
+	containers, err := object.GetContainers()
+	require.NoError(t, err)
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImagePolicyRule(excludes, settings).ContainerImagePolicy(object, containers, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestImagePolicyModuleRegistry(t *testing.T) {
+	assert.Empty(t, imagePolicyErrors(t, "", pkg.ImagePolicySettings{}))
+
+	assert.Equal(t, []string{
+		`Image "registry.example.com/deckhouse:v1.0.0" of the module registry is not pinned by digest: render it with helm_lib_module_image`,
+	}, imagePolicyErrors(t, "registry.example.com/deckhouse:v1.0.0", pkg.ImagePolicySettings{}))
+}
+
+func TestImagePolicyHardcoded(t *testing.T) {
+	assert.Equal(t, []string{
+		`Image "nginx" is hardcoded: render it from global.modulesImages with helm_lib_module_image or add its registry to allowed-registries`,
+		`Image "nginx" uses the latest tag`,
+	}, imagePolicyErrors(t, "nginx", pkg.ImagePolicySettings{}))
+
+	allowed := pkg.ImagePolicySettings{AllowedRegistries: []string{"ghcr.io", "quay.io/org/"}}
+
+	assert.Empty(t, imagePolicyErrors(t, "ghcr.io/vendor/agent:v1.2.3", allowed))
+	assert.Empty(t, imagePolicyErrors(t, "quay.io/org/agent@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc", allowed))
+	assert.Equal(t, []string{
+		`Image "ghcr.io/vendor/agent:latest" uses the latest tag`,
+	}, imagePolicyErrors(t, "ghcr.io/vendor/agent:latest", allowed))
+	assert.Len(t, imagePolicyErrors(t, "quay.io/other/agent:v1", allowed), 1, "only the repositories of the prefix are allowed")
+}
+
+func TestImagePolicyRequireDigest(t *testing.T) {
+	settings := pkg.ImagePolicySettings{AllowedRegistries: []string{"ghcr.io"}, RequireDigest: true}
+
+	assert.Equal(t, []string{
+		`Image "ghcr.io/vendor/agent:v1.2.3" of an allowed registry is not pinned by digest`,
+	}, imagePolicyErrors(t, "ghcr.io/vendor/agent:v1.2.3", settings))
+	assert.Empty(t, imagePolicyErrors(t, "ghcr.io/vendor/agent@sha256:d478cd82cb6a604e3a27383daf93637326d402570b2f3bec835d1f84c9ed0acc", settings))
+	assert.Len(t, imagePolicyErrors(t, "quay.io/vendor/agent:v1", settings), 1, "images of other registries are reported as hardcoded only")
+}
+
+func TestImagePolicyInvalidReference(t *testing.T) {
+	errs := imagePolicyErrors(t, "Registry/Image:!", pkg.ImagePolicySettings{})
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0], `Cannot parse image reference "Registry/Image:!"`)
+}
+
+func TestImagePolicyExclude(t *testing.T) {
+	assert.Empty(t, imagePolicyErrors(t, "nginx", pkg.ImagePolicySettings{}, pkg.ContainerRuleExclude{Kind: "Deployment", Name: "app", Container: "app"}))
+}
+
+func TestImagePolicyModuleImageReferences(t *testing.T) {
+	moduleDir := t.TempDir()
+
+	for dir, content := range map[string]string{
+		"pod-reloader": "image: {{ .ImageName }}\nfrom: alpine\n---\nimage: {{ .ImageName }}-distroless\nfrom: alpine\n",
+		"no-werf":      "",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(moduleDir, "images", dir), 0o755))
+
+		if content != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "images", dir, "werf.inc.yaml"), []byte(content), 0o600))
+		}
+	}
+
+	for file, content := range map[string]string{
+		"deployment.yaml": strings.Join([]string{
+			`image: {{ include "helm_lib_module_image" (list . "podReloader") }}`,
+			`image: {{ include "helm_lib_module_image" (list . "podReloaderDistroless") }}`,
+			`image: {{ include "helm_lib_module_image" (list . "noWerf") }}`,
+			`image: {{ include "helm_lib_module_image" (list . "missing") }}`,
+			`image: {{ include "helm_lib_module_image" (list . "missing") }}`,
+		}, "\n"),
+		"forms.yaml": strings.Join([]string{
+			`image: {{ include "helm_lib_module_image" . "directArgs" }}`,
+			`image: {{ include  "helm_lib_module_image"  ( list  $  "spaced" ) }}`,
+			`image: {{ list . "piped" | include "helm_lib_module_image" }}`,
+			`image: {{ include "helm_lib_module_image" $ "podReloader" }}`,
+			`image: {{ list . "podReloader" | include "helm_lib_module_image_no_fail" | quote }}`,
+		}, "\n"),
+		"_helpers.tpl": `{{- define "sidecar" }}{{ include "helm_lib_module_image" (list $ "inHelper") }}{{ end }}`,
+		"job/job.yaml": strings.Join([]string{
+			`{{- if .Values.broken }}{{ fail "not rendered" }}{{ end }}`,
+			`env:`,
+			`  - name: TOOL_IMAGE`,
+			`    value: {{ include "helm_lib_module_image_no_fail" (list . "inEnv") | quote }}`,
+		}, "\n"),
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(moduleDir, "templates", file)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "templates", file), []byte(content), 0o600))
+	}
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImagePolicyRule(nil, pkg.ImagePolicySettings{}).ModuleImageReferences(moduleDir, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, fmt.Sprintf("%s:%d: %s", e.FilePath, e.LineNumber, e.Text))
+	}
+
+	sort.Strings(texts)
+
+	assert.Equal(t, []string{
+		`templates/_helpers.tpl:1: Image "inHelper" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/deployment.yaml:3: Image "noWerf" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/deployment.yaml:4: Image "missing" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/forms.yaml:1: Image "directArgs" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/forms.yaml:2: Image "spaced" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/forms.yaml:3: Image "piped" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+		`templates/job/job.yaml:4: Image "inEnv" referenced by helm_lib_module_image is not built by the module: no images/*/werf.inc.yaml declares it`,
+	}, texts, "each image is reported once per file")
+}
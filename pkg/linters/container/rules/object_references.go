@@ -0,0 +1,334 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	ObjectReferencesRuleName = "object-references"
+
+	configMapKind             = "ConfigMap"
+	secretKind                = "Secret"
+	serviceAccountKind        = "ServiceAccount"
+	persistentVolumeClaimKind = "PersistentVolumeClaim"
+
+	// kubeRootCAConfigMap is published to every namespace by the cluster.
+	kubeRootCAConfigMap = "kube-root-ca.crt"
+
+	certManagerGroup = "cert-manager.io"
+)
+
+// certificateSecretKeys are the keys cert-manager writes to the Secret of a
+// Certificate.
+var certificateSecretKeys = []string{"tls.crt", "tls.key", "ca.crt"}
+
+func NewObjectReferencesRule(excludeRules []pkg.KindRuleExclude, externalReferences []pkg.ExternalReference) *ObjectReferencesRule {
+	return &ObjectReferencesRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: ObjectReferencesRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+		externalReferences: externalReferences,
+	}
+}
+
+type ObjectReferencesRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+
+	externalReferences []pkg.ExternalReference
+}
+
+// objectReference is a reference of a pod spec to another object of its
+// namespace. Key is set when a single ConfigMap or Secret key is referenced,
+// Container when the reference belongs to a container.
+type objectReference struct {
+	Kind      string
+	Name      string
+	Key       string
+	Source    string
+	Container string
+	Optional  bool
+}
+
+// ObjectReferences checks that the ConfigMaps, Secrets, ServiceAccounts and
+// PersistentVolumeClaims a pod spec references are rendered by the module or
+// declared as externally provided, and that the referenced ConfigMap and Secret
+// keys exist in the rendered data. A Secret may also be provided by a
+// cert-manager Certificate of the module through spec.secretName. StatefulSet
+// volume mounts must refer to a volume or a volume claim template.
+func (r *ObjectReferencesRule) ObjectReferences(object storage.StoreObject, storageMap map[storage.ResourceIndex]storage.StoreObject, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	if !isSecurityContextSupportedKind(object.Unstructured.GetKind()) {
+		return
+	}
+
+	if !r.Enabled(object.Unstructured.GetKind(), object.Unstructured.GetName()) {
+		return
+	}
+
+	podSpec, err := object.GetPodSpec()
+	if err != nil {
+		errorList.WithObjectID(object.Identity()).
+			Errorf("GetPodSpec failed: %v", err)
+
+		return
+	}
+
+	if podSpec == nil {
+		return
+	}
+
+	namespace := object.Unstructured.GetNamespace()
+
+	// A missing volume source is reported once, not for each of its items.
+	reported := map[objectReference]bool{}
+
+	for _, ref := range podSpecReferences(podSpec) {
+		objectID := object.Identity()
+		if ref.Container != "" {
+			objectID += " ; container = " + ref.Container
+		}
+
+		errorListObj := errorList.WithObjectID(objectID).WithValue(ref.Name)
+
+		if ref.Kind == secretKind && hasCertificateFor(storageMap, namespace, ref.Name) {
+			if ref.Key != "" && !ref.Optional && !slices.Contains(certificateSecretKeys, ref.Key) {
+				errorListObj.WithValue(ref.Key).
+					Errorf("Key %q referenced by %s does not exist in Secret %q issued by a Certificate", ref.Key, ref.Source, ref.Name)
+			}
+
+			continue
+		}
+
+		target, found := findObject(storageMap, ref.Kind, namespace, ref.Name)
+		if !found {
+			source := ref
+			source.Key = ""
+
+			if !ref.Optional && !r.isExternal(ref.Kind, namespace, ref.Name) && !reported[source] {
+				reported[source] = true
+
+				errorListObj.Errorf("%s %q referenced by %s is not rendered by the module and is not declared in external-references", ref.Kind, ref.Name, ref.Source)
+			}
+
+			continue
+		}
+
+		if ref.Key != "" && !ref.Optional && !hasDataKey(&target.Unstructured, ref.Key) {
+			errorListObj.WithValue(ref.Key).
+				Errorf("Key %q referenced by %s does not exist in %s %q", ref.Key, ref.Source, ref.Kind, ref.Name)
+		}
+	}
+
+	if object.Unstructured.GetKind() == "StatefulSet" {
+		checkStatefulSetMounts(object, podSpec, errorList)
+	}
+}
+
+func (r *ObjectReferencesRule) isExternal(kind, namespace, name string) bool {
+	if kind == configMapKind && name == kubeRootCAConfigMap {
+		return true
+	}
+
+	return slices.ContainsFunc(r.externalReferences, func(ref pkg.ExternalReference) bool {
+		return ref.Kind == kind && ref.Name == name && (ref.Namespace == "" || ref.Namespace == namespace)
+	})
+}
+
+// podSpecReferences returns the objects the pod spec references.
+func podSpecReferences(spec *corev1.PodSpec) []objectReference {
+	var refs []objectReference
+
+	if name := spec.ServiceAccountName; name != "" && name != "default" {
+		refs = append(refs, objectReference{Kind: serviceAccountKind, Name: name, Source: "serviceAccountName"})
+	}
+
+	for _, secret := range spec.ImagePullSecrets {
+		refs = append(refs, objectReference{Kind: secretKind, Name: secret.Name, Source: "imagePullSecrets"})
+	}
+
+	for i := range spec.Volumes {
+		refs = append(refs, volumeReferences(&spec.Volumes[i])...)
+	}
+
+	for _, container := range podContainers(spec) {
+		for _, envFrom := range container.EnvFrom {
+			switch {
+			case envFrom.ConfigMapRef != nil:
+				refs = append(refs, objectReference{
+					Kind: configMapKind, Name: envFrom.ConfigMapRef.Name, Source: "envFrom",
+					Container: container.Name, Optional: isOptional(envFrom.ConfigMapRef.Optional),
+				})
+			case envFrom.SecretRef != nil:
+				refs = append(refs, objectReference{
+					Kind: secretKind, Name: envFrom.SecretRef.Name, Source: "envFrom",
+					Container: container.Name, Optional: isOptional(envFrom.SecretRef.Optional),
+				})
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			source := "env " + env.Name
+
+			switch {
+			case env.ValueFrom.ConfigMapKeyRef != nil:
+				ref := env.ValueFrom.ConfigMapKeyRef
+				refs = append(refs, objectReference{
+					Kind: configMapKind, Name: ref.Name, Key: ref.Key, Source: source,
+					Container: container.Name, Optional: isOptional(ref.Optional),
+				})
+			case env.ValueFrom.SecretKeyRef != nil:
+				ref := env.ValueFrom.SecretKeyRef
+				refs = append(refs, objectReference{
+					Kind: secretKind, Name: ref.Name, Key: ref.Key, Source: source,
+					Container: container.Name, Optional: isOptional(ref.Optional),
+				})
+			}
+		}
+	}
+
+	return refs
+}
+
+func volumeReferences(volume *corev1.Volume) []objectReference {
+	source := "volume " + volume.Name
+
+	var refs []objectReference
+
+	addItems := func(kind, name string, items []corev1.KeyToPath, optional bool) {
+		refs = append(refs, objectReference{Kind: kind, Name: name, Source: source, Optional: optional})
+
+		for _, item := range items {
+			refs = append(refs, objectReference{Kind: kind, Name: name, Key: item.Key, Source: source, Optional: optional})
+		}
+	}
+
+	switch {
+	case volume.ConfigMap != nil:
+		addItems(configMapKind, volume.ConfigMap.Name, volume.ConfigMap.Items, isOptional(volume.ConfigMap.Optional))
+	case volume.Secret != nil:
+		addItems(secretKind, volume.Secret.SecretName, volume.Secret.Items, isOptional(volume.Secret.Optional))
+	case volume.PersistentVolumeClaim != nil:
+		refs = append(refs, objectReference{Kind: persistentVolumeClaimKind, Name: volume.PersistentVolumeClaim.ClaimName, Source: source})
+	case volume.Projected != nil:
+		for _, projection := range volume.Projected.Sources {
+			switch {
+			case projection.ConfigMap != nil:
+				addItems(configMapKind, projection.ConfigMap.Name, projection.ConfigMap.Items, isOptional(projection.ConfigMap.Optional))
+			case projection.Secret != nil:
+				addItems(secretKind, projection.Secret.Name, projection.Secret.Items, isOptional(projection.Secret.Optional))
+			}
+		}
+	}
+
+	return refs
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+func findObject(storageMap map[storage.ResourceIndex]storage.StoreObject, kind, namespace, name string) (storage.StoreObject, bool) {
+	for _, object := range storageMap {
+		if object.Unstructured.GetKind() == kind &&
+			object.Unstructured.GetNamespace() == namespace &&
+			object.Unstructured.GetName() == name {
+			return object, true
+		}
+	}
+
+	return storage.StoreObject{}, false
+}
+
+// hasCertificateFor reports whether a cert-manager Certificate of the namespace
+// issues its certificate into the named Secret.
+func hasCertificateFor(storageMap map[storage.ResourceIndex]storage.StoreObject, namespace, secretName string) bool {
+	for _, object := range storageMap {
+		if object.Unstructured.GetKind() != "Certificate" ||
+			object.Unstructured.GroupVersionKind().Group != certManagerGroup ||
+			object.Unstructured.GetNamespace() != namespace {
+			continue
+		}
+
+		if name, _, _ := unstructured.NestedString(object.Unstructured.Object, "spec", "secretName"); name == secretName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasDataKey reports whether the rendered ConfigMap or Secret holds the key.
+func hasDataKey(object *unstructured.Unstructured, key string) bool {
+	fields := []string{"data", "binaryData"}
+	if object.GetKind() == secretKind {
+		fields = []string{"data", "stringData"}
+	}
+
+	for _, field := range fields {
+		data, _, _ := unstructured.NestedMap(object.Object, field)
+		if _, ok := data[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkStatefulSetMounts reports StatefulSet volume mounts that refer to
+// neither a pod volume nor a volume claim template.
+func checkStatefulSetMounts(object storage.StoreObject, spec *corev1.PodSpec, errorList *errors.LintRuleErrorsList) {
+	var names []string
+
+	for _, volume := range spec.Volumes {
+		names = append(names, volume.Name)
+	}
+
+	templates, _, _ := unstructured.NestedSlice(object.Unstructured.Object, "spec", "volumeClaimTemplates")
+	for _, template := range templates {
+		if template, ok := template.(map[string]any); ok {
+			name, _, _ := unstructured.NestedString(template, "metadata", "name")
+			names = append(names, name)
+		}
+	}
+
+	for _, container := range podContainers(spec) {
+		for _, mount := range container.VolumeMounts {
+			if !slices.Contains(names, mount.Name) {
+				errorList.WithObjectID(object.Identity()+" ; container = "+container.Name).WithValue(mount.Name).
+					Errorf("Volume mount %q refers to neither a volume nor a volumeClaimTemplate of the StatefulSet", mount.Name)
+			}
+		}
+	}
+}
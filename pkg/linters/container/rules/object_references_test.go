@@ -0,0 +1,234 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const referencingDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      serviceAccountName: app
+      imagePullSecrets:
+        - name: deckhouse-registry
+      volumes:
+        - name: config
+          configMap:
+            name: app
+            items:
+              - key: config.yaml
+                path: config.yaml
+        - name: tls
+          secret:
+            secretName: app-tls
+        - name: data
+          persistentVolumeClaim:
+            claimName: app-data
+      containers:
+        - name: app
+          image: app
+          envFrom:
+            - configMapRef:
+                name: app
+          env:
+            - name: PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: app-tls
+                  key: password
+            - name: OPTIONAL
+              valueFrom:
+                configMapKeyRef:
+                  name: app-extra
+                  key: value
+                  optional: true
+`
+
+const referencedObjects = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: app
+  namespace: d8-test
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: deckhouse-registry
+  namespace: d8-test
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: d8-test
+data:
+  config.yaml: "{}"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-tls
+  namespace: d8-test
+stringData:
+  password: secret
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: app-data
+  namespace: d8-test
+`
+
+func referencesErrors(t *testing.T, manifest string, objects string, external []pkg.ExternalReference, excludes ...pkg.KindRuleExclude) []string {
+	t.Helper()
+
+	object := storagetest.Object(t, manifest)
+	storageMap := storagetest.Objects(t, manifest, objects)
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewObjectReferencesRule(excludes, external).ObjectReferences(object, storageMap, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.ObjectID+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestObjectReferencesResolved(t *testing.T) {
+	assert.Empty(t, referencesErrors(t, referencingDeployment, referencedObjects, nil))
+}
+
+func TestObjectReferencesMissingObjects(t *testing.T) {
+	const id = "kind = Deployment ; name = app ; namespace = d8-test"
+
+	objects := storagetest.Patch(t, referencedObjects, "  name: app-data\n", "  name: other-data\n")
+	objects = storagetest.Patch(t, objects, "kind: ServiceAccount\n", "kind: Role\n")
+
+	assert.Equal(t, []string{
+		id + `: PersistentVolumeClaim "app-data" referenced by volume data is not rendered by the module and is not declared in external-references`,
+		id + `: ServiceAccount "app" referenced by serviceAccountName is not rendered by the module and is not declared in external-references`,
+	}, referencesErrors(t, referencingDeployment, objects, nil))
+
+	assert.Empty(t, referencesErrors(t, referencingDeployment, objects, []pkg.ExternalReference{
+		{Kind: "ServiceAccount", Name: "app"},
+		{Kind: "PersistentVolumeClaim", Name: "app-data"},
+	}), "externally provided objects are not reported")
+
+	assert.Equal(t, []string{
+		id + `: PersistentVolumeClaim "app-data" referenced by volume data is not rendered by the module and is not declared in external-references`,
+	}, referencesErrors(t, referencingDeployment, objects, []pkg.ExternalReference{
+		{Kind: "ServiceAccount", Name: "app", Namespace: "d8-test"},
+		{Kind: "PersistentVolumeClaim", Name: "app-data", Namespace: "d8-other"},
+	}), "external references are limited to their namespace")
+
+	missingVolume := storagetest.Patch(t, referencedObjects, "kind: ConfigMap\n", "kind: Role\n")
+	assert.Contains(t, referencesErrors(t, referencingDeployment, missingVolume, nil),
+		id+`: ConfigMap "app" referenced by volume config is not rendered by the module and is not declared in external-references`)
+	assert.Len(t, referencesErrors(t, referencingDeployment, missingVolume, nil), 2, "the volume and envFrom references are reported once each")
+
+	typo := storagetest.Patch(t, referencingDeployment, "- configMapRef:\n                name: app", "- configMapRef:\n                name: ap")
+	assert.Equal(t, []string{
+		id + ` ; container = app: ConfigMap "ap" referenced by envFrom is not rendered by the module and is not declared in external-references`,
+	}, referencesErrors(t, typo, referencedObjects, nil))
+}
+
+func TestObjectReferencesMissingKeys(t *testing.T) {
+	const id = "kind = Deployment ; name = app ; namespace = d8-test"
+
+	objects := storagetest.Patch(t, referencedObjects, "config.yaml: \"{}\"", "settings.yaml: \"{}\"")
+	objects = storagetest.Patch(t, objects, "  password: secret", "  token: secret")
+
+	assert.Equal(t, []string{
+		id + ` ; container = app: Key "password" referenced by env PASSWORD does not exist in Secret "app-tls"`,
+		id + `: Key "config.yaml" referenced by volume config does not exist in ConfigMap "app"`,
+	}, referencesErrors(t, referencingDeployment, objects, nil))
+}
+
+func TestObjectReferencesCertificateSecret(t *testing.T) {
+	const certificate = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  secretName: app-tls
+`
+
+	objects := storagetest.Patch(t, referencedObjects,
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: app-tls\n  namespace: d8-test\nstringData:\n  password: secret\n", certificate)
+
+	assert.Equal(t, []string{
+		`kind = Deployment ; name = app ; namespace = d8-test ; container = app: Key "password" referenced by env PASSWORD does not exist in Secret "app-tls" issued by a Certificate`,
+	}, referencesErrors(t, referencingDeployment, objects, nil))
+
+	tlsKey := storagetest.Patch(t, referencingDeployment, "key: password", "key: tls.key")
+	assert.Empty(t, referencesErrors(t, tlsKey, objects, nil), "the Certificate provides its Secret")
+
+	otherNamespace := storagetest.Patch(t, objects, "  name: app\n  namespace: d8-test\nspec:\n  secretName", "  name: app\n  namespace: d8-other\nspec:\n  secretName")
+	assert.Len(t, referencesErrors(t, tlsKey, otherNamespace, nil), 2, "a Certificate of another namespace does not provide the Secret")
+}
+
+func TestObjectReferencesStatefulSetMounts(t *testing.T) {
+	const statefulSet = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  namespace: d8-test
+spec:
+  volumeClaimTemplates:
+    - metadata:
+        name: data
+  template:
+    spec:
+      containers:
+        - name: db
+          image: db
+          volumeMounts:
+            - name: data
+              mountPath: /data
+            - name: wal
+              mountPath: /wal
+`
+
+	assert.Equal(t, []string{
+		`kind = StatefulSet ; name = db ; namespace = d8-test ; container = db: Volume mount "wal" refers to neither a volume nor a volumeClaimTemplate of the StatefulSet`,
+	}, referencesErrors(t, statefulSet, referencedObjects, nil))
+}
+
+func TestObjectReferencesExclude(t *testing.T) {
+	assert.Empty(t, referencesErrors(t, referencingDeployment, "", nil, pkg.KindRuleExclude{Kind: "Deployment", Name: "app"}))
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	PodSecurityStandardsRuleName = "pod-security-standards"
+
+	// podSecurityEnforceLabel is the Pod Security Admission label of a namespace.
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	// podPolicyLabel is the Deckhouse admission-policy-engine label of a namespace,
+	// used when the Pod Security Admission label is not set.
+	podPolicyLabel = "security.deckhouse.io/pod-policy"
+
+	levelBaseline   = "baseline"
+	levelRestricted = "restricted"
+
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+)
+
+var (
+	// baselineCapabilities are the capabilities the baseline profile allows to add.
+	baselineCapabilities = []corev1.Capability{
+		"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+		"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+	}
+
+	// safeSysctls are the sysctls the baseline profile allows.
+	safeSysctls = []string{
+		"kernel.shm_rmid_forced",
+		"net.ipv4.ip_local_port_range",
+		"net.ipv4.ip_unprivileged_port_start",
+		"net.ipv4.tcp_syncookies",
+		"net.ipv4.ping_group_range",
+		"net.ipv4.ip_local_reserved_ports",
+		"net.ipv4.tcp_keepalive_time",
+		"net.ipv4.tcp_fin_timeout",
+		"net.ipv4.tcp_keepalive_intvl",
+		"net.ipv4.tcp_keepalive_probes",
+	}
+
+	// seLinuxTypes are the SELinux types the baseline profile allows.
+	seLinuxTypes = []string{"", "container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+)
+
+func NewPodSecurityStandardsRule(excludeRules []pkg.KindRuleExclude) *PodSecurityStandardsRule {
+	return &PodSecurityStandardsRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: PodSecurityStandardsRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+	}
+}
+
+type PodSecurityStandardsRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+}
+
+// pssViolation is a violated control of a Pod Security Standards profile.
+// Container is empty for the controls of the pod spec.
+type pssViolation struct {
+	Control   string
+	Container string
+	Value     any
+	Message   string
+}
+
+// ObjectPodSecurityStandards evaluates a pod controller against the Pod Security
+// Standards profile its namespace enforces. The level is taken from the labels
+// of the Namespace object the module renders; objects in namespaces the module
+// does not render, or that enforce the privileged level, are not checked.
+func (r *PodSecurityStandardsRule) ObjectPodSecurityStandards(object storage.StoreObject, storageMap map[storage.ResourceIndex]storage.StoreObject, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	if !isSecurityContextSupportedKind(object.Unstructured.GetKind()) {
+		return
+	}
+
+	if !r.Enabled(object.Unstructured.GetKind(), object.Unstructured.GetName()) {
+		return
+	}
+
+	level := namespacePodSecurityLevel(object.Unstructured.GetNamespace(), storageMap)
+	if level != levelBaseline && level != levelRestricted {
+		return
+	}
+
+	podSpec, err := object.GetPodSpec()
+	if err != nil {
+		errorList.WithObjectID(object.Identity()).
+			Errorf("GetPodSpec failed: %v", err)
+
+		return
+	}
+
+	if podSpec == nil {
+		return
+	}
+
+	violations := baselineViolations(podSpec, podTemplateAnnotations(&object.Unstructured))
+	if level == levelRestricted {
+		violations = append(violations, restrictedViolations(podSpec)...)
+	}
+
+	for _, v := range violations {
+		objectID := object.Identity()
+		if v.Container != "" {
+			objectID += " ; container = " + v.Container
+		}
+
+		errorList.WithObjectID(objectID).WithValue(v.Value).
+			Errorf("Violates the %q Pod Security Standard of namespace %q, control %q: %s", level, object.Unstructured.GetNamespace(), v.Control, v.Message)
+	}
+}
+
+// namespacePodSecurityLevel returns the Pod Security Standards level of the
+// rendered Namespace, or "" when the module does not render it.
+func namespacePodSecurityLevel(namespace string, storageMap map[storage.ResourceIndex]storage.StoreObject) string {
+	if namespace == "" {
+		return ""
+	}
+
+	for _, obj := range storageMap {
+		if obj.Unstructured.GetKind() != "Namespace" || obj.Unstructured.GetName() != namespace {
+			continue
+		}
+
+		labels := obj.Unstructured.GetLabels()
+		if level, ok := labels[podSecurityEnforceLabel]; ok {
+			return level
+		}
+
+		return labels[podPolicyLabel]
+	}
+
+	return ""
+}
+
+func podTemplateAnnotations(object *unstructured.Unstructured) map[string]string {
+	var path []string
+
+	switch object.GetKind() {
+	case "Pod":
+		return object.GetAnnotations()
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template", "metadata", "annotations"}
+	default:
+		path = []string{"spec", "template", "metadata", "annotations"}
+	}
+
+	annotations, _, _ := unstructured.NestedStringMap(object.Object, path...)
+
+	return annotations
+}
+
+// podContainers returns the init containers and containers in spec order.
+func podContainers(spec *corev1.PodSpec) []corev1.Container {
+	return append(slices.Clone(spec.InitContainers), spec.Containers...)
+}
+
+func baselineViolations(spec *corev1.PodSpec, annotations map[string]string) []pssViolation {
+	var violations []pssViolation
+
+	add := func(control, container string, value any, format string, args ...any) {
+		violations = append(violations, pssViolation{Control: control, Container: container, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	podSC := spec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+
+	if podSC.WindowsOptions != nil && podSC.WindowsOptions.HostProcess != nil && *podSC.WindowsOptions.HostProcess {
+		add("HostProcess", "", true, "pod sets securityContext.windowsOptions.hostProcess to true")
+	}
+
+	for _, ns := range []struct {
+		field   string
+		enabled bool
+	}{{"hostNetwork", spec.HostNetwork}, {"hostPID", spec.HostPID}, {"hostIPC", spec.HostIPC}} {
+		if ns.enabled {
+			add("Host Namespaces", "", true, "pod sets %s to true", ns.field)
+		}
+	}
+
+	for i := range spec.Volumes {
+		if spec.Volumes[i].HostPath != nil {
+			add("HostPath Volumes", "", spec.Volumes[i].HostPath.Path, "volume %q is a hostPath volume", spec.Volumes[i].Name)
+		}
+	}
+
+	if p := podSC.AppArmorProfile; p != nil && p.Type == corev1.AppArmorProfileTypeUnconfined {
+		add("AppArmor", "", p.Type, "pod sets securityContext.appArmorProfile.type to %q", p.Type)
+	}
+
+	if msg, bad := badSELinuxOptions(podSC.SELinuxOptions); bad {
+		add("SELinux", "", podSC.SELinuxOptions, "pod %s", msg)
+	}
+
+	if p := podSC.SeccompProfile; p != nil && p.Type == corev1.SeccompProfileTypeUnconfined {
+		add("Seccomp", "", p.Type, "pod sets securityContext.seccompProfile.type to %q", p.Type)
+	}
+
+	for _, sysctl := range podSC.Sysctls {
+		if !slices.Contains(safeSysctls, sysctl.Name) {
+			add("Sysctls", "", sysctl.Name, "pod sets the unsafe sysctl %q", sysctl.Name)
+		}
+	}
+
+	for _, c := range podContainers(spec) {
+		if profile, ok := annotations[appArmorAnnotationPrefix+c.Name]; ok && profile != "runtime/default" && !strings.HasPrefix(profile, "localhost/") {
+			add("AppArmor", c.Name, profile, "annotation %q sets the AppArmor profile %q", appArmorAnnotationPrefix+c.Name, profile)
+		}
+
+		for _, port := range c.Ports {
+			if port.HostPort != 0 {
+				add("Host Ports", c.Name, port.HostPort, "container uses hostPort %d", port.HostPort)
+			}
+		}
+
+		sc := c.SecurityContext
+		if sc == nil {
+			continue
+		}
+
+		if sc.WindowsOptions != nil && sc.WindowsOptions.HostProcess != nil && *sc.WindowsOptions.HostProcess {
+			add("HostProcess", c.Name, true, "container sets securityContext.windowsOptions.hostProcess to true")
+		}
+
+		if sc.Privileged != nil && *sc.Privileged {
+			add("Privileged Containers", c.Name, true, "container sets securityContext.privileged to true")
+		}
+
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if !slices.Contains(baselineCapabilities, capability) {
+					add("Capabilities", c.Name, capability, "container adds the capability %q", capability)
+				}
+			}
+		}
+
+		if p := sc.AppArmorProfile; p != nil && p.Type == corev1.AppArmorProfileTypeUnconfined {
+			add("AppArmor", c.Name, p.Type, "container sets securityContext.appArmorProfile.type to %q", p.Type)
+		}
+
+		if msg, bad := badSELinuxOptions(sc.SELinuxOptions); bad {
+			add("SELinux", c.Name, sc.SELinuxOptions, "container %s", msg)
+		}
+
+		if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+			add("/proc Mount Type", c.Name, *sc.ProcMount, "container sets securityContext.procMount to %q", *sc.ProcMount)
+		}
+
+		if p := sc.SeccompProfile; p != nil && p.Type == corev1.SeccompProfileTypeUnconfined {
+			add("Seccomp", c.Name, p.Type, "container sets securityContext.seccompProfile.type to %q", p.Type)
+		}
+	}
+
+	return violations
+}
+
+func badSELinuxOptions(opts *corev1.SELinuxOptions) (string, bool) {
+	switch {
+	case opts == nil:
+		return "", false
+	case !slices.Contains(seLinuxTypes, opts.Type):
+		return fmt.Sprintf("sets the SELinux type %q", opts.Type), true
+	case opts.User != "" || opts.Role != "":
+		return "sets a custom SELinux user or role", true
+	default:
+		return "", false
+	}
+}
+
+func restrictedViolations(spec *corev1.PodSpec) []pssViolation {
+	var violations []pssViolation
+
+	add := func(control, container string, value any, format string, args ...any) {
+		violations = append(violations, pssViolation{Control: control, Container: container, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	podSC := spec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+
+	for i := range spec.Volumes {
+		if volumeType := restrictedVolumeType(&spec.Volumes[i]); volumeType != "" {
+			add("Volume Types", "", volumeType, "volume %q has the type %q; only configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected and secret are allowed", spec.Volumes[i].Name, volumeType)
+		}
+	}
+
+	if podSC.RunAsUser != nil && *podSC.RunAsUser == 0 {
+		add("Running as Non-root user", "", 0, "pod sets securityContext.runAsUser to 0")
+	}
+
+	podNonRoot := podSC.RunAsNonRoot != nil && *podSC.RunAsNonRoot
+	if podSC.RunAsNonRoot != nil && !*podSC.RunAsNonRoot {
+		add("Running as Non-root", "", false, "pod sets securityContext.runAsNonRoot to false")
+	}
+
+	podSeccomp := podSC.SeccompProfile != nil && podSC.SeccompProfile.Type != corev1.SeccompProfileTypeUnconfined
+
+	for _, c := range podContainers(spec) {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			add("Privilege Escalation", c.Name, sc.AllowPrivilegeEscalation, "container must set securityContext.allowPrivilegeEscalation to false")
+		}
+
+		switch {
+		case sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot:
+			add("Running as Non-root", c.Name, false, "container sets securityContext.runAsNonRoot to false")
+		case sc.RunAsNonRoot == nil && !podNonRoot:
+			add("Running as Non-root", c.Name, nil, "securityContext.runAsNonRoot must be true in the pod or the container")
+		}
+
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			add("Running as Non-root user", c.Name, 0, "container sets securityContext.runAsUser to 0")
+		}
+
+		if sc.SeccompProfile == nil && !podSeccomp {
+			add("Seccomp", c.Name, nil, "securityContext.seccompProfile.type must be RuntimeDefault or Localhost in the pod or the container")
+		}
+
+		var drop, added []corev1.Capability
+		if sc.Capabilities != nil {
+			drop, added = sc.Capabilities.Drop, sc.Capabilities.Add
+		}
+
+		if !slices.Contains(drop, "ALL") {
+			add("Capabilities", c.Name, drop, "container must drop ALL capabilities")
+		}
+
+		// Capabilities outside the baseline set are already reported by baseline.
+		for _, capability := range added {
+			if capability != "NET_BIND_SERVICE" && slices.Contains(baselineCapabilities, capability) {
+				add("Capabilities", c.Name, capability, "container adds the capability %q; only NET_BIND_SERVICE is allowed", capability)
+			}
+		}
+	}
+
+	return violations
+}
+
+// restrictedVolumeType returns the type of a volume the restricted profile does
+// not allow, or "".
+func restrictedVolumeType(v *corev1.Volume) string {
+	src := v.VolumeSource
+
+	switch {
+	case src.ConfigMap != nil, src.CSI != nil, src.DownwardAPI != nil, src.EmptyDir != nil,
+		src.Ephemeral != nil, src.PersistentVolumeClaim != nil, src.Projected != nil, src.Secret != nil:
+		return ""
+	case src.HostPath != nil:
+		return "hostPath"
+	case src.NFS != nil:
+		return "nfs"
+	case src.ISCSI != nil:
+		return "iscsi"
+	case src.GitRepo != nil:
+		return "gitRepo"
+	case src.Image != nil:
+		return "image"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const compliantDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      securityContext:
+        runAsNonRoot: true
+        runAsUser: 64535
+        seccompProfile:
+          type: RuntimeDefault
+      volumes:
+        - name: config
+          configMap:
+            name: app
+      containers:
+        - name: app
+          image: app
+          securityContext:
+            allowPrivilegeEscalation: false
+            capabilities:
+              drop: ["ALL"]
+`
+
+const violatingDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    metadata:
+      annotations:
+        container.apparmor.security.beta.kubernetes.io/app: unconfined
+    spec:
+      hostPID: true
+      securityContext:
+        sysctls:
+          - name: kernel.msgmax
+            value: "65536"
+      volumes:
+        - name: root
+          hostPath:
+            path: /
+      initContainers:
+        - name: init
+          image: app
+          securityContext:
+            allowPrivilegeEscalation: false
+            runAsNonRoot: true
+            seccompProfile:
+              type: RuntimeDefault
+            capabilities:
+              drop: ["ALL"]
+      containers:
+        - name: app
+          image: app
+          ports:
+            - containerPort: 8080
+              hostPort: 8080
+          securityContext:
+            privileged: true
+            procMount: Unmasked
+            capabilities:
+              add: ["SYS_ADMIN", "CHOWN"]
+`
+
+func pssStorage(t *testing.T, object storage.StoreObject, namespaceLabels map[string]any) map[storage.ResourceIndex]storage.StoreObject {
+	t.Helper()
+
+	storageMap := map[storage.ResourceIndex]storage.StoreObject{
+		storage.GetResourceIndex(object): object,
+	}
+
+	if namespaceLabels != nil {
+		ns := storage.StoreObject{Unstructured: unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": "d8-test", "labels": namespaceLabels},
+		}}}
+		storageMap[storage.GetResourceIndex(ns)] = ns
+	}
+
+	return storageMap
+}
+
+func pssErrors(t *testing.T, manifest string, namespaceLabels map[string]any, excludes ...pkg.KindRuleExclude) []pkg.LinterError {
+	t.Helper()
+
+	object := storagetest.Object(t, manifest)
+	errorList := errors.NewLintRuleErrorsList()
+	NewPodSecurityStandardsRule(excludes).ObjectPodSecurityStandards(object, pssStorage(t, object, namespaceLabels), errorList)
+
+	return errorList.GetErrors()
+}
+
+func TestPodSecurityStandardsBaseline(t *testing.T) {
+	errs := pssErrors(t, violatingDeployment, map[string]any{podSecurityEnforceLabel: "baseline"})
+
+	var texts []string
+	for _, e := range errs {
+		texts = append(texts, e.ObjectID+": "+e.Text)
+	}
+
+	const prefix = `Violates the "baseline" Pod Security Standard of namespace "d8-test", control `
+
+	assert.Equal(t, []string{
+		"kind = Deployment ; name = app ; namespace = d8-test: " + prefix + `"Host Namespaces": pod sets hostPID to true`,
+		"kind = Deployment ; name = app ; namespace = d8-test: " + prefix + `"HostPath Volumes": volume "root" is a hostPath volume`,
+		"kind = Deployment ; name = app ; namespace = d8-test: " + prefix + `"Sysctls": pod sets the unsafe sysctl "kernel.msgmax"`,
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: " + prefix + `"AppArmor": annotation "container.apparmor.security.beta.kubernetes.io/app" sets the AppArmor profile "unconfined"`,
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: " + prefix + `"Host Ports": container uses hostPort 8080`,
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: " + prefix + `"Privileged Containers": container sets securityContext.privileged to true`,
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: " + prefix + `"Capabilities": container adds the capability "SYS_ADMIN"`,
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: " + prefix + `"/proc Mount Type": container sets securityContext.procMount to "Unmasked"`,
+	}, texts)
+}
+
+func TestPodSecurityStandardsRestricted(t *testing.T) {
+	errs := pssErrors(t, violatingDeployment, map[string]any{podSecurityEnforceLabel: "restricted"})
+
+	controls := map[string][]string{}
+	for _, e := range errs {
+		controls[e.ObjectID] = append(controls[e.ObjectID], e.Text)
+	}
+
+	pod := controls["kind = Deployment ; name = app ; namespace = d8-test"]
+	assert.Contains(t, pod, `Violates the "restricted" Pod Security Standard of namespace "d8-test", control "Volume Types": volume "root" has the type "hostPath"; only configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected and secret are allowed`)
+
+	app := controls["kind = Deployment ; name = app ; namespace = d8-test ; container = app"]
+	for _, text := range []string{
+		`control "Privilege Escalation": container must set securityContext.allowPrivilegeEscalation to false`,
+		`control "Running as Non-root": securityContext.runAsNonRoot must be true in the pod or the container`,
+		`control "Seccomp": securityContext.seccompProfile.type must be RuntimeDefault or Localhost in the pod or the container`,
+		`control "Capabilities": container must drop ALL capabilities`,
+		`control "Capabilities": container adds the capability "CHOWN"; only NET_BIND_SERVICE is allowed`,
+	} {
+		assert.Contains(t, app, `Violates the "restricted" Pod Security Standard of namespace "d8-test", `+text)
+	}
+
+	assert.Empty(t, controls["kind = Deployment ; name = app ; namespace = d8-test ; container = init"], "the init container is compliant")
+}
+
+func TestPodSecurityStandardsCompliant(t *testing.T) {
+	assert.Empty(t, pssErrors(t, compliantDeployment, map[string]any{podSecurityEnforceLabel: "restricted"}))
+}
+
+func TestPodSecurityStandardsLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]any
+		want   bool
+	}{
+		{name: "namespace is not rendered", labels: nil, want: false},
+		{name: "no level label", labels: map[string]any{"module": "test"}, want: false},
+		{name: "privileged", labels: map[string]any{podSecurityEnforceLabel: "privileged"}, want: false},
+		{name: "deckhouse pod policy", labels: map[string]any{podPolicyLabel: "baseline"}, want: true},
+		{name: "pod security admission label wins", labels: map[string]any{podSecurityEnforceLabel: "privileged", podPolicyLabel: "restricted"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, len(pssErrors(t, violatingDeployment, tt.labels)) > 0)
+		})
+	}
+}
+
+func TestPodSecurityStandardsExclude(t *testing.T) {
+	errs := pssErrors(t, violatingDeployment, map[string]any{podSecurityEnforceLabel: "baseline"},
+		pkg.KindRuleExclude{Kind: "Deployment", Name: "app"})
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	ProbeCorrectnessRuleName = "probe-correctness"
+
+	// Kubernetes defaults of the probe timings.
+	defaultProbePeriodSeconds    = 10
+	defaultProbeFailureThreshold = 3
+	defaultProbeTimeoutSeconds   = 1
+
+	// Default bounds of the probe timings (see pkg.ProbeCorrectnessSettings).
+	// Liveness probes failing for a shorter period restart containers that are
+	// merely slow under load.
+	defaultMinLivenessFailureSeconds = 10
+	defaultMaxProbeDetectionSeconds  = 600
+	defaultMaxStartupSeconds         = 3600
+)
+
+func NewProbeCorrectnessRule(excludeRules []pkg.ContainerRuleExclude, settings pkg.ProbeCorrectnessSettings) *ProbeCorrectnessRule {
+	return &ProbeCorrectnessRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: ProbeCorrectnessRuleName,
+		},
+		ContainerRule: pkg.ContainerRule{
+			ExcludeRules: excludeRules,
+		},
+		minLivenessFailureSeconds: orDefault(settings.MinLivenessFailureSeconds, defaultMinLivenessFailureSeconds),
+		maxDetectionSeconds:       orDefault(settings.MaxDetectionSeconds, defaultMaxProbeDetectionSeconds),
+		maxStartupSeconds:         orDefault(settings.MaxStartupSeconds, defaultMaxStartupSeconds),
+	}
+}
+
+type ProbeCorrectnessRule struct {
+	pkg.RuleMeta
+	pkg.ContainerRule
+
+	minLivenessFailureSeconds int32
+	maxDetectionSeconds       int32
+	maxStartupSeconds         int32
+}
+
+// namedProbe is a probe of a container together with its field name.
+type namedProbe struct {
+	Name  string
+	Probe *corev1.Probe
+}
+
+// CheckProbeCorrectness checks the probes of the pod spec: init containers
+// (other than sidecars) must not define probes, probe ports must refer to a
+// declared container port, the probe timings must detect failures within sane
+// bounds and a container delaying its probes for a slow start must not use
+// identical liveness and readiness probes without a startupProbe.
+func (r *ProbeCorrectnessRule) CheckProbeCorrectness(object storage.StoreObject, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	if !isSecurityContextSupportedKind(object.Unstructured.GetKind()) {
+		return
+	}
+
+	podSpec, err := object.GetPodSpec()
+	if err != nil {
+		errorList.WithObjectID(object.Identity()).
+			Errorf("GetPodSpec failed: %v", err)
+
+		return
+	}
+
+	if podSpec == nil {
+		return
+	}
+
+	for i := range podSpec.InitContainers {
+		c := &podSpec.InitContainers[i]
+
+		if !r.Enabled(object, c) {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(object.Identity() + " ; container = " + c.Name)
+
+		if isSidecarContainer(c) {
+			r.checkContainer(podSpec, c, errorListObj)
+
+			continue
+		}
+
+		for _, probe := range containerProbes(c) {
+			errorListObj.Errorf("Init container defines a %s: probes are only allowed on sidecar init containers with restartPolicy Always", probe.Name)
+		}
+	}
+
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+
+		if !r.Enabled(object, c) {
+			continue
+		}
+
+		r.checkContainer(podSpec, c, errorList.WithObjectID(object.Identity()+" ; container = "+c.Name))
+	}
+}
+
+func (r *ProbeCorrectnessRule) checkContainer(spec *corev1.PodSpec, c *corev1.Container, errorList *errors.LintRuleErrorsList) {
+	for _, probe := range containerProbes(c) {
+		checkProbePort(spec, c, probe, errorList)
+		r.checkProbeTimings(probe, errorList)
+	}
+
+	if c.LivenessProbe != nil && c.StartupProbe == nil && c.LivenessProbe.InitialDelaySeconds > 0 &&
+		reflect.DeepEqual(c.LivenessProbe, c.ReadinessProbe) {
+		errorList.Warnf("Liveness and readiness probes are identical and delayed by %ds for a slow start without a startupProbe: "+
+			"a start slower than the delay restarts the container, add a startupProbe", c.LivenessProbe.InitialDelaySeconds)
+	}
+}
+
+// checkProbePort checks that the port of an HTTP, TCP or gRPC probe refers to a
+// port the pod declares. A named port must be declared by the container itself,
+// since the kubelet resolves it there.
+func checkProbePort(spec *corev1.PodSpec, c *corev1.Container, probe namedProbe, errorList *errors.LintRuleErrorsList) {
+	var port intstr.IntOrString
+
+	switch {
+	case probe.Probe.HTTPGet != nil:
+		port = probe.Probe.HTTPGet.Port
+	case probe.Probe.TCPSocket != nil:
+		port = probe.Probe.TCPSocket.Port
+	case probe.Probe.GRPC != nil:
+		port = intstr.FromInt32(probe.Probe.GRPC.Port)
+	default:
+		return
+	}
+
+	if port.Type == intstr.String {
+		if !slices.ContainsFunc(c.Ports, func(p corev1.ContainerPort) bool { return p.Name == port.StrVal }) {
+			errorList.WithValue(port.StrVal).
+				Errorf("%s port %q is not a named port of the container", probe.Name, port.StrVal)
+		}
+
+		return
+	}
+
+	declared := slices.ContainsFunc(podContainers(spec), func(container corev1.Container) bool {
+		return slices.ContainsFunc(container.Ports, func(p corev1.ContainerPort) bool { return p.ContainerPort == port.IntVal })
+	})
+	if !declared {
+		errorList.WithValue(port.IntVal).
+			Warnf("%s port %d is not declared by any container of the pod", probe.Name, port.IntVal)
+	}
+}
+
+// checkProbeTimings checks the time a probe takes to detect a failure:
+// initialDelaySeconds + periodSeconds * failureThreshold. A timeout above the
+// period only delays the next probe, so it is a warning.
+func (r *ProbeCorrectnessRule) checkProbeTimings(probe namedProbe, errorList *errors.LintRuleErrorsList) {
+	p := probe.Probe
+
+	period := orDefault(p.PeriodSeconds, defaultProbePeriodSeconds)
+	failureThreshold := orDefault(p.FailureThreshold, defaultProbeFailureThreshold)
+	timeout := orDefault(p.TimeoutSeconds, defaultProbeTimeoutSeconds)
+
+	if timeout > period {
+		errorList.Warnf("%s timeoutSeconds %d exceeds periodSeconds %d", probe.Name, timeout, period)
+	}
+
+	failurePeriod := period * failureThreshold
+	detection := p.InitialDelaySeconds + failurePeriod
+
+	limit := r.maxDetectionSeconds
+	if probe.Name == "startupProbe" {
+		limit = r.maxStartupSeconds
+	}
+
+	if detection > limit {
+		errorList.Warnf("%s takes %ds (initialDelaySeconds + periodSeconds * failureThreshold) to detect a failure, above the maximum of %ds",
+			probe.Name, detection, limit)
+	}
+
+	if probe.Name == "livenessProbe" && failurePeriod < r.minLivenessFailureSeconds {
+		errorList.Warnf("livenessProbe restarts the container after %ds of failures (periodSeconds * failureThreshold), below the minimum of %ds",
+			failurePeriod, r.minLivenessFailureSeconds)
+	}
+}
+
+func containerProbes(c *corev1.Container) []namedProbe {
+	var probes []namedProbe
+
+	for _, probe := range []namedProbe{
+		{Name: "livenessProbe", Probe: c.LivenessProbe},
+		{Name: "readinessProbe", Probe: c.ReadinessProbe},
+		{Name: "startupProbe", Probe: c.StartupProbe},
+	} {
+		if probe.Probe != nil {
+			probes = append(probes, probe)
+		}
+	}
+
+	return probes
+}
+
+// isSidecarContainer reports whether the init container is a sidecar that runs
+// for the lifetime of the pod.
+func isSidecarContainer(c *corev1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+func orDefault(value, def int32) int32 {
+	if value == 0 {
+		return def
+	}
+
+	return value
+}
+
+func (r *ProbeCorrectnessRule) Enabled(object storage.StoreObject, container *corev1.Container) bool {
+	for _, rule := range r.ExcludeRules {
+		if !rule.Enabled(object, container) {
+			return false
+		}
+	}
+
+	return true
+}
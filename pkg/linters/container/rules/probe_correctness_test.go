@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const probedDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: init
+      containers:
+        - name: app
+          image: app
+          ports:
+            - name: http
+              containerPort: 8080
+            - name: grpc
+              containerPort: 9090
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+          readinessProbe:
+            tcpSocket:
+              port: 8080
+          startupProbe:
+            grpc:
+              port: 9090
+            periodSeconds: 5
+            failureThreshold: 60
+`
+
+func probeErrors(t *testing.T, manifest string, settings pkg.ProbeCorrectnessSettings, excludes ...pkg.ContainerRuleExclude) []string {
+	t.Helper()
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewProbeCorrectnessRule(excludes, settings).CheckProbeCorrectness(storagetest.Object(t, manifest), errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Level.String()+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestProbeCorrectnessCompliant(t *testing.T) {
+	assert.Empty(t, probeErrors(t, probedDeployment, pkg.ProbeCorrectnessSettings{}))
+}
+
+func TestProbeCorrectnessPorts(t *testing.T) {
+	manifest := storagetest.Patch(t, probedDeployment, "              port: http\n", "              port: metrics\n")
+	manifest = storagetest.Patch(t, manifest, "              port: 8080\n", "              port: 8081\n")
+
+	assert.Equal(t, []string{
+		`error: livenessProbe port "metrics" is not a named port of the container`,
+		"warn: readinessProbe port 8081 is not declared by any container of the pod",
+	}, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{}))
+}
+
+func TestProbeCorrectnessTimings(t *testing.T) {
+	manifest := storagetest.Patch(t, probedDeployment, "              port: http\n", "              port: http\n            periodSeconds: 2\n            timeoutSeconds: 3\n")
+	manifest = storagetest.Patch(t, manifest, "            failureThreshold: 60\n", "            failureThreshold: 1000\n")
+
+	assert.Equal(t, []string{
+		"warn: livenessProbe restarts the container after 6s of failures (periodSeconds * failureThreshold), below the minimum of 10s",
+		"warn: livenessProbe timeoutSeconds 3 exceeds periodSeconds 2",
+		"warn: startupProbe takes 5000s (initialDelaySeconds + periodSeconds * failureThreshold) to detect a failure, above the maximum of 3600s",
+	}, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{}))
+
+	assert.Equal(t, []string{
+		"warn: livenessProbe takes 6s (initialDelaySeconds + periodSeconds * failureThreshold) to detect a failure, above the maximum of 5s",
+		"warn: livenessProbe timeoutSeconds 3 exceeds periodSeconds 2",
+		"warn: readinessProbe takes 30s (initialDelaySeconds + periodSeconds * failureThreshold) to detect a failure, above the maximum of 5s",
+	}, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{MinLivenessFailureSeconds: 5, MaxDetectionSeconds: 5, MaxStartupSeconds: 6000}),
+		"the bounds are configurable")
+}
+
+func TestProbeCorrectnessIdenticalProbes(t *testing.T) {
+	const probe = `            httpGet:
+              path: /healthz
+              port: http
+            initialDelaySeconds: 60
+`
+
+	manifest := storagetest.Patch(t, probedDeployment, "            tcpSocket:\n              port: 8080\n", probe)
+	manifest = storagetest.Patch(t, manifest, "            httpGet:\n              path: /healthz\n              port: http\n", probe)
+	assert.Empty(t, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{}), "the startupProbe covers the slow start")
+
+	noStartup := manifest[:strings.Index(manifest, "          startupProbe:")]
+	assert.Equal(t, []string{
+		"warn: Liveness and readiness probes are identical and delayed by 60s for a slow start without a startupProbe: " +
+			"a start slower than the delay restarts the container, add a startupProbe",
+	}, probeErrors(t, noStartup, pkg.ProbeCorrectnessSettings{}))
+}
+
+func TestProbeCorrectnessInitContainers(t *testing.T) {
+	manifest := storagetest.Patch(t, probedDeployment, "          image: init\n", "          image: init\n          readinessProbe:\n            exec:\n              command: [\"true\"]\n")
+	assert.Equal(t, []string{
+		"error: Init container defines a readinessProbe: probes are only allowed on sidecar init containers with restartPolicy Always",
+	}, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{}))
+
+	sidecar := storagetest.Patch(t, manifest, "          image: init\n", "          image: init\n          restartPolicy: Always\n")
+	assert.Empty(t, probeErrors(t, sidecar, pkg.ProbeCorrectnessSettings{}))
+}
+
+func TestProbeCorrectnessExclude(t *testing.T) {
+	manifest := storagetest.Patch(t, probedDeployment, "              port: http\n", "              port: metrics\n")
+	assert.Empty(t, probeErrors(t, manifest, pkg.ProbeCorrectnessSettings{}, pkg.ContainerRuleExclude{Kind: "Deployment", Name: "app", Container: "app"}))
+}
@@ -59,7 +59,7 @@ func NewDistrolessRule(cfg *pkg.ImageLinterConfig) *DistrolessRule {
 
 func (r *DistrolessRule) CheckImageNamesInDockerFiles(modulePath string, errorList *errors.LintRuleErrorsList) {
 	imagesPath := filepath.Join(modulePath, ImagesDir)
-	if !fsutils.IsFile(imagesPath) {
+	if !fsutils.IsDir(imagesPath) {
 		return
 	}
 
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestDistrolessRuleFinalImage(t *testing.T) {
+	modulePath := writeDockerfile(t, "app", "FROM $BASE_GOLANG_ALPINE AS build\nFROM $BASE_UBUNTU\n")
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewDistrolessRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "distroless", errs[0].RuleID)
+	assert.Equal(t, "Last `FROM` instruction should use one of our $BASE_DISTROLESS images", errs[0].Text)
+	assert.Equal(t, "app/Dockerfile", errs[0].FilePath)
+	assert.Equal(t, "$BASE_UBUNTU", errs[0].ObjectValue)
+}
+
+func TestDistrolessRuleIntermediateImage(t *testing.T) {
+	modulePath := writeDockerfile(t, "app", "FROM golang:1.22 AS build\n"+
+		"FROM golang:1.22@sha256:"+strings.Repeat("0", 64)+" AS test\n"+
+		"FROM $BASE_DISTROLESS\n")
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewDistrolessRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "golang:1.22 AS build", errs[0].ObjectValue)
+	assert.Contains(t, errs[0].Text, "Intermediate `FROM` instructions should use one of our $BASE_ images")
+}
+
+func TestDistrolessRuleDistrolessImages(t *testing.T) {
+	for _, final := range []string{"$BASE_DISTROLESS", "$BASE_ALT_P11", "scratch"} {
+		t.Run(final, func(t *testing.T) {
+			modulePath := writeDockerfile(t, "app", "FROM $BASE_GOLANG_ALPINE AS build\nFROM "+final+"\n")
+
+			errorList := errors.NewLintRuleErrorsList()
+			NewDistrolessRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+			assert.Empty(t, errorList.GetErrors())
+		})
+	}
+}
+
+func TestDistrolessRuleExcludedImage(t *testing.T) {
+	modulePath := writeDockerfile(t, "legacy", "FROM $BASE_UBUNTU\n")
+
+	cfg := &pkg.ImageLinterConfig{}
+	cfg.ExcludeRules.SkipDistrolessFilePathPrefix = pkg.PrefixRuleExcludeList{"legacy"}
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewDistrolessRule(cfg).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, pkg.Warn, errs[0].Level)
+	assert.Equal(t, "WARNING!!! SKIP DISTROLESS CHECK!!!", errs[0].Text)
+}
+
+func TestDistrolessRuleWithoutImages(t *testing.T) {
+	errorList := errors.NewLintRuleErrorsList()
+	NewDistrolessRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(t.TempDir(), errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
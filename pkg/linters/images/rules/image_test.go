@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// writeDockerfile creates images/<image>/Dockerfile in a new module directory
+// and returns the module path.
+func writeDockerfile(t *testing.T, image, content string) string {
+	t.Helper()
+
+	modulePath := t.TempDir()
+	dir := filepath.Join(modulePath, ImagesDir, image)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(content), 0o600))
+
+	return modulePath
+}
+
+func TestImageRuleUpstreamImage(t *testing.T) {
+	modulePath := writeDockerfile(t, "app", "FROM $BASE_GOLANG_ALPINE AS build\nFROM ubuntu:22.04\n")
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImageRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "dockerfile", errs[0].RuleID)
+	assert.Equal(t, "Please use $BASE_UBUNTU as an image name", errs[0].Text)
+	assert.Equal(t, "app/Dockerfile", errs[0].FilePath)
+	assert.Equal(t, 2, errs[0].LineNumber)
+}
+
+func TestImageRuleBaseImages(t *testing.T) {
+	modulePath := writeDockerfile(t, "app", "FROM $BASE_GOLANG_ALPINE AS build\nFROM $BASE_DISTROLESS\n")
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImageRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
+
+func TestImageRuleExcludedImage(t *testing.T) {
+	modulePath := writeDockerfile(t, "legacy", "FROM ubuntu:22.04\n")
+
+	cfg := &pkg.ImageLinterConfig{}
+	cfg.ExcludeRules.SkipDistrolessFilePathPrefix = pkg.PrefixRuleExcludeList{"legacy"}
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewImageRule(cfg).CheckImageNamesInDockerFiles(modulePath, errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
+
+func TestImageRuleWithoutImages(t *testing.T) {
+	errorList := errors.NewLintRuleErrorsList()
+	NewImageRule(&pkg.ImageLinterConfig{}).CheckImageNamesInDockerFiles(t.TempDir(), errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
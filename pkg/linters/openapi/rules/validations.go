@@ -20,10 +20,10 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/google/cel-go/cel"
 	"sigs.k8s.io/yaml"
 
 	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/openapi"
 	"github.com/deckhouse/dmt/pkg"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
@@ -32,21 +32,13 @@ const DeckhouseValidationsRuleName = "deckhouse-validations"
 
 const (
 	// deckhouseValidationsKey is the OpenAPI extension that carries Deckhouse CEL
-	// validation rules for a module's config-values / values schema. It is the
-	// dmt-side mirror of deckhouse-controller's
-	// internal/packages/values/schema/cel (const ruleKey).
-	deckhouseValidationsKey = "x-deckhouse-validations"
+	// validation rules for a module's config-values / values schema.
+	deckhouseValidationsKey = openapi.ValidationsKey
 	// kubernetesValidationsKey is the upstream Kubernetes CEL-validation extension.
 	// It is honored by the API server for CRDs, but Deckhouse module config-values /
 	// values schemas are validated by deckhouse-controller, which only reads
 	// x-deckhouse-validations. So this key in an openapi/ schema is silently ignored.
 	kubernetesValidationsKey = "x-kubernetes-validations"
-
-	// celSelfVar / celOldSelfVar are the variables deckhouse-controller exposes to
-	// x-deckhouse-validations expressions; we declare the same ones so an expression
-	// that compiles here compiles there.
-	celSelfVar    = "self"
-	celOldSelfVar = "oldSelf"
 )
 
 // DeckhouseValidationsRule validates the Deckhouse/Kubernetes CEL-validation
@@ -163,7 +155,7 @@ func (r *DeckhouseValidationsRule) checkDeckhouseValidations(filePath, path stri
 	// an expression accepted here is accepted there. self/oldSelf are dynamically
 	// typed because the concrete values are unknown at lint time — this is the most
 	// permissive binding and will not reject an expression the controller accepts.
-	env, envErr := newValidationsCELEnv()
+	env, envErr := openapi.NewValidationsCELEnv()
 
 	for i := range list {
 		entryPath := fmt.Sprintf("%s[%d]", path, i)
@@ -196,15 +188,6 @@ func (r *DeckhouseValidationsRule) checkDeckhouseValidations(filePath, path stri
 	}
 }
 
-// newValidationsCELEnv mirrors the environment deckhouse-controller compiles
-// x-deckhouse-validations expressions in: self and oldSelf, dynamically typed.
-func newValidationsCELEnv() (*cel.Env, error) {
-	return cel.NewEnv(
-		cel.Variable(celSelfVar, cel.DynType),
-		cel.Variable(celOldSelfVar, cel.DynType),
-	)
-}
-
 // nonEmptyStringField reports whether key holds a non-empty string, returning it.
 func nonEmptyStringField(m map[string]any, key string) (string, bool) {
 	v, found := m[key]
@@ -21,7 +21,6 @@ import (
 
 	"github.com/iancoleman/strcase"
 
-	"github.com/deckhouse/dmt/internal/modules"
 	"github.com/deckhouse/dmt/pkg"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
@@ -46,8 +45,8 @@ type UserAuthZRule struct {
 objectUserAuthzClusterRolePath validates that files for user-authz contains only cluster roles.
 Also, it validates that role names equals to d8:user-authz:<ChartName>:<AccessLevel>
 */
-func (*UserAuthZRule) ObjectUserAuthzClusterRolePath(m *modules.Module, errorList *errors.LintRuleErrorsList) {
-	errorList = errorList.WithModule(m.GetName())
+func (r *UserAuthZRule) ObjectUserAuthzClusterRolePath(m pkg.Module, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName()).WithModule(m.GetName())
 
 	for _, object := range m.GetStorage() {
 		errorListObj := errorList.WithObjectID(object.Identity()).WithFilePath(object.GetPath())
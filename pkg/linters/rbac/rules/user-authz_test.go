@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/mocks"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const userAuthzClusterRole = `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: d8:user-authz:test:user
+  annotations:
+    user-authz.deckhouse.io/access-level: User
+`
+
+func userAuthzErrors(t *testing.T, manifest string) []string {
+	t.Helper()
+
+	object := map[string]any{}
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &object))
+
+	store := storage.NewUnstructuredObjectStore()
+	require.NoError(t, store.Put("/module/"+UserAuthzClusterRolePath, UserAuthzClusterRolePath, object, []byte(manifest)))
+
+	mc := minimock.NewController(t)
+
+	mod := mocks.NewModuleMock(mc)
+	mod.GetNameMock.Return("test")
+	mod.GetStorageMock.Return(store.Storage)
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewUserAuthZRule().ObjectUserAuthzClusterRolePath(mod, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		assert.Equal(t, UserAuthZRuleName, e.RuleID)
+		texts = append(texts, e.Text)
+	}
+
+	return texts
+}
+
+func TestUserAuthzClusterRole(t *testing.T) {
+	assert.Empty(t, userAuthzErrors(t, userAuthzClusterRole))
+}
+
+func TestUserAuthzOnlyClusterRoles(t *testing.T) {
+	errs := userAuthzErrors(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: d8:user-authz:test:user
+  namespace: d8-test
+`)
+
+	assert.Equal(t, []string{`Only ClusterRoles can be specified in "templates/user-authz-cluster-roles.yaml"`}, errs)
+}
+
+func TestUserAuthzAccessLevelAnnotation(t *testing.T) {
+	errs := userAuthzErrors(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: d8:user-authz:test:user
+`)
+
+	assert.Equal(t, []string{`User-authz access ClusterRoles should have annotation "user-authz.deckhouse.io/access-level"`}, errs)
+}
+
+func TestUserAuthzClusterRoleName(t *testing.T) {
+	errs := userAuthzErrors(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: d8:user-authz:test:reader
+  annotations:
+    user-authz.deckhouse.io/access-level: PrivilegedUser
+`)
+
+	assert.Equal(t, []string{`Name of user-authz ClusterRoles should be "d8:user-authz:test:privileged-user"`}, errs)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	GlobalValuesRuleName = "global-values"
+)
+
+// globalValuesRe matches a dotted reference into the global values, e.g.
+// `.Values.global.discovery.kubernetesVersion` or `$.Values.global.modules.https`,
+// capturing the path after "global".
+var globalValuesRe = regexp.MustCompile(`\.Values\.global((?:\.[A-Za-z_][A-Za-z0-9_]*)+)`)
+
+// GlobalValuesRule reports template references to global values that the
+// global values schema the module is linted against does not define. The
+// schema follows the Deckhouse version the module targets (see
+// values.GlobalSchemaResolver), so a reference to a value that only newer
+// Deckhouse releases provide is caught before it renders empty in a cluster.
+type GlobalValuesRule struct {
+	pkg.RuleMeta
+}
+
+func NewGlobalValuesRule() *GlobalValuesRule {
+	return &GlobalValuesRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: GlobalValuesRuleName,
+		},
+	}
+}
+
+func (r *GlobalValuesRule) CheckGlobalValuesReferences(m pkg.Module, globalSchema *values.GlobalSchema, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	if globalSchema == nil || globalSchema.Schema == nil {
+		return
+	}
+
+	templatesPath := filepath.Join(m.GetPath(), "templates")
+	if _, err := os.Stat(templatesPath); os.IsNotExist(err) {
+		return
+	}
+
+	files := fsutils.GetFiles(templatesPath, true, fsutils.FilterFileByExtensions(".yaml", ".yml", ".tpl"))
+
+	for _, filePath := range files {
+		relPath := fsutils.Rel(m.GetPath(), filePath)
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			errorList.WithFilePath(relPath).Errorf("Failed to read file: %v", err)
+			continue
+		}
+
+		reported := make(map[string]struct{})
+
+		for _, match := range globalValuesRe.FindAllSubmatchIndex(content, -1) {
+			path := strings.Split(strings.TrimPrefix(string(content[match[2]:match[3]]), "."), ".")
+
+			missing, ok := missingGlobalPath(globalSchema.Schema, path)
+			if ok {
+				continue
+			}
+
+			ref := ".Values.global." + strings.Join(missing, ".")
+			if _, dup := reported[ref]; dup {
+				continue
+			}
+
+			reported[ref] = struct{}{}
+
+			line := bytes.Count(content[:match[0]], []byte("\n")) + 1
+
+			errorList.
+				WithFilePath(relPath).
+				WithLineNumber(line).
+				WithValue(ref).
+				Errorf("Template references %s, which is not defined in the global values schema (%s)", ref, globalSchema.Source)
+		}
+	}
+}
+
+// missingGlobalPath walks path through the schema. It returns true when the
+// schema defines the path or cannot tell (a free-form object, an object with
+// additionalProperties); otherwise it returns the path up to and including the
+// first undefined key.
+func missingGlobalPath(s *spec.Schema, path []string) ([]string, bool) {
+	for i, key := range path {
+		next, defined := schemaProperty(s, key)
+		if !defined {
+			return path[:i+1], false
+		}
+
+		if next == nil {
+			return nil, true
+		}
+
+		s = next
+	}
+
+	return nil, true
+}
+
+// schemaProperty returns the schema of the key property of s. A nil schema with
+// true means s accepts the key without describing it.
+func schemaProperty(s *spec.Schema, key string) (*spec.Schema, bool) {
+	if prop, ok := s.Properties[key]; ok {
+		return &prop, true
+	}
+
+	for _, group := range [][]spec.Schema{s.AllOf, s.AnyOf, s.OneOf} {
+		for i := range group {
+			if prop, ok := schemaProperty(&group[i], key); ok {
+				return prop, true
+			}
+		}
+	}
+
+	if ap := s.AdditionalProperties; ap != nil {
+		if ap.Schema != nil {
+			return ap.Schema, true
+		}
+
+		if ap.Allows {
+			return nil, true
+		}
+	}
+
+	// A schema without any properties (a free-form object, or a scalar) does not
+	// constrain what is read from it.
+	if len(s.Properties) == 0 && len(s.AllOf) == 0 && len(s.AnyOf) == 0 && len(s.OneOf) == 0 {
+		return nil, true
+	}
+
+	return nil, false
+}
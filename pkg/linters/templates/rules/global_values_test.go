@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/mocks"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const globalValuesTestSchema = `
+type: object
+additionalProperties: false
+properties:
+  highAvailability:
+    type: boolean
+  discovery:
+    type: object
+    additionalProperties: true
+    properties:
+      kubernetesVersion:
+        type: string
+  modules:
+    type: object
+    additionalProperties: false
+    properties:
+      https:
+        type: object
+        additionalProperties: false
+        properties:
+          mode:
+            type: string
+      placement:
+        type: object
+  modulesImages:
+    type: object
+    properties:
+      digests:
+        type: object
+        additionalProperties:
+          type: object
+          additionalProperties:
+            type: string
+`
+
+func TestGlobalValuesRule_CheckGlobalValuesReferences(t *testing.T) {
+	schema := new(spec.Schema)
+	require.NoError(t, yaml.Unmarshal([]byte(globalValuesTestSchema), schema))
+
+	globalSchema := &values.GlobalSchema{Schema: schema, Source: "Deckhouse v1.68 (bundled)"}
+
+	modulePath := writeCRDModule(t, moduleYAMLNoRequirement, map[string]string{
+		"templates/deployment.yaml": `replicas: {{ .Values.global.highAvailability | ternary 2 1 }}
+{{- if semverCompare ">=1.30" .Values.global.discovery.kubernetesVersion }}
+{{- end }}
+host: {{ .Values.global.discovery.anythingGoes }}
+scheme: {{ $.Values.global.modules.https.mode }}
+certificate: {{ .Values.global.modules.https.certManager.clusterIssuerName }}
+tolerations: {{ .Values.global.modules.placement.customTolerationKeys }}
+image: {{ index .Values.global.modulesImages.digests.common "kubeRbacProxy" }}
+`,
+		"templates/_helpers.tpl": `{{- define "x" }}{{ .Values.global.clusterConfiguration.clusterDomain }}{{ .Values.global.clusterConfiguration.podSubnetCIDR }}{{ end }}`,
+	})
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewGlobalValuesRule().CheckGlobalValuesReferences(crdMockModule(t, modulePath), globalSchema, errorList)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 2)
+
+	byValue := make(map[any]string)
+	for _, e := range errs {
+		assert.Equal(t, GlobalValuesRuleName, e.RuleID)
+		byValue[e.ObjectValue] = e.Text
+	}
+
+	assert.Contains(t, byValue[".Values.global.modules.https.certManager"], "(Deckhouse v1.68 (bundled))")
+	assert.Contains(t, byValue, ".Values.global.clusterConfiguration", "a missing key is reported once per file")
+
+	for _, e := range errs {
+		if e.ObjectValue == ".Values.global.modules.https.certManager" {
+			assert.Equal(t, "templates/deployment.yaml", e.FilePath)
+			assert.Equal(t, 6, e.LineNumber)
+		}
+	}
+}
+
+func TestGlobalValuesRule_NoSchema(t *testing.T) {
+	errorList := errors.NewLintRuleErrorsList()
+	NewGlobalValuesRule().CheckGlobalValuesReferences(mocks.NewModuleMock(minimock.NewController(t)), nil, errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	HighAvailabilityRuleName = "high-availability"
+
+	hostnameTopologyKey = "kubernetes.io/hostname"
+)
+
+func NewHighAvailabilityRule(excludeRules []pkg.KindRuleExclude) *HighAvailabilityRule {
+	return &HighAvailabilityRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: HighAvailabilityRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+	}
+}
+
+type HighAvailabilityRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+}
+
+// haController is a Deployment or StatefulSet as rendered in the
+// high-availability scenario.
+type haController struct {
+	object   storage.StoreObject
+	replicas int32
+	template corev1.PodTemplateSpec
+	// maxUnavailable is the rolling update budget; nil when the update strategy
+	// does not take pods down in batches (StatefulSet defaults, OnDelete).
+	maxUnavailable *intstr.IntOrString
+	// recreate is set for a Deployment with the Recreate strategy.
+	recreate bool
+}
+
+// ControllersMustBeHighlyAvailable checks that the Deployments and StatefulSets
+// running more than one replica in the high-availability scenario spread their
+// pods across nodes, are covered by a PodDisruptionBudget that can be satisfied
+// and are not updated by taking all replicas down at once. The scenario is an
+// extra render of the module, so it is skipped when the rule is ignored.
+func (r *HighAvailabilityRule) ControllersMustBeHighlyAvailable(md *modules.Module, errorList *errors.LintRuleErrorsList) {
+	if errorList.Ignored() {
+		return
+	}
+
+	errorList = errorList.WithRule(r.GetName())
+
+	objects, err := md.GetHAStorage(errorList)
+	if err != nil {
+		errorList.WithFilePath(md.GetPath()).Errorf("Cannot check high-availability topology: %s", err)
+
+		return
+	}
+
+	r.checkControllers(objects, errorList)
+}
+
+func (r *HighAvailabilityRule) checkControllers(objects map[storage.ResourceIndex]storage.StoreObject, errorList *errors.LintRuleErrorsList) {
+	// PDB parse errors are reported by the pdb rule.
+	pdbSelectors := collectPDBSelectors(objects, errors.NewLintRuleErrorsList())
+
+	for _, object := range objects {
+		if !isPodController(object.Unstructured.GetKind()) {
+			continue
+		}
+
+		if !r.Enabled(object.Unstructured.GetKind(), object.Unstructured.GetName()) {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(object.Identity()).WithFilePath(object.GetPath())
+
+		controller, err := parseHAController(object)
+		if err != nil {
+			errorListObj.Errorf("Cannot parse pod controller: %s", err)
+
+			continue
+		}
+
+		if controller.replicas < 2 {
+			continue
+		}
+
+		checkPodSpread(controller, errorListObj)
+		checkPDBSatisfiable(controller, pdbSelectors, errorListObj)
+		checkRollingUpdate(controller, errorListObj)
+	}
+}
+
+func parseHAController(object storage.StoreObject) (*haController, error) {
+	content := object.Unstructured.UnstructuredContent()
+	converter := runtime.DefaultUnstructuredConverter
+
+	c := &haController{object: object, replicas: 1}
+
+	switch object.Unstructured.GetKind() {
+	case "Deployment":
+		deployment := new(appsv1.Deployment)
+		if err := converter.FromUnstructured(content, deployment); err != nil {
+			return nil, err
+		}
+
+		if deployment.Spec.Replicas != nil {
+			c.replicas = *deployment.Spec.Replicas
+		}
+
+		c.template = deployment.Spec.Template
+
+		switch strategy := deployment.Spec.Strategy; strategy.Type {
+		case appsv1.RecreateDeploymentStrategyType:
+			c.recreate = true
+		default:
+			// The API server defaults maxUnavailable to 25%.
+			maxUnavailable := intstr.FromString("25%")
+			if strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxUnavailable != nil {
+				maxUnavailable = *strategy.RollingUpdate.MaxUnavailable
+			}
+
+			c.maxUnavailable = &maxUnavailable
+		}
+	case "StatefulSet":
+		statefulSet := new(appsv1.StatefulSet)
+		if err := converter.FromUnstructured(content, statefulSet); err != nil {
+			return nil, err
+		}
+
+		if statefulSet.Spec.Replicas != nil {
+			c.replicas = *statefulSet.Spec.Replicas
+		}
+
+		c.template = statefulSet.Spec.Template
+
+		strategy := statefulSet.Spec.UpdateStrategy
+		if strategy.Type != appsv1.OnDeleteStatefulSetStrategyType && strategy.RollingUpdate != nil {
+			c.maxUnavailable = strategy.RollingUpdate.MaxUnavailable
+		}
+	default:
+		return nil, fmt.Errorf("object of kind %s is not a pod controller", object.Unstructured.GetKind())
+	}
+
+	return c, nil
+}
+
+// checkPodSpread requires a pod anti-affinity or a topology spread constraint on
+// the node hostname that selects the controller's own pods.
+func checkPodSpread(c *haController, errorList *errors.LintRuleErrorsList) {
+	podLabels := labels.Set(c.template.Labels)
+	spec := &c.template.Spec
+
+	if affinity := spec.Affinity; affinity != nil && affinity.PodAntiAffinity != nil {
+		antiAffinity := affinity.PodAntiAffinity
+
+		for i := range antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if spreadsPods(&antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i], podLabels) {
+				return
+			}
+		}
+
+		for i := range antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			if spreadsPods(&antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i].PodAffinityTerm, podLabels) {
+				return
+			}
+		}
+	}
+
+	for i := range spec.TopologySpreadConstraints {
+		constraint := &spec.TopologySpreadConstraints[i]
+		if constraint.TopologyKey == hostnameTopologyKey && selectsPods(constraint.LabelSelector, podLabels) {
+			return
+		}
+	}
+
+	errorList.WithValue(c.replicas).
+		Errorf("Controller runs %d replicas in the high-availability scenario but does not spread them across nodes: no pod anti-affinity or topologySpreadConstraints on %q select its pods", c.replicas, hostnameTopologyKey)
+}
+
+func spreadsPods(term *corev1.PodAffinityTerm, podLabels labels.Set) bool {
+	return term.TopologyKey == hostnameTopologyKey && selectsPods(term.LabelSelector, podLabels)
+}
+
+func selectsPods(selector *v1.LabelSelector, podLabels labels.Set) bool {
+	if selector == nil {
+		return false
+	}
+
+	sel, err := v1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+
+	return !sel.Empty() && sel.Matches(podLabels)
+}
+
+// checkPDBSatisfiable reports the PodDisruptionBudgets of the controller that
+// allow no voluntary disruption at all, so a node drain can never evict a pod.
+// A controller without a PDB is reported by the pdb rule.
+func checkPDBSatisfiable(c *haController, selectors []nsLabelSelector, errorList *errors.LintRuleErrorsList) {
+	podNamespace := c.object.Unstructured.GetNamespace()
+	podLabels := labels.Set(c.template.Labels)
+	replicas := int(c.replicas)
+
+	for _, sel := range selectors {
+		if sel.pdb == nil || !sel.Matches(podNamespace, podLabels) {
+			continue
+		}
+
+		spec := sel.pdb.Spec
+
+		switch {
+		case spec.MinAvailable != nil:
+			minAvailable, err := intstr.GetScaledValueFromIntOrPercent(spec.MinAvailable, replicas, true)
+			if err == nil && minAvailable >= replicas {
+				errorList.WithValue(spec.MinAvailable.String()).
+					Errorf("PodDisruptionBudget %q requires minAvailable %d of %d replicas in the high-availability scenario, so no pod can ever be evicted", sel.pdb.Name, minAvailable, replicas)
+			}
+		case spec.MaxUnavailable != nil:
+			maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(spec.MaxUnavailable, replicas, true)
+			if err == nil && maxUnavailable < 1 {
+				errorList.WithValue(spec.MaxUnavailable.String()).
+					Errorf("PodDisruptionBudget %q allows maxUnavailable %d of %d replicas in the high-availability scenario, so no pod can ever be evicted", sel.pdb.Name, maxUnavailable, replicas)
+			}
+		}
+	}
+}
+
+// checkRollingUpdate reports update strategies that take every replica down at
+// once.
+func checkRollingUpdate(c *haController, errorList *errors.LintRuleErrorsList) {
+	if c.recreate {
+		errorList.WithValue(appsv1.RecreateDeploymentStrategyType).
+			Errorf("Controller runs %d replicas in the high-availability scenario but uses the Recreate strategy, which takes all replicas down at once", c.replicas)
+
+		return
+	}
+
+	if c.maxUnavailable == nil {
+		return
+	}
+
+	// Deployments round maxUnavailable down.
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(c.maxUnavailable, int(c.replicas), false)
+	if err == nil && maxUnavailable >= int(c.replicas) {
+		errorList.WithValue(c.maxUnavailable.String()).
+			Errorf("Rolling update maxUnavailable allows all %d replicas down at once in the high-availability scenario", c.replicas)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const haDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  replicas: 3
+  strategy:
+    rollingUpdate:
+      maxUnavailable: 1
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      affinity:
+        podAntiAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            - topologyKey: kubernetes.io/hostname
+              labelSelector:
+                matchLabels:
+                  app: app
+      containers:
+        - name: app
+          image: app
+`
+
+const haPDB = `
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  maxUnavailable: 1
+  selector:
+    matchLabels:
+      app: app
+`
+
+func haErrors(t *testing.T, excludes []pkg.KindRuleExclude, manifests ...string) []string {
+	t.Helper()
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewHighAvailabilityRule(excludes).checkControllers(storagetest.Objects(t, manifests...), errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	return texts
+}
+
+func TestHighAvailabilityCompliant(t *testing.T) {
+	assert.Empty(t, haErrors(t, nil, haDeployment, haPDB))
+
+	spread := storagetest.Patch(t, haDeployment, `      affinity:
+        podAntiAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            - topologyKey: kubernetes.io/hostname
+              labelSelector:`, `      topologySpreadConstraints:
+        - topologyKey: kubernetes.io/hostname
+          maxSkew: 1
+          whenUnsatisfiable: DoNotSchedule
+          labelSelector:`)
+	assert.Empty(t, haErrors(t, nil, spread, haPDB), "topologySpreadConstraints spread pods too")
+}
+
+func TestHighAvailabilitySingleReplica(t *testing.T) {
+	single := storagetest.Patch(t, haDeployment, "replicas: 3", "replicas: 1")
+	single = storagetest.Patch(t, single, "kubernetes.io/hostname", "topology.kubernetes.io/zone")
+
+	assert.Empty(t, haErrors(t, nil, single), "controllers with a single replica are not checked")
+}
+
+func TestHighAvailabilityNoSpread(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment string
+	}{
+		{name: "zone topology", deployment: storagetest.Patch(t, haDeployment, "kubernetes.io/hostname", "topology.kubernetes.io/zone")},
+		{name: "selector of other pods", deployment: storagetest.Patch(t, haDeployment, "                  app: app", "                  app: other")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, []string{
+				`Controller runs 3 replicas in the high-availability scenario but does not spread them across nodes: no pod anti-affinity or topologySpreadConstraints on "kubernetes.io/hostname" select its pods`,
+			}, haErrors(t, nil, tt.deployment, haPDB))
+		})
+	}
+}
+
+func TestHighAvailabilityPDB(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []string
+	}{
+		{name: "minAvailable below replicas", spec: "minAvailable: 2"},
+		{name: "minAvailable percentage", spec: "minAvailable: 50%"},
+		{
+			name: "minAvailable equals replicas",
+			spec: "minAvailable: 3",
+			want: []string{`PodDisruptionBudget "app" requires minAvailable 3 of 3 replicas in the high-availability scenario, so no pod can ever be evicted`},
+		},
+		{
+			name: "minAvailable 100%",
+			spec: "minAvailable: 100%",
+			want: []string{`PodDisruptionBudget "app" requires minAvailable 3 of 3 replicas in the high-availability scenario, so no pod can ever be evicted`},
+		},
+		{
+			name: "maxUnavailable zero",
+			spec: "maxUnavailable: 0",
+			want: []string{`PodDisruptionBudget "app" allows maxUnavailable 0 of 3 replicas in the high-availability scenario, so no pod can ever be evicted`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdb := storagetest.Patch(t, haPDB, "maxUnavailable: 1", tt.spec)
+			assert.Equal(t, tt.want, haErrors(t, nil, haDeployment, pdb))
+		})
+	}
+}
+
+func TestHighAvailabilityRollingUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		want     []string
+	}{
+		{name: "default strategy", strategy: ""},
+		{name: "half of replicas", strategy: "  strategy:\n    rollingUpdate:\n      maxUnavailable: 50%\n"},
+		{
+			name:     "all replicas",
+			strategy: "  strategy:\n    rollingUpdate:\n      maxUnavailable: 100%\n",
+			want:     []string{"Rolling update maxUnavailable allows all 3 replicas down at once in the high-availability scenario"},
+		},
+		{
+			name:     "recreate",
+			strategy: "  strategy:\n    type: Recreate\n",
+			want:     []string{"Controller runs 3 replicas in the high-availability scenario but uses the Recreate strategy, which takes all replicas down at once"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := storagetest.Patch(t, haDeployment, "  strategy:\n    rollingUpdate:\n      maxUnavailable: 1\n", tt.strategy)
+			assert.Equal(t, tt.want, haErrors(t, nil, deployment, haPDB))
+		})
+	}
+}
+
+func TestHighAvailabilityStatefulSet(t *testing.T) {
+	statefulSet := storagetest.Patch(t, haDeployment, "kind: Deployment", "kind: StatefulSet")
+	statefulSet = storagetest.Patch(t, statefulSet, "  strategy:\n    rollingUpdate:\n      maxUnavailable: 1\n",
+		"  updateStrategy:\n    rollingUpdate:\n      maxUnavailable: 3\n")
+
+	assert.Equal(t, []string{
+		"Rolling update maxUnavailable allows all 3 replicas down at once in the high-availability scenario",
+	}, haErrors(t, nil, statefulSet, haPDB))
+}
+
+func TestHighAvailabilityExclude(t *testing.T) {
+	deployment := storagetest.Patch(t, haDeployment, "kubernetes.io/hostname", "topology.kubernetes.io/zone")
+
+	assert.Empty(t, haErrors(t, []pkg.KindRuleExclude{{Kind: "Deployment", Name: "app"}}, deployment, haPDB))
+}
+
+func TestHighAvailabilityIgnored(t *testing.T) {
+	errorList := errors.NewLintRuleErrorsList().WithMaxLevel(ptr.To(pkg.Ignored))
+
+	// The module is not rendered at all: a nil module would panic otherwise.
+	NewHighAvailabilityRule(nil).ControllersMustBeHighlyAvailable(nil, errorList)
+
+	assert.Empty(t, errorList.GetErrors())
+}
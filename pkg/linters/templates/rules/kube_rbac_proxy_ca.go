@@ -58,21 +58,20 @@ func (r *KubeRbacProxyRule) NamespaceMustContainKubeRBACProxyCA(objectStore *sto
 	}
 
 	for index, object := range objectStore.Storage {
-		if !strings.HasPrefix(index.Namespace, "d8-") {
+		// Namespaces are cluster-scoped: the namespace a Namespace object
+		// declares is its name.
+		if index.Kind != "Namespace" || !strings.HasPrefix(index.Name, "d8-") {
 			// skip non-deckhouse namespaces
 			continue
 		}
 
-		errorList = errorList.WithFilePath(object.GetPath())
-
-		if index.Kind == "Namespace" {
-			if !proxyInNamespaces.Has(index.Name) {
-				errorList.WithObjectID(fmt.Sprintf("namespace = %s", index.Name)).
-					WithValue(proxyInNamespaces.Slice()).
-					Error("All system namespaces should contain kube-rbac-proxy CA certificate." +
-						"\n\tConsider using corresponding helm_lib helper 'helm_lib_kube_rbac_proxy_ca_certificate'.",
-					)
-			}
+		if !proxyInNamespaces.Has(index.Name) {
+			errorList.WithFilePath(object.GetPath()).
+				WithObjectID(fmt.Sprintf("namespace = %s", index.Name)).
+				WithValue(proxyInNamespaces.Slice()).
+				Error("All system namespaces should contain kube-rbac-proxy CA certificate." +
+					"\n\tConsider using corresponding helm_lib helper 'helm_lib_kube_rbac_proxy_ca_certificate'.",
+				)
 		}
 	}
 }
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const kubeRbacProxyNamespace = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: d8-test
+`
+
+const kubeRbacProxyCA = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-rbac-proxy-ca.crt
+  namespace: d8-test
+`
+
+func kubeRbacProxyErrors(t *testing.T, manifests ...string) *errors.LintRuleErrorsList {
+	t.Helper()
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewKubeRbacProxyRule(nil).NamespaceMustContainKubeRBACProxyCA(
+		&storage.UnstructuredObjectStore{Storage: storagetest.Objects(t, manifests...)}, errorList)
+
+	return errorList
+}
+
+func TestKubeRbacProxyMissingCA(t *testing.T) {
+	errs := kubeRbacProxyErrors(t, kubeRbacProxyNamespace).GetErrors()
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, KubeRbacProxyRuleName, errs[0].RuleID)
+	assert.Equal(t, "namespace = d8-test", errs[0].ObjectID)
+	assert.Contains(t, errs[0].Text, "All system namespaces should contain kube-rbac-proxy CA certificate.")
+}
+
+func TestKubeRbacProxyCAInNamespace(t *testing.T) {
+	assert.False(t, kubeRbacProxyErrors(t, kubeRbacProxyNamespace, kubeRbacProxyCA).ContainsErrors())
+}
+
+func TestKubeRbacProxyCAInAnotherNamespace(t *testing.T) {
+	errs := kubeRbacProxyErrors(t, kubeRbacProxyNamespace,
+		storagetest.Patch(t, kubeRbacProxyCA, "namespace: d8-test", "namespace: d8-other")).GetErrors()
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, "namespace = d8-test", errs[0].ObjectID)
+}
+
+func TestKubeRbacProxyNonSystemNamespace(t *testing.T) {
+	errorList := kubeRbacProxyErrors(t, storagetest.Patch(t, kubeRbacProxyNamespace, "name: d8-test", "name: app"))
+
+	assert.False(t, errorList.ContainsErrors())
+}
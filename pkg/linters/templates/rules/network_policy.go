@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	NetworkPolicyRuleName = "network-policy"
+
+	NetworkPolicyKind = "NetworkPolicy"
+)
+
+func NewNetworkPolicyRule(excludeRules []pkg.KindRuleExclude, requireFullCoverage bool) *NetworkPolicyRule {
+	return &NetworkPolicyRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: NetworkPolicyRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+		requireFullCoverage: requireFullCoverage,
+	}
+}
+
+type NetworkPolicyRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+
+	requireFullCoverage bool
+}
+
+// networkPolicy is a rendered NetworkPolicy with its parsed pod selector.
+type networkPolicy struct {
+	policy   *networkingv1.NetworkPolicy
+	selector labels.Selector
+}
+
+// isIngress reports whether the policy restricts ingress traffic. Without
+// policyTypes every policy restricts ingress.
+func (p *networkPolicy) isIngress() bool {
+	return len(p.policy.Spec.PolicyTypes) == 0 || slices.Contains(p.policy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+}
+
+// CheckNetworkPolicyCoverage computes the NetworkPolicy coverage of the module:
+// policies whose podSelector matches no pod template, pod controllers no
+// ingress policy selects and container ports no ingress rule allows. Gaps are
+// reported in the namespaces the module renders ingress NetworkPolicies in; with
+// full coverage required they are errors in every namespace.
+func (r *NetworkPolicyRule) CheckNetworkPolicyCoverage(md pkg.Module, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	objects := md.GetStorage()
+	wiring := newServiceWiring(objects)
+
+	var policies []networkPolicy
+
+	for _, object := range objects {
+		if object.Unstructured.GetKind() != NetworkPolicyKind {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(object.Identity()).WithFilePath(object.GetPath())
+
+		policy, err := parseNetworkPolicy(object)
+		if err != nil {
+			errorListObj.Errorf("Cannot parse NetworkPolicy: %s", err)
+
+			continue
+		}
+
+		policies = append(policies, *policy)
+
+		if !r.Enabled(NetworkPolicyKind, object.Unstructured.GetName()) {
+			continue
+		}
+
+		if !policy.selector.Empty() && len(wiring.selectPods(policy.policy.Namespace, policy.selector)) == 0 {
+			errorListObj.WithValue(policy.selector.String()).
+				Error("NetworkPolicy podSelector does not match any pod template in the module")
+		}
+	}
+
+	for _, pod := range wiring.pods {
+		kind := pod.object.Unstructured.GetKind()
+		if !isNetworkPolicyTarget(kind) || pod.spec.HostNetwork {
+			continue
+		}
+
+		if !r.Enabled(kind, pod.object.Unstructured.GetName()) {
+			continue
+		}
+
+		r.checkPodCoverage(pod, policies, errorList.WithObjectID(pod.object.Identity()).WithFilePath(pod.object.GetPath()))
+	}
+}
+
+func parseNetworkPolicy(object storage.StoreObject) (*networkPolicy, error) {
+	policy := new(networkingv1.NetworkPolicy)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Unstructured.UnstructuredContent(), policy); err != nil {
+		return nil, err
+	}
+
+	selector, err := v1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid podSelector: %w", err)
+	}
+
+	return &networkPolicy{policy: policy, selector: selector}, nil
+}
+
+// isNetworkPolicyTarget reports whether pods of the kind serve traffic for long
+// enough to need a NetworkPolicy.
+func isNetworkPolicyTarget(kind string) bool {
+	return kind == "Deployment" || kind == "StatefulSet" || kind == "DaemonSet"
+}
+
+func (r *NetworkPolicyRule) checkPodCoverage(pod podTemplate, policies []networkPolicy, errorList *errors.LintRuleErrorsList) {
+	var (
+		selecting []networkPolicy
+		guarded   bool
+	)
+
+	for _, policy := range policies {
+		if policy.policy.Namespace != pod.namespace || !policy.isIngress() {
+			continue
+		}
+
+		guarded = true
+
+		if policy.selector.Matches(pod.labels) {
+			selecting = append(selecting, policy)
+		}
+	}
+
+	// Without full coverage only the namespaces the module guards with ingress
+	// NetworkPolicies are expected to be covered.
+	if !guarded && !r.requireFullCoverage {
+		return
+	}
+
+	if len(selecting) == 0 {
+		r.report(errorList, "Pod controller is not selected by any ingress NetworkPolicy in namespace %q", pod.namespace)
+
+		return
+	}
+
+	for i := range pod.spec.Containers {
+		container := &pod.spec.Containers[i]
+
+		for _, port := range container.Ports {
+			if slices.ContainsFunc(selecting, func(policy networkPolicy) bool { return allowsPort(policy.policy, port) }) {
+				continue
+			}
+
+			name := fmt.Sprint(port.ContainerPort)
+			if port.Name != "" {
+				name = port.Name
+			}
+
+			r.report(errorList.WithObjectID(pod.object.Identity()+" ; container = "+container.Name).WithValue(port.ContainerPort),
+				"Container port %q is not reachable: no ingress rule of the NetworkPolicies selecting the pod allows it", name)
+		}
+	}
+}
+
+// report records a coverage gap: an error when full coverage is required, a
+// warning otherwise.
+func (r *NetworkPolicyRule) report(errorList *errors.LintRuleErrorsList, format string, args ...any) {
+	if r.requireFullCoverage {
+		errorList.Errorf(format, args...)
+
+		return
+	}
+
+	errorList.Warnf(format, args...)
+}
+
+// allowsPort reports whether an ingress rule of the policy allows traffic to
+// the container port.
+func allowsPort(policy *networkingv1.NetworkPolicy, port corev1.ContainerPort) bool {
+	for _, rule := range policy.Spec.Ingress {
+		// A rule without ports allows every port.
+		if len(rule.Ports) == 0 {
+			return true
+		}
+
+		for _, rulePort := range rule.Ports {
+			if matchesPort(rulePort, port) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesPort(rulePort networkingv1.NetworkPolicyPort, port corev1.ContainerPort) bool {
+	ruleProtocol := corev1.ProtocolTCP
+	if rulePort.Protocol != nil {
+		ruleProtocol = *rulePort.Protocol
+	}
+
+	portProtocol := port.Protocol
+	if portProtocol == "" {
+		portProtocol = corev1.ProtocolTCP
+	}
+
+	if ruleProtocol != portProtocol {
+		return false
+	}
+
+	switch {
+	case rulePort.Port == nil:
+		return true
+	case rulePort.Port.Type == intstr.String:
+		return port.Name != "" && rulePort.Port.StrVal == port.Name
+	case rulePort.EndPort != nil:
+		return port.ContainerPort >= rulePort.Port.IntVal && port.ContainerPort <= *rulePort.EndPort
+	default:
+		return port.ContainerPort == rulePort.Port.IntVal
+	}
+}
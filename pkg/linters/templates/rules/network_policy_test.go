@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/mocks"
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const appNetworkPolicy = `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  podSelector:
+    matchLabels:
+      app: app
+  policyTypes: [Ingress]
+  ingress:
+    - ports:
+        - port: http
+    - ports:
+        - port: 9000
+          endPort: 9100
+`
+
+const networkPolicyWorker = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: d8-test
+spec:
+  template:
+    metadata:
+      labels:
+        app: worker
+    spec:
+      containers:
+        - name: worker
+          image: worker
+`
+
+func networkPolicyErrors(t *testing.T, excludes []pkg.KindRuleExclude, requireFullCoverage bool, manifests ...string) []string {
+	t.Helper()
+
+	mc := minimock.NewController(t)
+
+	mod := mocks.NewModuleMock(mc)
+	mod.GetStorageMock.Return(storagetest.Objects(t, manifests...))
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewNetworkPolicyRule(excludes, requireFullCoverage).CheckNetworkPolicyCoverage(mod, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Level.String()+": "+e.ObjectID+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestNetworkPolicyCompliant(t *testing.T) {
+	assert.Empty(t, networkPolicyErrors(t, nil, true, wiringDeployment, appNetworkPolicy))
+}
+
+func TestNetworkPolicyUnmatchedSelector(t *testing.T) {
+	unmatched := storagetest.Patch(t, appNetworkPolicy, "      app: app", "      app: other")
+	assert.Contains(t, networkPolicyErrors(t, nil, false, wiringDeployment, unmatched),
+		"error: kind = NetworkPolicy ; name = app ; namespace = d8-test: NetworkPolicy podSelector does not match any pod template in the module")
+
+	everyPod := storagetest.Patch(t, appNetworkPolicy, "    matchLabels:\n      app: app\n", "    {}\n")
+	assert.Empty(t, networkPolicyErrors(t, nil, false, wiringDeployment, everyPod), "an empty podSelector selects every pod")
+}
+
+func TestNetworkPolicyUncoveredController(t *testing.T) {
+	assert.Equal(t, []string{
+		`warn: kind = Deployment ; name = worker ; namespace = d8-test: Pod controller is not selected by any ingress NetworkPolicy in namespace "d8-test"`,
+	}, networkPolicyErrors(t, nil, false, wiringDeployment, networkPolicyWorker, appNetworkPolicy))
+
+	assert.Empty(t, networkPolicyErrors(t, nil, false, networkPolicyWorker), "namespaces without NetworkPolicies are not checked by default")
+
+	assert.Equal(t, []string{
+		`error: kind = Deployment ; name = worker ; namespace = d8-test: Pod controller is not selected by any ingress NetworkPolicy in namespace "d8-test"`,
+	}, networkPolicyErrors(t, nil, true, networkPolicyWorker))
+
+	egress := storagetest.Patch(t, appNetworkPolicy, "[Ingress]", "[Egress]")
+	assert.Empty(t, networkPolicyErrors(t, nil, false, wiringDeployment, egress),
+		"an egress-only NetworkPolicy does not make the namespace guarded")
+	assert.Equal(t, []string{
+		`error: kind = Deployment ; name = app ; namespace = d8-test: Pod controller is not selected by any ingress NetworkPolicy in namespace "d8-test"`,
+	}, networkPolicyErrors(t, nil, true, wiringDeployment, egress), "an egress-only NetworkPolicy does not cover the pod")
+
+	hostNetwork := storagetest.Patch(t, networkPolicyWorker, "    spec:\n", "    spec:\n      hostNetwork: true\n")
+	assert.Empty(t, networkPolicyErrors(t, nil, true, hostNetwork), "NetworkPolicies do not apply to host network pods")
+}
+
+func TestNetworkPolicyUnreachablePorts(t *testing.T) {
+	closed := storagetest.Patch(t, appNetworkPolicy, "        - port: 9000\n          endPort: 9100\n", "        - port: 9000\n          protocol: UDP\n")
+	assert.Equal(t, []string{
+		`warn: kind = Deployment ; name = app ; namespace = d8-test ; container = app: Container port "metrics" is not reachable: no ingress rule of the NetworkPolicies selecting the pod allows it`,
+	}, networkPolicyErrors(t, nil, false, wiringDeployment, closed))
+
+	allPorts := storagetest.Patch(t, appNetworkPolicy, "    - ports:\n        - port: http\n", "    - from:\n        - podSelector: {}\n")
+	assert.Empty(t, networkPolicyErrors(t, nil, true, wiringDeployment, allPorts), "a rule without ports allows every port")
+}
+
+func TestNetworkPolicyExclude(t *testing.T) {
+	assert.Empty(t, networkPolicyErrors(t, []pkg.KindRuleExclude{{Kind: "Deployment", Name: "worker"}}, true, networkPolicyWorker))
+}
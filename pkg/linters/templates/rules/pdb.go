@@ -54,6 +54,8 @@ type PDBRule struct {
 type nsLabelSelector struct {
 	namespace string
 	selector  labels.Selector
+	// pdb is the PodDisruptionBudget the selector belongs to.
+	pdb *policyv1.PodDisruptionBudget
 }
 
 func (s *nsLabelSelector) Matches(namespace string, labelSet labels.Set) bool {
@@ -65,7 +67,7 @@ func (s *nsLabelSelector) Matches(namespace string, labelSet labels.Set) bool {
 func (r *PDBRule) ControllerMustHavePDB(md *modules.Module, errorList *errors.LintRuleErrorsList) {
 	errorList = errorList.WithRule(r.GetName())
 
-	pdbSelectors := collectPDBSelectors(md, errorList)
+	pdbSelectors := collectPDBSelectors(md.GetStorage(), errorList)
 
 	for _, object := range md.GetStorage() {
 		if !isPodController(object.Unstructured.GetKind()) {
@@ -90,19 +92,20 @@ func (r *PDBRule) ControllerMustHavePDB(md *modules.Module, errorList *errors.Li
 }
 
 // collectPDBSelectors collects selectors for matching pods
-func collectPDBSelectors(md *modules.Module, errorList *errors.LintRuleErrorsList) []nsLabelSelector {
+func collectPDBSelectors(objects map[storage.ResourceIndex]storage.StoreObject, errorList *errors.LintRuleErrorsList) []nsLabelSelector {
 	var selectors []nsLabelSelector
 
-	for _, object := range md.GetStorage() {
+	for _, object := range objects {
 		if object.Unstructured.GetKind() != "PodDisruptionBudget" {
 			continue
 		}
 
-		labelSelector := parsePDBSelector(object, errorList)
+		pdb, labelSelector := parsePDBSelector(object, errorList)
 
 		sel := nsLabelSelector{
 			namespace: object.Unstructured.GetNamespace(),
 			selector:  labelSelector,
+			pdb:       pdb,
 		}
 
 		selectors = append(selectors, sel)
@@ -134,7 +137,7 @@ func ensurePDBIsPresent(selectors []nsLabelSelector, podController storage.Store
 		Error("No PodDisruptionBudget matches pod labels of the controller")
 }
 
-func parsePDBSelector(pdbObj storage.StoreObject, errorList *errors.LintRuleErrorsList) labels.Selector {
+func parsePDBSelector(pdbObj storage.StoreObject, errorList *errors.LintRuleErrorsList) (*policyv1.PodDisruptionBudget, labels.Selector) {
 	content := pdbObj.Unstructured.UnstructuredContent()
 	converter := runtime.DefaultUnstructuredConverter
 
@@ -146,23 +149,23 @@ func parsePDBSelector(pdbObj storage.StoreObject, errorList *errors.LintRuleErro
 	if err != nil {
 		errorListObj.Errorf("Cannot parse PodDisruptionBudget: %s", err)
 
-		return nil
+		return nil, nil
 	}
 
 	sel, err := v1.LabelSelectorAsSelector(pdb.Spec.Selector)
 	if err != nil {
 		errorListObj.Errorf("Cannot parse label selector: %s", err)
 
-		return nil
+		return nil, nil
 	}
 
 	if pdb.Annotations["helm.sh/hook"] != "" || pdb.Annotations["helm.sh/hook-delete-policy"] != "" {
 		errorListObj.Error("PDB must have no helm hook annotations")
 
-		return nil
+		return nil, nil
 	}
 
-	return sel
+	return pdb, sel
 }
 
 func parsePodControllerLabels(object storage.StoreObject) (map[string]string, error) {
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	ResourceRequestsRuleName = "resource-requests"
+)
+
+func NewResourceRequestsRule(excludeRules []pkg.KindRuleExclude, settings pkg.ResourceRequestsSettings) *ResourceRequestsRule {
+	return &ResourceRequestsRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: ResourceRequestsRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+		settings: settings,
+	}
+}
+
+type ResourceRequestsRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+
+	settings pkg.ResourceRequestsSettings
+}
+
+// requestBounds are the parsed module bounds of a resource request; nil when
+// not configured.
+type requestBounds struct {
+	min, max *resource.Quantity
+}
+
+// CheckResourceRequests checks the CPU and memory requests of the pod
+// controllers: they must be set unless a VPA applying recommendations manages
+// the container, limits must not be lower than requests, requests must stay
+// within the bounds configured for the module and static requests must lie
+// within the minAllowed/maxAllowed range of the container's VPA policy.
+func (r *ResourceRequestsRule) CheckResourceRequests(md pkg.Module, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	bounds, ok := r.parseBounds(errorList.WithFilePath(md.GetPath()))
+	if !ok {
+		return
+	}
+
+	// VPA errors are reported by the vpa rule.
+	_, _, vpaUpdateModes, vpaContainerPolicies := parseTargetsGroups(md, errors.NewLintRuleErrorsList())
+
+	for index, object := range md.GetStorage() {
+		if !IsPodController(object.Unstructured.GetKind()) {
+			continue
+		}
+
+		if !r.Enabled(object.Unstructured.GetKind(), object.Unstructured.GetName()) {
+			continue
+		}
+
+		podSpec, err := object.GetPodSpec()
+		if err != nil {
+			errorList.WithObjectID(object.Identity()).WithFilePath(object.GetPath()).
+				Errorf("Cannot get pod spec: %s", err)
+
+			continue
+		}
+
+		if podSpec == nil {
+			continue
+		}
+
+		var policies []ContainerResourcePolicy
+		if mode, ok := vpaUpdateModes[index]; ok && mode != UpdateModeOff {
+			policies = vpaContainerPolicies[index]
+		}
+
+		for i := range podSpec.InitContainers {
+			r.checkContainer(object, &podSpec.InitContainers[i], nil, bounds, errorList)
+		}
+
+		for i := range podSpec.Containers {
+			container := &podSpec.Containers[i]
+			r.checkContainer(object, container, findContainerPolicy(policies, container.Name), bounds, errorList)
+		}
+	}
+}
+
+// parseBounds parses the configured request bounds of CPU and memory.
+func (r *ResourceRequestsRule) parseBounds(errorList *errors.LintRuleErrorsList) (map[corev1.ResourceName]requestBounds, bool) {
+	bounds := map[corev1.ResourceName]requestBounds{}
+	ok := true
+
+	parse := func(name corev1.ResourceName, key, value string) *resource.Quantity {
+		if value == "" {
+			return nil
+		}
+
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			errorList.WithValue(value).
+				Errorf("Invalid resource-requests setting %s.%s %q: %s", name, key, value, err)
+
+			ok = false
+
+			return nil
+		}
+
+		return &quantity
+	}
+
+	for name, configured := range map[corev1.ResourceName]pkg.ResourceBounds{
+		corev1.ResourceCPU:    r.settings.CPU,
+		corev1.ResourceMemory: r.settings.Memory,
+	} {
+		bounds[name] = requestBounds{
+			min: parse(name, "min", configured.Min),
+			max: parse(name, "max", configured.Max),
+		}
+	}
+
+	return bounds, ok
+}
+
+// findContainerPolicy returns the VPA policy of the container, falling back to
+// the default "*" policy; nil when the VPA does not manage the container.
+func findContainerPolicy(policies []ContainerResourcePolicy, name string) *ContainerResourcePolicy {
+	idx := slices.IndexFunc(policies, func(p ContainerResourcePolicy) bool { return p.ContainerName == name })
+	if idx < 0 {
+		idx = slices.IndexFunc(policies, func(p ContainerResourcePolicy) bool { return p.ContainerName == DefaultContainerResourcePolicy })
+	}
+
+	if idx < 0 || (policies[idx].Mode != nil && *policies[idx].Mode == ContainerScalingModeOff) {
+		return nil
+	}
+
+	return &policies[idx]
+}
+
+// policyControls reports whether the VPA policy recommends the resource.
+func policyControls(policy *ContainerResourcePolicy, name corev1.ResourceName) bool {
+	return policy != nil && (policy.ControlledResources == nil || slices.Contains(*policy.ControlledResources, name))
+}
+
+func (r *ResourceRequestsRule) checkContainer(
+	object storage.StoreObject,
+	container *corev1.Container,
+	policy *ContainerResourcePolicy,
+	bounds map[corev1.ResourceName]requestBounds,
+	errorList *errors.LintRuleErrorsList,
+) {
+	errorList = errorList.WithObjectID(object.Identity() + " ; container = " + container.Name).WithFilePath(object.GetPath())
+
+	requests := container.Resources.Requests
+	limits := container.Resources.Limits
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		governed := policyControls(policy, name)
+
+		request, ok := requests[name]
+		if !ok || request.IsZero() {
+			if !governed {
+				errorList.Errorf("Container does not set a %s request and no VPA applying recommendations manages it", name)
+			}
+
+			continue
+		}
+
+		if b := bounds[name]; b.min != nil && request.Cmp(*b.min) < 0 {
+			errorList.WithValue(request.String()).
+				Errorf("Container %s request %s is below the minimum %s configured for the module", name, request.String(), b.min.String())
+		} else if b.max != nil && request.Cmp(*b.max) > 0 {
+			errorList.WithValue(request.String()).
+				Errorf("Container %s request %s is above the maximum %s configured for the module", name, request.String(), b.max.String())
+		}
+
+		if !governed {
+			continue
+		}
+
+		if minAllowed, ok := policy.MinAllowed[name]; ok && request.Cmp(minAllowed) < 0 {
+			errorList.WithValue(request.String()).
+				Errorf("Container %s request %s is below the VPA minAllowed %s", name, request.String(), minAllowed.String())
+		}
+
+		if maxAllowed, ok := policy.MaxAllowed[name]; ok && request.Cmp(maxAllowed) > 0 {
+			errorList.WithValue(request.String()).
+				Errorf("Container %s request %s is above the VPA maxAllowed %s", name, request.String(), maxAllowed.String())
+		}
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		request, hasRequest := requests[name]
+		limit, hasLimit := limits[name]
+
+		if hasRequest && hasLimit && limit.Cmp(request) < 0 {
+			errorList.WithValue(limit.String()).
+				Errorf("Container %s limit %s is lower than its request %s", name, limit.String(), request.String())
+		}
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/mocks"
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const requestsDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: app
+          resources:
+            requests:
+              cpu: 10m
+              memory: 16Mi
+      containers:
+        - name: app
+          image: app
+          resources:
+            requests:
+              cpu: 50m
+              memory: 64Mi
+            limits:
+              memory: 128Mi
+`
+
+const requestsVPA = `
+apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app
+  updatePolicy:
+    updateMode: Initial
+  resourcePolicy:
+    containerPolicies:
+      - containerName: app
+        minAllowed:
+          cpu: 10m
+          memory: 32Mi
+        maxAllowed:
+          cpu: 100m
+          memory: 128Mi
+`
+
+func requestsErrors(t *testing.T, settings pkg.ResourceRequestsSettings, excludes []pkg.KindRuleExclude, manifests ...string) []string {
+	t.Helper()
+
+	mc := minimock.NewController(t)
+
+	mod := mocks.NewModuleMock(mc)
+	mod.GetPathMock.Optional().Return("/modules/test")
+	mod.GetStorageMock.Optional().Return(storagetest.Objects(t, manifests...))
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewResourceRequestsRule(excludes, settings).CheckResourceRequests(mod, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.ObjectID+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestResourceRequestsCompliant(t *testing.T) {
+	assert.Empty(t, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, requestsDeployment, requestsVPA))
+}
+
+func TestResourceRequestsMissing(t *testing.T) {
+	const id = "kind = Deployment ; name = app ; namespace = d8-test ; container = app"
+
+	noRequests := storagetest.Patch(t, requestsDeployment, "              cpu: 50m\n              memory: 64Mi\n", "              ephemeral-storage: 50Mi\n")
+	assert.Equal(t, []string{
+		id + ": Container does not set a cpu request and no VPA applying recommendations manages it",
+		id + ": Container does not set a memory request and no VPA applying recommendations manages it",
+	}, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, noRequests))
+
+	assert.Empty(t, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, noRequests, requestsVPA), "the VPA sets the requests")
+
+	offVPA := storagetest.Patch(t, requestsVPA, "updateMode: Initial", "updateMode: \"Off\"")
+	assert.Len(t, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, noRequests, offVPA), 2, "a VPA in Off mode does not set requests")
+
+	cpuOnly := storagetest.Patch(t, requestsVPA, "      - containerName: app\n", "      - containerName: app\n        controlledResources: [cpu]\n")
+	assert.Equal(t, []string{
+		id + ": Container does not set a memory request and no VPA applying recommendations manages it",
+	}, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, noRequests, cpuOnly))
+}
+
+func TestResourceRequestsLimits(t *testing.T) {
+	lowLimit := storagetest.Patch(t, requestsDeployment, "              memory: 128Mi\n", "              memory: 32Mi\n")
+	assert.Equal(t, []string{
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: Container memory limit 32Mi is lower than its request 64Mi",
+	}, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, lowLimit, requestsVPA))
+}
+
+func TestResourceRequestsBounds(t *testing.T) {
+	settings := pkg.ResourceRequestsSettings{
+		CPU:    pkg.ResourceBounds{Min: "20m"},
+		Memory: pkg.ResourceBounds{Max: "32Mi"},
+	}
+
+	assert.Equal(t, []string{
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: Container memory request 64Mi is above the maximum 32Mi configured for the module",
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = init: Container cpu request 10m is below the minimum 20m configured for the module",
+	}, requestsErrors(t, settings, nil, requestsDeployment, requestsVPA))
+
+	invalid := pkg.ResourceRequestsSettings{CPU: pkg.ResourceBounds{Max: "many"}}
+	errs := requestsErrors(t, invalid, nil, requestsDeployment)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0], `Invalid resource-requests setting cpu.max "many"`)
+}
+
+func TestResourceRequestsVPAConsistency(t *testing.T) {
+	outside := storagetest.Patch(t, requestsDeployment, "              cpu: 50m\n", "              cpu: 5m\n")
+	outside = storagetest.Patch(t, outside, "              memory: 64Mi\n", "              memory: 256Mi\n")
+	outside = storagetest.Patch(t, outside, "              memory: 128Mi\n", "              memory: 512Mi\n")
+
+	assert.Equal(t, []string{
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: Container cpu request 5m is below the VPA minAllowed 10m",
+		"kind = Deployment ; name = app ; namespace = d8-test ; container = app: Container memory request 256Mi is above the VPA maxAllowed 128Mi",
+	}, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, outside, requestsVPA))
+
+	wildcard := storagetest.Patch(t, requestsVPA, "containerName: app", "containerName: \"*\"")
+	assert.Len(t, requestsErrors(t, pkg.ResourceRequestsSettings{}, nil, outside, wildcard), 2, "the default policy applies to every container")
+}
+
+func TestResourceRequestsExclude(t *testing.T) {
+	noRequests := storagetest.Patch(t, requestsDeployment, "              cpu: 50m\n              memory: 64Mi\n", "")
+	assert.Empty(t, requestsErrors(t, pkg.ResourceRequestsSettings{}, []pkg.KindRuleExclude{{Kind: "Deployment", Name: "app"}}, noRequests))
+}
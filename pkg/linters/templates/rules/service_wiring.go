@@ -0,0 +1,536 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	ServiceWiringRuleName = "service-wiring"
+)
+
+func NewServiceWiringRule(excludeRules []pkg.KindRuleExclude) *ServiceWiringRule {
+	return &ServiceWiringRule{
+		RuleMeta: pkg.RuleMeta{
+			Name: ServiceWiringRuleName,
+		},
+		KindRule: pkg.KindRule{
+			ExcludeRules: excludeRules,
+		},
+	}
+}
+
+type ServiceWiringRule struct {
+	pkg.RuleMeta
+	pkg.KindRule
+}
+
+// podTemplate is the pod template of a pod or pod controller.
+type podTemplate struct {
+	object    storage.StoreObject
+	namespace string
+	labels    labels.Set
+	spec      *corev1.PodSpec
+}
+
+// serviceWiring is the index of the module objects the references resolve to.
+type serviceWiring struct {
+	pods     []podTemplate
+	services []*corev1.Service
+	// operatorPods are the labels of the pods prometheus-operator creates for
+	// the module's Prometheus and Alertmanager objects.
+	operatorPods []operatorPods
+	// namespaces are the namespaces the module renders objects in.
+	namespaces map[string]struct{}
+}
+
+// operatorPods are the pods an operator creates for a custom resource. Their
+// spec is up to the operator, so only their labels are known.
+type operatorPods struct {
+	namespace string
+	labels    labels.Set
+}
+
+// monitorSpec holds the fields of a ServiceMonitor or PodMonitor
+// (monitoring.coreos.com/v1) the rule resolves.
+type monitorSpec struct {
+	Selector          v1.LabelSelector `json:"selector"`
+	NamespaceSelector struct {
+		Any        bool     `json:"any"`
+		MatchNames []string `json:"matchNames"`
+	} `json:"namespaceSelector"`
+	Endpoints []struct {
+		Port string `json:"port"`
+	} `json:"endpoints"`
+	PodMetricsEndpoints []struct {
+		Port string `json:"port"`
+	} `json:"podMetricsEndpoints"`
+}
+
+// httpRouteSpec holds the backend references of an HTTPRoute
+// (gateway.networking.k8s.io).
+type httpRouteSpec struct {
+	Rules []struct {
+		BackendRefs []struct {
+			Group     *string `json:"group"`
+			Kind      *string `json:"kind"`
+			Name      string  `json:"name"`
+			Namespace *string `json:"namespace"`
+			Port      *int32  `json:"port"`
+		} `json:"backendRefs"`
+	} `json:"rules"`
+}
+
+// CheckServiceWiring validates the references between the module objects: every
+// Service selector matches a pod template and its named target ports exist in
+// the selected containers, every Ingress and HTTPRoute backend is an existing
+// Service port, and every ServiceMonitor and PodMonitor selects an existing
+// Service or pod port.
+func (r *ServiceWiringRule) CheckServiceWiring(md pkg.Module, errorList *errors.LintRuleErrorsList) {
+	errorList = errorList.WithRule(r.GetName())
+
+	wiring := newServiceWiring(md.GetStorage())
+
+	for _, object := range md.GetStorage() {
+		kind := object.Unstructured.GetKind()
+		if !r.Enabled(kind, object.Unstructured.GetName()) {
+			continue
+		}
+
+		errorListObj := errorList.WithObjectID(object.Identity()).WithFilePath(object.GetPath())
+
+		var err error
+
+		switch kind {
+		case "Service":
+			err = wiring.checkService(object, errorListObj)
+		case IngressKind:
+			err = wiring.checkIngress(object, errorListObj)
+		case HTTPRouteKind:
+			err = wiring.checkHTTPRoute(object, errorListObj)
+		case "ServiceMonitor":
+			err = wiring.checkServiceMonitor(object, errorListObj)
+		case "PodMonitor":
+			err = wiring.checkPodMonitor(object, errorListObj)
+		}
+
+		if err != nil {
+			errorListObj.Errorf("Cannot convert object to %s: %v", kind, err)
+		}
+	}
+}
+
+func newServiceWiring(objects map[storage.ResourceIndex]storage.StoreObject) *serviceWiring {
+	w := &serviceWiring{namespaces: map[string]struct{}{}}
+
+	for _, object := range objects {
+		namespace := object.Unstructured.GetNamespace()
+		if namespace != "" {
+			w.namespaces[namespace] = struct{}{}
+		}
+
+		switch object.Unstructured.GetKind() {
+		case "Service":
+			service := new(corev1.Service)
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Unstructured.UnstructuredContent(), service); err == nil {
+				w.services = append(w.services, service)
+			}
+		case "Prometheus", "Alertmanager":
+			if pods, ok := prometheusOperatorPods(&object.Unstructured); ok {
+				w.operatorPods = append(w.operatorPods, pods)
+			}
+		default:
+			spec, err := object.GetPodSpec()
+			if err != nil || spec == nil {
+				continue
+			}
+
+			w.pods = append(w.pods, podTemplate{
+				object:    object,
+				namespace: namespace,
+				labels:    podTemplateLabels(&object.Unstructured),
+				spec:      spec,
+			})
+		}
+	}
+
+	return w
+}
+
+func podTemplateLabels(object *unstructured.Unstructured) labels.Set {
+	var path []string
+
+	switch object.GetKind() {
+	case "Pod":
+		return object.GetLabels()
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template", "metadata", "labels"}
+	default:
+		path = []string{"spec", "template", "metadata", "labels"}
+	}
+
+	podLabels, _, _ := unstructured.NestedStringMap(object.Object, path...)
+
+	return podLabels
+}
+
+// prometheusOperatorPods returns the labels prometheus-operator sets on the pods
+// of a Prometheus or Alertmanager object: its own labels, including the legacy
+// "app" and the "prometheus" or "alertmanager" instance label, and
+// spec.podMetadata.labels.
+func prometheusOperatorPods(object *unstructured.Unstructured) (operatorPods, bool) {
+	if object.GroupVersionKind().Group != "monitoring.coreos.com" {
+		return operatorPods{}, false
+	}
+
+	kind := strings.ToLower(object.GetKind())
+	name := object.GetName()
+
+	podLabels := labels.Set{
+		"app":                          kind,
+		kind:                           name,
+		"app.kubernetes.io/name":       kind,
+		"app.kubernetes.io/instance":   name,
+		"app.kubernetes.io/managed-by": "prometheus-operator",
+		"operator.prometheus.io/name":  name,
+	}
+
+	extra, _, _ := unstructured.NestedStringMap(object.Object, "spec", "podMetadata", "labels")
+	for key, value := range extra {
+		podLabels[key] = value
+	}
+
+	return operatorPods{namespace: object.GetNamespace(), labels: podLabels}, true
+}
+
+// selectsOperatorPods reports whether the selector matches the pods an operator
+// creates in one of the namespaces.
+func (w *serviceWiring) selectsOperatorPods(namespaces []string, selector labels.Selector) bool {
+	return slices.ContainsFunc(w.operatorPods, func(pods operatorPods) bool {
+		return slices.Contains(namespaces, pods.namespace) && selector.Matches(pods.labels)
+	})
+}
+
+// selectPods returns the pod templates in the namespace the selector matches.
+func (w *serviceWiring) selectPods(namespace string, selector labels.Selector) []podTemplate {
+	var pods []podTemplate
+
+	for _, pod := range w.pods {
+		if pod.namespace == namespace && selector.Matches(pod.labels) {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods
+}
+
+func (w *serviceWiring) findService(namespace, name string) *corev1.Service {
+	for _, service := range w.services {
+		if service.Namespace == namespace && service.Name == name {
+			return service
+		}
+	}
+
+	return nil
+}
+
+// rendered reports whether the module renders objects in every namespace, so a
+// reference into them can be resolved.
+func (w *serviceWiring) rendered(namespaces ...string) bool {
+	for _, namespace := range namespaces {
+		if _, ok := w.namespaces[namespace]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w *serviceWiring) checkService(object storage.StoreObject, errorList *errors.LintRuleErrorsList) error {
+	service := new(corev1.Service)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Unstructured.UnstructuredContent(), service); err != nil {
+		return err
+	}
+
+	// Services without a selector have their endpoints managed elsewhere.
+	if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+		return nil
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+
+	pods := w.selectPods(service.Namespace, selector)
+	if len(pods) == 0 {
+		// The container ports of operator-created pods are not rendered.
+		if w.selectsOperatorPods([]string{service.Namespace}, selector) {
+			return nil
+		}
+
+		errorList.WithValue(service.Spec.Selector).
+			Error("Service selector does not match any pod template in the module")
+
+		return nil
+	}
+
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type != intstr.String {
+			continue
+		}
+
+		if !slices.ContainsFunc(pods, func(pod podTemplate) bool { return hasContainerPort(pod.spec, port.TargetPort.StrVal) }) {
+			errorList.WithObjectID(object.Identity()+" ; port = "+port.Name).WithValue(port.TargetPort.StrVal).
+				Errorf("Service targetPort %q is not a container port name of the selected pods", port.TargetPort.StrVal)
+		}
+	}
+
+	return nil
+}
+
+func hasContainerPort(spec *corev1.PodSpec, name string) bool {
+	for _, containers := range [][]corev1.Container{spec.Containers, spec.InitContainers} {
+		for i := range containers {
+			for _, port := range containers[i].Ports {
+				if port.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *serviceWiring) checkIngress(object storage.StoreObject, errorList *errors.LintRuleErrorsList) error {
+	ingress := new(networkingv1.Ingress)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Unstructured.UnstructuredContent(), ingress); err != nil {
+		return err
+	}
+
+	var backends []*networkingv1.IngressServiceBackend
+
+	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
+		backends = append(backends, ingress.Spec.DefaultBackend.Service)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				backends = append(backends, path.Backend.Service)
+			}
+		}
+	}
+
+	for _, backend := range backends {
+		service := w.findService(ingress.Namespace, backend.Name)
+		if service == nil {
+			errorList.WithValue(backend.Name).
+				Errorf("Ingress backend Service %q does not exist in the module", backend.Name)
+
+			continue
+		}
+
+		if !slices.ContainsFunc(service.Spec.Ports, func(p corev1.ServicePort) bool {
+			return (backend.Port.Name != "" && p.Name == backend.Port.Name) || (backend.Port.Name == "" && p.Port == backend.Port.Number)
+		}) {
+			port := backend.Port.Name
+			if port == "" {
+				port = fmt.Sprint(backend.Port.Number)
+			}
+
+			errorList.WithValue(port).
+				Errorf("Ingress backend port %q is not a port of Service %q", port, backend.Name)
+		}
+	}
+
+	return nil
+}
+
+func (w *serviceWiring) checkHTTPRoute(object storage.StoreObject, errorList *errors.LintRuleErrorsList) error {
+	spec := new(httpRouteSpec)
+	if content, ok := object.Unstructured.Object["spec"].(map[string]any); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, spec); err != nil {
+			return err
+		}
+	}
+
+	routeNamespace := object.Unstructured.GetNamespace()
+
+	for _, rule := range spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") {
+				continue
+			}
+
+			namespace := routeNamespace
+			if ref.Namespace != nil {
+				namespace = *ref.Namespace
+			}
+
+			// Cross-namespace references need a ReferenceGrant and usually point
+			// outside the module.
+			if namespace != routeNamespace {
+				continue
+			}
+
+			service := w.findService(namespace, ref.Name)
+			if service == nil {
+				errorList.WithValue(ref.Name).
+					Errorf("HTTPRoute backend Service %q does not exist in the module", ref.Name)
+
+				continue
+			}
+
+			if ref.Port != nil && !slices.ContainsFunc(service.Spec.Ports, func(p corev1.ServicePort) bool { return p.Port == *ref.Port }) {
+				errorList.WithValue(*ref.Port).
+					Errorf("HTTPRoute backend port %d is not a port of Service %q", *ref.Port, ref.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// monitorNamespaces returns the namespaces a monitor selects objects in, or nil
+// for namespaceSelector.any: such a monitor also selects objects outside the
+// module and is not checked.
+func monitorNamespaces(object storage.StoreObject, spec *monitorSpec) []string {
+	switch {
+	case spec.NamespaceSelector.Any:
+		return nil
+	case len(spec.NamespaceSelector.MatchNames) > 0:
+		return spec.NamespaceSelector.MatchNames
+	default:
+		return []string{object.Unstructured.GetNamespace()}
+	}
+}
+
+func parseMonitorSpec(object storage.StoreObject) (*monitorSpec, labels.Selector, error) {
+	spec := new(monitorSpec)
+	if content, ok := object.Unstructured.Object["spec"].(map[string]any); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, spec); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	selector, err := v1.LabelSelectorAsSelector(&spec.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return spec, selector, nil
+}
+
+func (w *serviceWiring) checkServiceMonitor(object storage.StoreObject, errorList *errors.LintRuleErrorsList) error {
+	spec, selector, err := parseMonitorSpec(object)
+	if err != nil {
+		return err
+	}
+
+	namespaces := monitorNamespaces(object, spec)
+	if namespaces == nil || !w.rendered(namespaces...) {
+		return nil
+	}
+
+	var ports []string
+
+	found := false
+
+	for _, service := range w.services {
+		if slices.Contains(namespaces, service.Namespace) && selector.Matches(labels.Set(service.Labels)) {
+			found = true
+
+			for _, port := range service.Spec.Ports {
+				ports = append(ports, port.Name)
+			}
+		}
+	}
+
+	if !found {
+		errorList.WithValue(selector.String()).
+			Error("ServiceMonitor selector does not match any Service in the module")
+
+		return nil
+	}
+
+	for _, endpoint := range spec.Endpoints {
+		if endpoint.Port != "" && !slices.Contains(ports, endpoint.Port) {
+			errorList.WithValue(endpoint.Port).
+				Errorf("ServiceMonitor endpoint port %q is not a port name of the selected Services", endpoint.Port)
+		}
+	}
+
+	return nil
+}
+
+func (w *serviceWiring) checkPodMonitor(object storage.StoreObject, errorList *errors.LintRuleErrorsList) error {
+	spec, selector, err := parseMonitorSpec(object)
+	if err != nil {
+		return err
+	}
+
+	namespaces := monitorNamespaces(object, spec)
+	if namespaces == nil || !w.rendered(namespaces...) {
+		return nil
+	}
+
+	var pods []podTemplate
+
+	for _, pod := range w.pods {
+		if slices.Contains(namespaces, pod.namespace) && selector.Matches(pod.labels) {
+			pods = append(pods, pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		if w.selectsOperatorPods(namespaces, selector) {
+			return nil
+		}
+
+		errorList.WithValue(selector.String()).
+			Error("PodMonitor selector does not match any pod template in the module")
+
+		return nil
+	}
+
+	for _, endpoint := range spec.PodMetricsEndpoints {
+		if endpoint.Port != "" && !slices.ContainsFunc(pods, func(pod podTemplate) bool { return hasContainerPort(pod.spec, endpoint.Port) }) {
+			errorList.WithValue(endpoint.Port).
+				Errorf("PodMonitor endpoint port %q is not a container port name of the selected pods", endpoint.Port)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deckhouse/dmt/internal/mocks"
+	"github.com/deckhouse/dmt/internal/storage/storagetest"
+	"github.com/deckhouse/dmt/pkg"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const wiringDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+        - name: app
+          image: app
+          ports:
+            - name: http
+              containerPort: 8080
+            - name: metrics
+              containerPort: 9090
+`
+
+const wiringService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: d8-test
+  labels:
+    app: app
+spec:
+  selector:
+    app: app
+  ports:
+    - name: http
+      port: 80
+      targetPort: http
+    - name: metrics
+      port: 9090
+      targetPort: metrics
+`
+
+const wiringIngress = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  rules:
+    - host: app.example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: app
+                port:
+                  name: http
+`
+
+const wiringHTTPRoute = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  rules:
+    - backendRefs:
+        - name: app
+          port: 80
+        - name: other
+          namespace: d8-other
+          port: 80
+`
+
+const wiringServiceMonitor = `
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: app
+  namespace: d8-monitoring
+spec:
+  namespaceSelector:
+    matchNames: [d8-test]
+  selector:
+    matchLabels:
+      app: app
+  endpoints:
+    - port: metrics
+`
+
+const wiringPodMonitor = `
+apiVersion: monitoring.coreos.com/v1
+kind: PodMonitor
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  selector:
+    matchLabels:
+      app: app
+  podMetricsEndpoints:
+    - port: metrics
+`
+
+func wiringErrors(t *testing.T, excludes []pkg.KindRuleExclude, manifests ...string) []string {
+	t.Helper()
+
+	mc := minimock.NewController(t)
+
+	mod := mocks.NewModuleMock(mc)
+	mod.GetStorageMock.Return(storagetest.Objects(t, manifests...))
+
+	errorList := errors.NewLintRuleErrorsList()
+	NewServiceWiringRule(excludes).CheckServiceWiring(mod, errorList)
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.ObjectID+": "+e.Text)
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func TestServiceWiringCompliant(t *testing.T) {
+	assert.Empty(t, wiringErrors(t, nil, wiringDeployment, wiringService, wiringIngress, wiringHTTPRoute, wiringServiceMonitor, wiringPodMonitor))
+}
+
+func TestServiceWiringService(t *testing.T) {
+	unmatched := storagetest.Patch(t, wiringService, "  selector:\n    app: app", "  selector:\n    app: other")
+	assert.Equal(t, []string{
+		"kind = Service ; name = app ; namespace = d8-test: Service selector does not match any pod template in the module",
+	}, wiringErrors(t, nil, wiringDeployment, unmatched))
+
+	badPort := storagetest.Patch(t, wiringService, "targetPort: http", "targetPort: web")
+	assert.Equal(t, []string{
+		`kind = Service ; name = app ; namespace = d8-test ; port = http: Service targetPort "web" is not a container port name of the selected pods`,
+	}, wiringErrors(t, nil, wiringDeployment, badPort))
+
+	selectorless := storagetest.Patch(t, wiringService, "  selector:\n    app: app\n", "")
+	assert.Empty(t, wiringErrors(t, nil, selectorless), "Services without a selector are not checked")
+}
+
+func TestServiceWiringIngress(t *testing.T) {
+	missing := storagetest.Patch(t, wiringIngress, "name: app\n                port", "name: web\n                port")
+	assert.Equal(t, []string{
+		`kind = Ingress ; name = app ; namespace = d8-test: Ingress backend Service "web" does not exist in the module`,
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, missing))
+
+	badPort := storagetest.Patch(t, wiringIngress, "name: http", "name: https")
+	assert.Equal(t, []string{
+		`kind = Ingress ; name = app ; namespace = d8-test: Ingress backend port "https" is not a port of Service "app"`,
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, badPort))
+}
+
+func TestServiceWiringHTTPRoute(t *testing.T) {
+	badPort := storagetest.Patch(t, wiringHTTPRoute, "port: 80", "port: 8080")
+	assert.Equal(t, []string{
+		`kind = HTTPRoute ; name = app ; namespace = d8-test: HTTPRoute backend port 8080 is not a port of Service "app"`,
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, badPort))
+
+	missing := storagetest.Patch(t, wiringHTTPRoute, "        - name: app\n", "        - name: web\n")
+	assert.Equal(t, []string{
+		`kind = HTTPRoute ; name = app ; namespace = d8-test: HTTPRoute backend Service "web" does not exist in the module`,
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, missing))
+}
+
+func TestServiceWiringMonitors(t *testing.T) {
+	badPort := storagetest.Patch(t, wiringServiceMonitor, "port: metrics", "port: prometheus")
+	assert.Equal(t, []string{
+		`kind = ServiceMonitor ; name = app ; namespace = d8-monitoring: ServiceMonitor endpoint port "prometheus" is not a port name of the selected Services`,
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, badPort))
+
+	unmatched := storagetest.Patch(t, wiringServiceMonitor, "      app: app", "      app: other")
+	assert.Equal(t, []string{
+		"kind = ServiceMonitor ; name = app ; namespace = d8-monitoring: ServiceMonitor selector does not match any Service in the module",
+	}, wiringErrors(t, nil, wiringDeployment, wiringService, unmatched))
+
+	external := storagetest.Patch(t, unmatched, "[d8-test]", "[d8-other]")
+	assert.Empty(t, wiringErrors(t, nil, wiringDeployment, wiringService, external), "namespaces the module does not render are not checked")
+
+	anyNamespace := storagetest.Patch(t, unmatched, "    matchNames: [d8-test]", "    any: true")
+	assert.Empty(t, wiringErrors(t, nil, wiringDeployment, wiringService, anyNamespace), "monitors of any namespace are not checked")
+
+	podBadPort := storagetest.Patch(t, wiringPodMonitor, "port: metrics", "port: prometheus")
+	assert.Equal(t, []string{
+		`kind = PodMonitor ; name = app ; namespace = d8-test: PodMonitor endpoint port "prometheus" is not a container port name of the selected pods`,
+	}, wiringErrors(t, nil, wiringDeployment, podBadPort))
+}
+
+const wiringPrometheus = `
+apiVersion: monitoring.coreos.com/v1
+kind: Prometheus
+metadata:
+  name: main
+  namespace: d8-test
+spec:
+  podMetadata:
+    labels:
+      tier: monitoring
+`
+
+func TestServiceWiringOperatorPods(t *testing.T) {
+	for _, selector := range []string{"prometheus: main", "app.kubernetes.io/name: prometheus", "tier: monitoring"} {
+		service := storagetest.Patch(t, wiringService, "  selector:\n    app: app", "  selector:\n    "+selector)
+		assert.Empty(t, wiringErrors(t, nil, wiringPrometheus, service), "Service selecting the Prometheus pods by %q", selector)
+	}
+
+	other := storagetest.Patch(t, wiringService, "  selector:\n    app: app", "  selector:\n    prometheus: longterm")
+	assert.Equal(t, []string{
+		"kind = Service ; name = app ; namespace = d8-test: Service selector does not match any pod template in the module",
+	}, wiringErrors(t, nil, wiringPrometheus, other))
+
+	podMonitor := storagetest.Patch(t, wiringPodMonitor, "      app: app", "      prometheus: main")
+	assert.Empty(t, wiringErrors(t, nil, wiringPrometheus, podMonitor))
+}
+
+func TestServiceWiringExclude(t *testing.T) {
+	badPort := storagetest.Patch(t, wiringService, "targetPort: http", "targetPort: web")
+	assert.Empty(t, wiringErrors(t, []pkg.KindRuleExclude{{Kind: "Service", Name: "app"}}, wiringDeployment, badPort))
+}
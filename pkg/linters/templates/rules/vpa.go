@@ -52,7 +52,7 @@ type VPARule struct {
 func (r *VPARule) ControllerMustHaveVPA(md *modules.Module, errorList *errors.LintRuleErrorsList) {
 	errorList = errorList.WithRule(r.GetName())
 
-	vpaTargets, vpaContainerNamesMap, vpaUpdateModes := parseTargetsGroups(md, errorList)
+	vpaTargets, vpaContainerNamesMap, vpaUpdateModes, _ := parseTargetsGroups(md, errorList)
 
 	for index, object := range md.GetStorage() {
 		// Skip non-pod controllers
@@ -84,14 +84,16 @@ func IsPodController(kind string) bool {
 }
 
 // parseTargetsGroups resolves target resource indexes
-func parseTargetsGroups(md *modules.Module, errorList *errors.LintRuleErrorsList) (
+func parseTargetsGroups(md pkg.Module, errorList *errors.LintRuleErrorsList) (
 	map[storage.ResourceIndex]struct{},
 	map[storage.ResourceIndex]set.Set,
 	map[storage.ResourceIndex]UpdateMode,
+	map[storage.ResourceIndex][]ContainerResourcePolicy,
 ) {
 	vpaTargets := make(map[storage.ResourceIndex]struct{})
 	vpaContainerNamesMap := make(map[storage.ResourceIndex]set.Set)
 	vpaUpdateModes := make(map[storage.ResourceIndex]UpdateMode)
+	vpaContainerPolicies := make(map[storage.ResourceIndex][]ContainerResourcePolicy)
 
 	for _, object := range md.GetStorage() {
 		kind := object.Unstructured.GetKind()
@@ -100,16 +102,17 @@ func parseTargetsGroups(md *modules.Module, errorList *errors.LintRuleErrorsList
 			continue
 		}
 
-		fillVPAMaps(vpaTargets, vpaContainerNamesMap, vpaUpdateModes, object, errorList)
+		fillVPAMaps(vpaTargets, vpaContainerNamesMap, vpaUpdateModes, vpaContainerPolicies, object, errorList)
 	}
 
-	return vpaTargets, vpaContainerNamesMap, vpaUpdateModes
+	return vpaTargets, vpaContainerNamesMap, vpaUpdateModes, vpaContainerPolicies
 }
 
 func fillVPAMaps(
 	vpaTargets map[storage.ResourceIndex]struct{},
 	vpaContainerNamesMap map[storage.ResourceIndex]set.Set,
 	vpaUpdateModes map[storage.ResourceIndex]UpdateMode,
+	vpaContainerPolicies map[storage.ResourceIndex][]ContainerResourcePolicy,
 	vpa storage.StoreObject,
 	errorList *errors.LintRuleErrorsList,
 ) {
@@ -120,13 +123,19 @@ func fillVPAMaps(
 
 	vpaTargets[target] = struct{}{}
 
-	updateMode, vnm, ok := parseVPAResourcePolicyContainers(vpa, errorList)
+	updateMode, policies, ok := parseVPAResourcePolicyContainers(vpa, errorList)
 	if !ok {
 		return
 	}
 
+	vnm := set.New()
+	for i := range policies {
+		vnm.Add(policies[i].ContainerName)
+	}
+
 	vpaContainerNamesMap[target] = vnm
 	vpaUpdateModes[target] = updateMode
+	vpaContainerPolicies[target] = policies
 }
 
 // isValidUpdateMode checks if the updateMode is one of the allowed values
@@ -139,30 +148,28 @@ func isValidUpdateMode(updateMode UpdateMode) bool {
 	}
 }
 
-// parseVPAResourcePolicyContainers parses VPA containers names in ResourcePolicy and check if minAllowed and maxAllowed for container is set
-func parseVPAResourcePolicyContainers(vpaObject storage.StoreObject, errorList *errors.LintRuleErrorsList) (UpdateMode, set.Set, bool) {
+// parseVPAResourcePolicyContainers parses VPA container policies in ResourcePolicy and check if minAllowed and maxAllowed for container is set
+func parseVPAResourcePolicyContainers(vpaObject storage.StoreObject, errorList *errors.LintRuleErrorsList) (UpdateMode, []ContainerResourcePolicy, bool) {
 	errorListObj := errorList.WithObjectID(vpaObject.Identity()).WithFilePath(vpaObject.ShortPath())
 
-	containers := set.New()
-
 	v := &VerticalPodAutoscaler{}
 
 	err := runtime.DefaultUnstructuredConverter.FromUnstructured(vpaObject.Unstructured.UnstructuredContent(), v)
 	if err != nil {
 		errorListObj.Errorf("Cannot unmarshal VPA object: %v", err)
 
-		return "", containers, false
+		return "", nil, false
 	}
 
 	updateMode := *v.Spec.UpdatePolicy.UpdateMode
 	if updateMode == UpdateModeOff {
-		return updateMode, containers, true
+		return updateMode, nil, true
 	}
 
 	if v.Spec.ResourcePolicy == nil || len(v.Spec.ResourcePolicy.ContainerPolicies) == 0 {
 		errorListObj.Error("No VPA specs resourcePolicy.containerPolicies is found for object")
 
-		return updateMode, containers, false
+		return updateMode, nil, false
 	}
 
 	if updateMode == UpdateModeAuto {
@@ -197,11 +204,9 @@ func parseVPAResourcePolicyContainers(vpaObject storage.StoreObject, errorList *
 		if cp.MinAllowed.Memory().Cmp(*cp.MaxAllowed.Memory()) > 0 {
 			errorListObj.Errorf("MinAllowed.memory for container %s should be less than maxAllowed.memory", cp.ContainerName)
 		}
-
-		containers.Add(cp.ContainerName)
 	}
 
-	return updateMode, containers, true
+	return updateMode, v.Spec.ResourcePolicy.ContainerPolicies, true
 }
 
 // parseVPATargetIndex parses VPA target resource index, writes to the passed struct pointer
@@ -55,8 +55,14 @@ func (l *Templates) Run(m *modules.Module) {
 
 	// VPA
 	rules.NewVPARule(l.cfg.ExcludeRules.VPAAbsent.Get()).ControllerMustHaveVPA(m, errorList.WithMaxLevel(l.cfg.Rules.VPARule.GetLevel()))
+	// Resource requests
+	rules.NewResourceRequestsRule(l.cfg.ExcludeRules.ResourceRequests.Get(), l.cfg.ResourceRequestsSettings).
+		CheckResourceRequests(m, errorList.WithMaxLevel(l.cfg.Rules.ResourceRequestsRule.GetLevel()))
 	// PDB
 	rules.NewPDBRule(l.cfg.ExcludeRules.PDBAbsent.Get()).ControllerMustHavePDB(m, errorList.WithMaxLevel(l.cfg.Rules.PDBRule.GetLevel()))
+	// High availability
+	rules.NewHighAvailabilityRule(l.cfg.ExcludeRules.HighAvailability.Get()).
+		ControllersMustBeHighlyAvailable(m, errorList.WithMaxLevel(l.cfg.Rules.HighAvailabilityRule.GetLevel()))
 	// Ingress
 	ingressRule := rules.NewIngressRule(l.cfg.ExcludeRules.Ingress.Get())
 	// HttpRoute
@@ -85,6 +91,14 @@ func (l *Templates) Run(m *modules.Module) {
 
 	httpRouteRule.ModuleMustHaveGatewayResources(m, errorList.WithMaxLevel(l.cfg.Rules.HTTPRouteRule.GetLevel()))
 
+	// Service wiring rule
+	rules.NewServiceWiringRule(l.cfg.ExcludeRules.ServiceWiring.Get()).
+		CheckServiceWiring(m, errorList.WithMaxLevel(l.cfg.Rules.ServiceWiringRule.GetLevel()))
+
+	// NetworkPolicy coverage rule
+	rules.NewNetworkPolicyRule(l.cfg.ExcludeRules.NetworkPolicy.Get(), l.cfg.NetworkPolicySettings.RequireFullCoverage).
+		CheckNetworkPolicyCoverage(m, errorList.WithMaxLevel(l.cfg.Rules.NetworkPolicyRule.GetLevel()))
+
 	// Cluster domain rule
 	clusterDomainRule := rules.NewClusterDomainRule()
 	clusterDomainRule.ValidateClusterDomainInTemplates(m, errorList.WithMaxLevel(l.cfg.Rules.ClusterDomainRule.GetLevel()))
@@ -110,6 +124,11 @@ func (l *Templates) Run(m *modules.Module) {
 	rules.NewCRDEnabledModulesRule().
 		CheckCRDEnabledModules(m, errorList.WithMaxLevel(l.cfg.Rules.CRDEnabledModulesRule.GetLevel()))
 
+	// GlobalValues rule - references to global values missing from the schema of
+	// the targeted Deckhouse version
+	rules.NewGlobalValuesRule().
+		CheckGlobalValuesReferences(m, m.GetGlobalSchema(), errorList.WithMaxLevel(l.cfg.Rules.GlobalValuesRule.GetLevel()))
+
 	// MountPoints rule
 	rules.NewMountPointsRule(l.cfg.ExcludeRules.MountPoints.Get()).ValidateMountPoints(m, errorList.WithMaxLevel(l.cfg.Rules.MountPointsRule.GetLevel()))
 
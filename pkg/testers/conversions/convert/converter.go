@@ -194,5 +194,5 @@ func parseConversionFile(path string) (conversionFile, error) {
 }
 
 func isConversionFile(name string) bool {
-	return filepath.Ext(name) == ".yaml" && name != "testcases.yaml"
+	return filepath.Ext(name) == ".yaml" && name != "testcases.yaml" && !strings.HasSuffix(name, "-schema.yaml")
 }
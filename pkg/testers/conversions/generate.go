@@ -0,0 +1,263 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tester
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/go-openapi/spec"
+	"github.com/mohae/deepcopy"
+
+	"github.com/deckhouse/dmt/internal/modules/values/schema/defaults"
+	"github.com/deckhouse/dmt/internal/modules/values/schema/reggen"
+	"github.com/deckhouse/dmt/pkg/testers/conversions/convert"
+)
+
+const (
+	// generatedCases is the number of schema-valid settings generated per
+	// config version.
+	generatedCases = 25
+	// generateAttempts bounds the attempts to generate them: candidates that do
+	// not satisfy the schema (e.g. a missed cross-field constraint) are dropped.
+	generateAttempts = generatedCases * 8
+	// generateSeed makes the generated cases reproducible between runs.
+	generateSeed = 1
+
+	maxGenerateDepth = 8
+	maxGeneratedKeys = 2
+	maxGeneratedItem = 3
+	maxStringLength  = 8
+)
+
+// generateSettings returns up to generatedCases distinct settings that are
+// valid for the schema: the fullest config built from the schema's defaults
+// and examples, followed by random configs with random optional fields.
+func generateSettings(s *versionSchema, seed int64) []map[string]any {
+	var (
+		result []map[string]any
+		seen   = map[string]struct{}{}
+	)
+
+	add := func(settings map[string]any) {
+		// Round-trip through YAML so values have the types parsed settings have.
+		key := convert.FormatYAML(settings)
+
+		settings, err := convert.ParseYAML(key)
+		if err != nil || settings == nil || validateSettings(s, settings) != nil {
+			return
+		}
+
+		if _, ok := seen[key]; ok {
+			return
+		}
+
+		seen[key] = struct{}{}
+		result = append(result, settings)
+	}
+
+	if full, err := defaults.Generate(deepcopy.Copy(s.schema).(*spec.Schema)); err == nil {
+		add(full)
+	}
+
+	g := &generator{rand: rand.New(rand.NewSource(seed))} //nolint:gosec // reproducible test data
+
+	for i := 0; i < generateAttempts && len(result) < generatedCases; i++ {
+		if settings, ok := g.value(s.schema, 0).(map[string]any); ok {
+			add(settings)
+		}
+	}
+
+	return result
+}
+
+// generator produces random values for an OpenAPI schema. It aims at valid
+// values but does not guarantee them; callers validate the result.
+type generator struct {
+	rand *rand.Rand
+}
+
+func (g *generator) value(s *spec.Schema, depth int) any {
+	switch {
+	case len(s.Enum) > 0:
+		return deepcopy.Copy(s.Enum[g.rand.Intn(len(s.Enum))])
+	case s.Default != nil && g.rand.Intn(4) == 0:
+		return deepcopy.Copy(s.Default)
+	case len(s.OneOf) > 0:
+		return g.value(&s.OneOf[g.rand.Intn(len(s.OneOf))], depth)
+	case len(s.AnyOf) > 0:
+		return g.value(&s.AnyOf[g.rand.Intn(len(s.AnyOf))], depth)
+	}
+
+	switch schemaType(s) {
+	case "object":
+		return g.object(s, depth)
+	case "array":
+		return g.array(s, depth)
+	case "string":
+		return g.string(s)
+	case "integer":
+		return g.number(s, true)
+	case "number":
+		return g.number(s, false)
+	case "boolean":
+		return g.rand.Intn(2) == 0
+	default:
+		return nil
+	}
+}
+
+func (g *generator) object(s *spec.Schema, depth int) map[string]any {
+	obj := map[string]any{}
+	if depth >= maxGenerateDepth {
+		return obj
+	}
+
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+
+	// Map iteration order is random; sort to keep the output reproducible.
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !required[name] && g.rand.Intn(2) == 0 {
+			continue
+		}
+
+		prop := s.Properties[name]
+		if v := g.value(&prop, depth+1); v != nil {
+			obj[name] = v
+		}
+	}
+
+	if ap := s.AdditionalProperties; ap != nil && ap.Schema != nil {
+		for range g.rand.Intn(maxGeneratedKeys + 1) {
+			if v := g.value(ap.Schema, depth+1); v != nil {
+				obj[g.word()] = v
+			}
+		}
+	}
+
+	return obj
+}
+
+func (g *generator) array(s *spec.Schema, depth int) []any {
+	minItems, maxItems := 0, maxGeneratedItem
+	if s.MinItems != nil {
+		minItems = int(*s.MinItems)
+		maxItems = max(maxItems, minItems)
+	}
+
+	if s.MaxItems != nil {
+		maxItems = min(maxItems, int(*s.MaxItems))
+	}
+
+	items := []any{}
+	if s.Items == nil || s.Items.Schema == nil || depth >= maxGenerateDepth || maxItems < minItems {
+		return items
+	}
+
+	for range minItems + g.rand.Intn(maxItems-minItems+1) {
+		if v := g.value(s.Items.Schema, depth+1); v != nil {
+			items = append(items, v)
+		}
+	}
+
+	return items
+}
+
+func (g *generator) string(s *spec.Schema) string {
+	if s.Pattern != "" {
+		if rg, err := reggen.NewGenerator(s.Pattern); err == nil {
+			rg.SetSeed(g.rand.Int63())
+			return rg.Generate(maxStringLength)
+		}
+	}
+
+	minLength, maxLength := 1, maxStringLength
+	if s.MinLength != nil {
+		minLength = int(*s.MinLength)
+		maxLength = max(maxLength, minLength)
+	}
+
+	if s.MaxLength != nil {
+		maxLength = min(maxLength, int(*s.MaxLength))
+	}
+
+	if maxLength < minLength {
+		return ""
+	}
+
+	return g.letters(minLength + g.rand.Intn(maxLength-minLength+1))
+}
+
+func (g *generator) number(s *spec.Schema, integer bool) any {
+	lo, hi := 0.0, 100.0
+	if s.Minimum != nil {
+		lo = *s.Minimum
+		hi = max(hi, lo+100)
+	}
+
+	if s.Maximum != nil {
+		hi = *s.Maximum
+		if s.Minimum == nil {
+			lo = min(lo, hi-100)
+		}
+	}
+
+	v := lo + g.rand.Float64()*(hi-lo)
+	if integer {
+		return int64(v)
+	}
+
+	return v
+}
+
+func (g *generator) word() string {
+	return g.letters(1 + g.rand.Intn(maxStringLength))
+}
+
+func (g *generator) letters(n int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.rand.Intn(len(alphabet))]
+	}
+
+	return string(b)
+}
+
+func schemaType(s *spec.Schema) string {
+	switch {
+	case len(s.Type) > 0:
+		return s.Type[0]
+	case len(s.Properties) > 0 || (s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil):
+		return "object"
+	case s.Items != nil:
+		return "array"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tester
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/gitutils"
+	"github.com/deckhouse/dmt/internal/modules/values"
+)
+
+// versionSchema is the config-values schema of a config version.
+type versionSchema struct {
+	schema *spec.Schema
+	// source tells where the schema comes from, for messages.
+	source string
+}
+
+// schemaHistory resolves the config-values schema of every config version of a
+// module. The schema of a past version is taken, in order of preference, from
+// `openapi/conversions/v<N>-schema.yaml`, or from the newest git revision of
+// `openapi/config-values.yaml` that declared that version.
+type schemaHistory struct {
+	modulePath     string
+	currentVersion int
+
+	resolved map[int]*versionSchema
+	// gitScanned is set once the git history has been read into resolved.
+	gitScanned bool
+}
+
+func newSchemaHistory(modulePath string, currentVersion int) *schemaHistory {
+	return &schemaHistory{
+		modulePath:     modulePath,
+		currentVersion: currentVersion,
+		resolved:       map[int]*versionSchema{},
+	}
+}
+
+// forVersion returns the schema of the given config version, or nil when it is
+// unknown or declares nothing to validate against.
+func (h *schemaHistory) forVersion(version int) (*versionSchema, error) {
+	if s, ok := h.resolved[version]; ok {
+		return s, nil
+	}
+
+	s, err := h.resolve(version)
+	if err != nil {
+		return nil, err
+	}
+
+	h.resolved[version] = s
+
+	return s, nil
+}
+
+func (h *schemaHistory) resolve(version int) (*versionSchema, error) {
+	if version == h.currentVersion {
+		return loadVersionSchema(filepath.Join(h.modulePath, configValuesFile), configValuesFile)
+	}
+
+	name := fmt.Sprintf("v%d-schema.yaml", version)
+
+	s, err := loadVersionSchema(filepath.Join(h.modulePath, conversionsFolder, name), conversionsFolder+"/"+name)
+	if err != nil || s != nil {
+		return s, err
+	}
+
+	if h.gitScanned {
+		return nil, nil
+	}
+
+	h.gitScanned = true
+
+	for v, s := range h.scanGitHistory() {
+		if _, ok := h.resolved[v]; !ok && v != h.currentVersion {
+			h.resolved[v] = s
+		}
+	}
+
+	return h.resolved[version], nil
+}
+
+// scanGitHistory reads the config-values schema of every past config version
+// from the git history of openapi/config-values.yaml. Modules outside a git
+// repository simply have no history.
+func (h *schemaHistory) scanGitHistory() map[int]*versionSchema {
+	found := map[int]*versionSchema{}
+
+	absModule, err := filepath.Abs(h.modulePath)
+	if err != nil {
+		return found
+	}
+
+	topLevel, err := gitutils.Run(absModule, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return found
+	}
+
+	rel, err := filepath.Rel(strings.TrimSpace(topLevel), filepath.Join(absModule, configValuesFile))
+	if err != nil {
+		return found
+	}
+
+	rel = filepath.ToSlash(rel)
+
+	log, err := gitutils.Run(absModule, "log", "--format=%H", "--", ":/"+rel)
+	if err != nil {
+		return found
+	}
+
+	// Newest first: the latest revision declaring a version wins.
+	for _, sha := range strings.Fields(log) {
+		content, err := gitutils.Run(absModule, "show", sha+":"+rel)
+		if err != nil {
+			continue
+		}
+
+		version := parseConfigVersion([]byte(content))
+		if _, ok := found[version]; ok {
+			continue
+		}
+
+		s, err := parseVersionSchema([]byte(content), fmt.Sprintf("%s at %.12s", configValuesFile, sha))
+		if err != nil || s == nil {
+			continue
+		}
+
+		found[version] = s
+
+		if version <= 1 {
+			break
+		}
+	}
+
+	return found
+}
+
+func loadVersionSchema(path, source string) (*versionSchema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("cannot read schema: %w", err)
+	}
+
+	return parseVersionSchema(content, source)
+}
+
+// parseVersionSchema loads a config-values schema. A schema with neither a type
+// nor properties (e.g. only x-config-version) has nothing to validate and
+// yields nil.
+func parseVersionSchema(content []byte, source string) (*versionSchema, error) {
+	schema, err := values.LoadConfigValuesSchema(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load schema %s: %w", source, err)
+	}
+
+	if len(schema.Type) == 0 && len(schema.Properties) == 0 {
+		return nil, nil
+	}
+
+	return &versionSchema{schema: schema, source: source}, nil
+}
+
+// parseConfigVersion returns x-config-version of a config-values schema; a
+// schema without it is version 1.
+func parseConfigVersion(content []byte) int {
+	var configValues struct {
+		ConfigVersion int `json:"x-config-version"`
+	}
+
+	if err := yaml.Unmarshal(content, &configValues); err != nil || configValues.ConfigVersion < 1 {
+		return 1
+	}
+
+	return configValues.ConfigVersion
+}
+
+// validateSettings validates settings against the schema and returns the
+// violations, sorted, or nil when the settings are valid.
+func validateSettings(s *versionSchema, settings map[string]any) []string {
+	var data any = settings
+	if settings == nil {
+		data = map[string]any{}
+	}
+
+	return values.ValidateValues(s.schema, data)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tester
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+// v1Schema has `replicas`; v2 renames it to `replicaMode`.
+const v1Schema = `
+type: object
+properties:
+  replicas:
+    type: string
+    enum: ["one", "two"]
+  logLevel:
+    type: string
+    pattern: '^(debug|info)$'
+`
+
+const v2Schema = `x-config-version: 2
+type: object
+properties:
+  replicaMode:
+    type: string
+    enum: ["one", "two"]
+  logLevel:
+    type: string
+    pattern: '^(debug|info)$'
+`
+
+func writeSchemaModule(t *testing.T, conversion, testcases string) string {
+	t.Helper()
+
+	modulePath := t.TempDir()
+	convDir := filepath.Join(modulePath, "openapi", "conversions")
+	require.NoError(t, os.MkdirAll(convDir, 0o755))
+
+	files := map[string]string{
+		filepath.Join(modulePath, "openapi", "config-values.yaml"): v2Schema,
+		filepath.Join(convDir, "v1-schema.yaml"):                   v1Schema,
+		filepath.Join(convDir, "v2.yaml"): "version: 2\nconversions:\n  - " + conversion +
+			"\ndescription:\n  en: v2\n  ru: v2\n",
+	}
+
+	if testcases != "" {
+		files[filepath.Join(convDir, "testcases.yaml")] = testcases
+	}
+
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return modulePath
+}
+
+const renameReplicas = `if has("replicas") then .replicaMode = .replicas | del(.replicas) end`
+
+func TestConversionsTester_GeneratedCasesPass(t *testing.T) {
+	errorList := pkgerrors.NewTestErrorsList()
+
+	assert.True(t, New(errorList).Run(writeSchemaModule(t, renameReplicas, "")), "generated cases make the tester applicable")
+	assert.Empty(t, errorList.GetErrors())
+}
+
+func TestConversionsTester_GeneratedCasesFail(t *testing.T) {
+	errorList := pkgerrors.NewTestErrorsList()
+	New(errorList).Run(writeSchemaModule(t, "del(.unused)", ""))
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1, "only the first failing generated case is reported")
+	assert.True(t, strings.HasPrefix(errs[0].TestName, "generated v1 to v2 #"))
+	assert.Contains(t, errs[0].Text, "replicas")
+	assert.Contains(t, errs[0].Text, "openapi/config-values.yaml")
+	assert.Contains(t, errs[0].Output, "settings:\n")
+	assert.Contains(t, errs[0].Output, "converted:\n")
+}
+
+func TestConversionsTester_TestcaseSchemaValidation(t *testing.T) {
+	testcases := `testcases:
+  - name: "input not valid for v1"
+    currentVersion: 1
+    expectedVersion: 2
+    settings: |
+      replicas: three
+    expected: |
+      replicaMode: three
+  - name: "output not valid for v2"
+    currentVersion: 2
+    expectedVersion: 2
+    settings: |
+      replicaMode: three
+    expected: |
+      replicaMode: three
+`
+
+	errorList := pkgerrors.NewTestErrorsList()
+	New(errorList).Run(writeSchemaModule(t, renameReplicas, testcases))
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	require.Len(t, texts, 4, texts)
+	assert.Contains(t, texts[0], `testcase "input not valid for v1": settings do not match the schema of version 1 (openapi/conversions/v1-schema.yaml)`)
+	assert.Contains(t, texts[1], `testcase "input not valid for v1": converted settings do not match the schema of version 2 (openapi/config-values.yaml)`)
+	assert.Contains(t, texts[2], `testcase "output not valid for v2": settings do not match the schema of version 2`)
+	assert.Contains(t, texts[3], `testcase "output not valid for v2": converted settings do not match the schema of version 2`)
+}
+
+func TestSchemaHistoryFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repo := t.TempDir()
+	modulePath := filepath.Join(repo, "modules", "app")
+	configValues := filepath.Join(modulePath, "openapi", "config-values.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configValues), 0o755))
+
+	git := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "-q")
+
+	for _, content := range []string{v1Schema, v2Schema} {
+		require.NoError(t, os.WriteFile(configValues, []byte(content), 0o600))
+		git("add", "-A")
+		git("commit", "-q", "-m", "schema")
+	}
+
+	history := newSchemaHistory(modulePath, 2)
+
+	v1, err := history.forVersion(1)
+	require.NoError(t, err)
+	require.NotNil(t, v1)
+	assert.True(t, strings.HasPrefix(v1.source, "openapi/config-values.yaml at "), v1.source)
+	assert.Contains(t, v1.schema.Properties, "replicas")
+
+	v3, err := history.forVersion(3)
+	require.NoError(t, err)
+	assert.Nil(t, v3)
+}
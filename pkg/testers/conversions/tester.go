@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"sigs.k8s.io/yaml"
 
@@ -88,7 +89,7 @@ func (t *Tester) Run(modulePath string) bool {
 		return true
 	}
 
-	return t.runConversions(convFolder, errorList)
+	return t.runConversions(modulePath, convFolder, configVersion, errorList)
 }
 
 // checkConversions verifies that the module has a conversions folder and a valid config version.
@@ -126,9 +127,26 @@ func (t *Tester) parseConversions(convFolder string) (int, error) {
 	return convert.ValidateConversions(convFolder)
 }
 
-// runConversions executes testcases against the converter and records results into ErrorList.
-// Returns true if testcases were found (tester is applicable), false if no testcases file exists.
-func (t *Tester) runConversions(convFolder string, errorList *pkgerrors.TestErrorsList) bool {
+// runConversions executes testcases against the converter, validates them
+// against the config-values schemas of their versions and runs generated cases,
+// recording results into ErrorList. Returns true if anything was tested.
+func (t *Tester) runConversions(modulePath, convFolder string, configVersion int, errorList *pkgerrors.TestErrorsList) bool {
+	converter, err := convert.NewConverter(convFolder)
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	history := newSchemaHistory(modulePath, max(configVersion, 1))
+
+	ranTestcases := t.runTestcases(convFolder, converter, history, errorList)
+	ranGenerated := t.runGeneratedCases(converter, history, configVersion, errorList)
+
+	return ranTestcases || ranGenerated
+}
+
+// runTestcases executes testcases.yaml. Returns false if no testcases file exists.
+func (t *Tester) runTestcases(convFolder string, converter *convert.Converter, history *schemaHistory, errorList *pkgerrors.TestErrorsList) bool {
 	testcasesPath := filepath.Join(convFolder, "testcases.yaml")
 
 	_, err := os.Stat(testcasesPath)
@@ -147,43 +165,163 @@ func (t *Tester) runConversions(convFolder string, errorList *pkgerrors.TestErro
 		return true
 	}
 
-	converter, err := convert.NewConverter(convFolder)
+	for _, tc := range testcases {
+		errorList.RunCase(tc.Name, func(caseErrors *pkgerrors.TestErrorsList) {
+			runTestcase(tc, converter, history, errorList, caseErrors)
+		})
+	}
+
+	return true
+}
+
+// runTestcase runs a single testcase of testcases.yaml.
+func runTestcase(tc testcase, converter *convert.Converter, history *schemaHistory, errorList, caseErrors *pkgerrors.TestErrorsList) {
+	settings, err := convert.ParseYAML(tc.Settings)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
+		return
+	}
+
+	checkSchema(history, max(tc.CurrentVersion, 1), settings, caseErrors,
+		fmt.Sprintf("testcase %q: settings", tc.Name))
+
+	converted, err := converter.ConvertTo(tc.CurrentVersion, tc.ExpectedVersion, settings)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
+		return
+	}
+
+	expected, err := convert.ParseYAML(tc.Expected)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
+		return
+	}
+
+	if !convert.MapsEqual(converted, expected) {
+		caseErrors.AddTestResult(
+			fmt.Sprintf("testcase %q: conversion mismatch", tc.Name),
+			convert.FormatYAML(converted),
+			convert.FormatYAML(expected),
+		)
+
+		return
+	}
+
+	checkSchema(history, max(tc.ExpectedVersion, 1), converted, caseErrors,
+		fmt.Sprintf("testcase %q: converted settings", tc.Name))
+}
+
+// runGeneratedCases generates schema-valid settings for every past config
+// version and checks that converting them to the current version yields
+// settings valid for the current schema. Only the first failing case of each
+// version is reported. Returns false if there is no schema to generate from.
+func (t *Tester) runGeneratedCases(converter *convert.Converter, history *schemaHistory, configVersion int, errorList *pkgerrors.TestErrorsList) bool {
+	if configVersion < 2 {
+		return false
+	}
+
+	target, err := history.forVersion(configVersion)
 	if err != nil {
 		errorList.Errorf("%s", err.Error())
 		return true
 	}
 
-	for _, tc := range testcases {
-		settings, err := convert.ParseYAML(tc.Settings)
+	if target == nil {
+		return false
+	}
+
+	ran := false
+
+	for version := 1; version < configVersion; version++ {
+		source, err := history.forVersion(version)
 		if err != nil {
-			errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
+			errorList.Errorf("%s", err.Error())
 			continue
 		}
 
-		converted, err := converter.ConvertTo(tc.CurrentVersion, tc.ExpectedVersion, settings)
-		if err != nil {
-			errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
+		if source == nil {
 			continue
 		}
 
-		expected, err := convert.ParseYAML(tc.Expected)
+		ran = true
+
+		errorList.RunCase(fmt.Sprintf("generated v%d to v%d", version, configVersion), func(_ *pkgerrors.TestErrorsList) {
+			runGeneratedCase(converter, source, target, version, configVersion, errorList)
+		})
+	}
+
+	return ran
+}
+
+// runGeneratedCase converts the settings generated for a past config version
+// to the current one and reports the first case that fails.
+func runGeneratedCase(converter *convert.Converter, source, target *versionSchema, version, configVersion int, errorList *pkgerrors.TestErrorsList) {
+	for i, settings := range generateSettings(source, generateSeed+int64(version)) {
+		name := fmt.Sprintf("generated v%d to v%d #%d", version, configVersion, i+1)
+
+		converted, err := converter.ConvertTo(version, configVersion, settings)
 		if err != nil {
-			errorList.Errorf("testcase %q: %s", tc.Name, err.Error())
-			continue
+			errorList.WithTestName(name).
+				AddTestOutput(fmt.Sprintf("%s: conversion failed: %s", name, err.Error()),
+					formatCase(settings, nil))
+
+			return
 		}
 
-		if !convert.MapsEqual(converted, expected) {
-			errorList.
-				WithTestName(tc.Name).
-				AddTestResult(
-					fmt.Sprintf("testcase %q: conversion mismatch", tc.Name),
-					convert.FormatYAML(converted),
-					convert.FormatYAML(expected),
-				)
+		if violations := validateSettings(target, converted); violations != nil {
+			errorList.WithTestName(name).
+				AddTestOutput(fmt.Sprintf("%s: converted settings do not match the schema of version %d (%s): %s",
+					name, configVersion, target.source, strings.Join(violations, "; ")),
+					formatCase(settings, converted))
+
+			return
 		}
 	}
+}
 
-	return true
+// checkSchema validates settings against the schema of the given version, if
+// it is known, and reports the violations.
+func checkSchema(history *schemaHistory, version int, settings map[string]any, errorList *pkgerrors.TestErrorsList, what string) {
+	s, err := history.forVersion(version)
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return
+	}
+
+	if s == nil {
+		return
+	}
+
+	if violations := validateSettings(s, settings); violations != nil {
+		errorList.Errorf("%s do not match the schema of version %d (%s): %s",
+			what, version, s.source, strings.Join(violations, "; "))
+	}
+}
+
+// formatCase shows a generated case: its settings and, if any, their conversion.
+func formatCase(settings, converted map[string]any) string {
+	var sb strings.Builder
+
+	sb.WriteString("settings:\n")
+	sb.WriteString(indent(convert.FormatYAML(settings)))
+
+	if converted != nil {
+		sb.WriteString("converted:\n")
+		sb.WriteString(indent(convert.FormatYAML(converted)))
+	}
+
+	return sb.String()
+}
+
+func indent(text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+
+	return strings.Join(lines, "")
 }
 
 func hasConversionsFolder(convFolder string) (bool, error) {
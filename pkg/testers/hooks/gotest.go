@@ -0,0 +1,267 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// waitDelay is how long a timed out `go test` may take to exit after it has
+// been killed before its output pipes are closed forcibly.
+const waitDelay = 5 * time.Second
+
+// testEvent is a `go test -json` (test2json) event.
+type testEvent struct {
+	Action      string
+	Package     string
+	Test        string
+	Elapsed     float64
+	Output      string
+	ImportPath  string
+	FailedBuild string
+}
+
+// failure is a failed test, or a failed package when test is empty.
+type failure struct {
+	pkg     string
+	test    string
+	build   bool
+	elapsed time.Duration
+	output  string
+}
+
+// testCase is a top-level Go test that finished, or a package that failed
+// without a failed test (e.g. it did not build), when test is empty.
+type testCase struct {
+	pkg     string
+	test    string
+	elapsed time.Duration
+}
+
+// name identifies the case like `go test` does: "<package>.<test>".
+func (c testCase) name() string {
+	if c.test == "" {
+		return c.pkg
+	}
+
+	return c.pkg + "." + c.test
+}
+
+type goTestResult struct {
+	// cases are in the order the tests finished.
+	cases    []testCase
+	failures []failure
+}
+
+// caseFailures returns the failures of a case: the failures of the test and its
+// subtests, or of the package for a package case.
+func (r *goTestResult) caseFailures(c testCase) []failure {
+	var failures []failure
+
+	for _, f := range r.failures {
+		if f.pkg == c.pkg && topLevelTest(f.test) == c.test {
+			failures = append(failures, f)
+		}
+	}
+
+	return failures
+}
+
+// topLevelTest returns the top-level test of a (sub)test name.
+func topLevelTest(test string) string {
+	top, _, _ := strings.Cut(test, "/")
+
+	return top
+}
+
+// runGoTest runs `go test -json <pattern>` in dir as a separate process bound
+// by timeout. Test failures are part of the result; an error means the suite
+// could not be run to completion.
+func runGoTest(dir, pattern string, timeout time.Duration) (*goTestResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "go", "test", "-json", "-timeout", timeout.String(), pattern)
+	cmd.Dir = dir
+	// Test the hooks module on its own, even if dmt runs inside a Go workspace.
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+
+	runErr := cmd.Run()
+
+	res, err := parseTestEvents(&stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return res, fmt.Errorf("timed out after %s", timeout)
+	}
+
+	if runErr != nil && len(res.failures) == 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = runErr.Error()
+		}
+
+		return nil, errors.New(msg)
+	}
+
+	return res, nil
+}
+
+type eventKey struct {
+	pkg, test string
+}
+
+// parseTestEvents collects the finished top-level tests and the failed tests
+// and packages from a test2json stream. A failed test is only reported when none of its subtests failed, and
+// a failed package only when none of its tests did, so every failure is shown
+// once, with the output of the test that caused it. A failed package becomes a
+// case of its own only then, as its tests are cases already.
+func parseTestEvents(r io.Reader) (*goTestResult, error) {
+	var (
+		outputs     = map[eventKey]*strings.Builder{}
+		buildOutput = map[string]*strings.Builder{}
+		failed      []testEvent
+		finished    []testEvent
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var ev testEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, fmt.Errorf("parse go test output: %w", err)
+		}
+
+		switch ev.Action {
+		case "output":
+			if isFramingLine(ev.Output) {
+				continue
+			}
+
+			key := eventKey{ev.Package, ev.Test}
+			if outputs[key] == nil {
+				outputs[key] = &strings.Builder{}
+			}
+
+			outputs[key].WriteString(ev.Output)
+		case "build-output":
+			if buildOutput[ev.ImportPath] == nil {
+				buildOutput[ev.ImportPath] = &strings.Builder{}
+			}
+
+			buildOutput[ev.ImportPath].WriteString(ev.Output)
+		case "fail":
+			failed = append(failed, ev)
+
+			if ev.Test != "" && !strings.Contains(ev.Test, "/") {
+				finished = append(finished, ev)
+			}
+		case "pass", "skip":
+			if ev.Test != "" && !strings.Contains(ev.Test, "/") {
+				finished = append(finished, ev)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read go test output: %w", err)
+	}
+
+	res := &goTestResult{}
+
+	for _, ev := range finished {
+		res.cases = append(res.cases, testCase{pkg: ev.Package, test: ev.Test, elapsed: elapsed(ev)})
+	}
+
+	for _, ev := range failed {
+		if hasFailedChild(failed, ev) {
+			continue
+		}
+
+		if ev.Test == "" {
+			res.cases = append(res.cases, testCase{pkg: ev.Package, elapsed: elapsed(ev)})
+		}
+
+		f := failure{
+			pkg:     ev.Package,
+			test:    ev.Test,
+			elapsed: elapsed(ev),
+		}
+
+		if b := outputs[eventKey{ev.Package, ev.Test}]; b != nil {
+			f.output = b.String()
+		}
+
+		if ev.FailedBuild != "" {
+			f.build = true
+			if b := buildOutput[ev.FailedBuild]; b != nil {
+				f.output = b.String()
+			}
+		}
+
+		res.failures = append(res.failures, f)
+	}
+
+	return res, nil
+}
+
+func elapsed(ev testEvent) time.Duration {
+	return time.Duration(ev.Elapsed * float64(time.Second))
+}
+
+func hasFailedChild(failed []testEvent, parent testEvent) bool {
+	for _, ev := range failed {
+		if ev.Package != parent.Package || ev.Test == parent.Test {
+			continue
+		}
+
+		if parent.Test == "" || strings.HasPrefix(ev.Test, parent.Test+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isFramingLine reports whether a test output line is one of the `=== RUN`
+// style lines go test prints around the actual test output.
+func isFramingLine(line string) bool {
+	return strings.HasPrefix(line, "=== ")
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks implements the "hooks" tester. It runs the `go test` suites of
+// a module's Go hooks built on module-sdk and reports the failed tests.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	// ID is the tester identifier surfaced in results.
+	ID = "hooks"
+	// DefaultTimeout bounds a single `go test` run.
+	DefaultTimeout = 10 * time.Minute
+
+	hooksDir      = "hooks"
+	moduleSDKPath = "github.com/deckhouse/module-sdk"
+)
+
+type Tester struct {
+	name, desc string
+	timeout    time.Duration
+
+	ErrorList *pkgerrors.TestErrorsList
+}
+
+// New creates the hooks tester. A non-positive timeout means DefaultTimeout.
+func New(errorList *pkgerrors.TestErrorsList, timeout time.Duration) *Tester {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Tester{
+		name:      ID,
+		desc:      "Runs the go test suites of module-sdk based Go hooks",
+		timeout:   timeout,
+		ErrorList: errorList.WithTestGroup(ID),
+	}
+}
+
+func (t *Tester) Name() string { return t.name }
+func (t *Tester) Desc() string { return t.desc }
+
+// Run executes the hooks tester against the given module path.
+// Returns true if the tester was applicable (i.e. the module has Go hooks).
+func (t *Tester) Run(modulePath string) bool {
+	errorList := t.ErrorList.WithModule(filepath.Base(modulePath))
+
+	suites := findSuites(modulePath)
+	if len(suites) == 0 {
+		return false
+	}
+
+	for _, s := range suites {
+		if errorList.Stopped() {
+			break
+		}
+
+		runSuite(modulePath, s, t.timeout, errorList)
+	}
+
+	return true
+}
+
+// suite is a Go module to test: `go test <pattern>` runs in dir.
+type suite struct {
+	dir     string
+	pattern string
+}
+
+// name identifies the suite by its package pattern relative to the module,
+// e.g. "hooks/...".
+func (s suite) name(modulePath string) string {
+	return filepath.ToSlash(filepath.Join(relPath(modulePath, s.dir), s.pattern))
+}
+
+// runSuite runs a single go test suite and records every top-level Go test it
+// ran as a testcase, with the test's failures and its own duration. A suite
+// that could not be run to completion is a failed testcase named after it.
+func runSuite(modulePath string, s suite, timeout time.Duration, errorList *pkgerrors.TestErrorsList) {
+	res, err := runGoTest(s.dir, s.pattern, timeout)
+	if res != nil {
+		for _, c := range res.cases {
+			errorList.RecordCase(c.name(), c.elapsed, func(caseErrors *pkgerrors.TestErrorsList) {
+				for _, f := range res.caseFailures(c) {
+					caseErrors.AddTestOutput(f.text(), f.output)
+				}
+			})
+		}
+	}
+
+	if err != nil {
+		errorList.RunCase(s.name(modulePath), func(caseErrors *pkgerrors.TestErrorsList) {
+			caseErrors.Errorf("go test %s in %s: %s", s.pattern, relPath(modulePath, s.dir), err.Error())
+		})
+	}
+}
+
+// findSuites returns the Go hook suites of the module: every go.mod under
+// hooks/ that requires module-sdk, or the module's own go.mod when it requires
+// module-sdk and the module has a hooks/ directory.
+func findSuites(modulePath string) []suite {
+	root := filepath.Join(modulePath, hooksDir)
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	var suites []suite
+
+	if requiresModuleSDK(filepath.Join(modulePath, "go.mod")) {
+		suites = append(suites, suite{dir: modulePath, pattern: "./" + hooksDir + "/..."})
+	}
+
+	goModFiles := fsutils.GetFiles(root, true, fsutils.FilterFileByNames("go.mod"))
+	sort.Strings(goModFiles)
+
+	for _, goMod := range goModFiles {
+		if requiresModuleSDK(goMod) {
+			suites = append(suites, suite{dir: filepath.Dir(goMod), pattern: "./..."})
+		}
+	}
+
+	return suites
+}
+
+func requiresModuleSDK(goModFile string) bool {
+	content, err := os.ReadFile(goModFile)
+	if err != nil {
+		return false
+	}
+
+	modFile, err := modfile.Parse(goModFile, content, nil)
+	if err != nil {
+		return false
+	}
+
+	for _, req := range modFile.Require {
+		if req.Mod.Path == moduleSDKPath {
+			return true
+		}
+	}
+
+	return false
+}
+
+func relPath(modulePath, path string) string {
+	rel, err := filepath.Rel(modulePath, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+func (f *failure) text() string {
+	switch {
+	case f.build:
+		return fmt.Sprintf("package %s failed to build", f.pkg)
+	case f.test == "":
+		return fmt.Sprintf("package %s failed", f.pkg)
+	default:
+		return fmt.Sprintf("test %s failed (%s)", f.test, f.elapsed)
+	}
+}
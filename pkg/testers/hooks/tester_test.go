@@ -0,0 +1,152 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestFindSuites(t *testing.T) {
+	modulePath := t.TempDir()
+	assert.Empty(t, findSuites(modulePath), "module without hooks")
+
+	writeFile(t, filepath.Join(modulePath, "hooks", "go", "go.mod"),
+		"module hooks\n\ngo 1.24\n\nrequire github.com/deckhouse/module-sdk v0.3.0\n")
+	writeFile(t, filepath.Join(modulePath, "hooks", "other", "go.mod"), "module other\n\ngo 1.24\n")
+
+	assert.Equal(t, []suite{{dir: filepath.Join(modulePath, "hooks", "go"), pattern: "./..."}}, findSuites(modulePath))
+
+	writeFile(t, filepath.Join(modulePath, "go.mod"),
+		"module root\n\ngo 1.24\n\nrequire github.com/deckhouse/module-sdk v0.3.0\n")
+
+	suites := findSuites(modulePath)
+	require.Len(t, suites, 2)
+	assert.Equal(t, suite{dir: modulePath, pattern: "./hooks/..."}, suites[0])
+}
+
+func TestParseTestEvents(t *testing.T) {
+	stream := strings.Join([]string{
+		`{"Action":"run","Package":"hooks/a","Test":"TestA"}`,
+		`{"Action":"output","Package":"hooks/a","Test":"TestA","Output":"=== RUN   TestA\n"}`,
+		`{"Action":"run","Package":"hooks/a","Test":"TestA/sub"}`,
+		`{"Action":"output","Package":"hooks/a","Test":"TestA/sub","Output":"    a_test.go:10: boom\n"}`,
+		`{"Action":"fail","Package":"hooks/a","Test":"TestA/sub","Elapsed":0.5}`,
+		`{"Action":"fail","Package":"hooks/a","Test":"TestA","Elapsed":0.5}`,
+		`{"Action":"pass","Package":"hooks/a","Test":"TestB","Elapsed":0}`,
+		`{"Action":"fail","Package":"hooks/a","Elapsed":0.6}`,
+		`{"ImportPath":"hooks/b","Action":"build-output","Output":"b.go:3:1: syntax error\n"}`,
+		`{"ImportPath":"hooks/b","Action":"build-fail"}`,
+		`{"Action":"fail","Package":"hooks/b","Elapsed":0,"FailedBuild":"hooks/b"}`,
+	}, "\n")
+
+	res, err := parseTestEvents(strings.NewReader(stream))
+	require.NoError(t, err)
+	require.Len(t, res.failures, 2)
+
+	assert.Equal(t, failure{pkg: "hooks/a", test: "TestA/sub", elapsed: 500 * time.Millisecond, output: "    a_test.go:10: boom\n"}, res.failures[0])
+	assert.Equal(t, "test TestA/sub failed (500ms)", res.failures[0].text())
+
+	assert.True(t, res.failures[1].build)
+	assert.Equal(t, "b.go:3:1: syntax error\n", res.failures[1].output)
+	assert.Equal(t, "package hooks/b failed to build", res.failures[1].text())
+
+	assert.Equal(t, []testCase{
+		{pkg: "hooks/a", test: "TestA", elapsed: 500 * time.Millisecond},
+		{pkg: "hooks/a", test: "TestB"},
+		{pkg: "hooks/b"},
+	}, res.cases)
+	assert.Equal(t, []failure{res.failures[0]}, res.caseFailures(res.cases[0]))
+	assert.Empty(t, res.caseFailures(res.cases[1]))
+	assert.Equal(t, "hooks/b", res.cases[2].name())
+}
+
+func TestRunGoTest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/hooks\n\ngo 1.24\n")
+	writeFile(t, filepath.Join(dir, "hook_test.go"), `package hooks
+
+import "testing"
+
+func TestPass(t *testing.T) {}
+
+func TestFail(t *testing.T) { t.Error("wrong patch") }
+`)
+
+	res, err := runGoTest(dir, "./...", time.Minute)
+	require.NoError(t, err)
+	require.Len(t, res.failures, 1)
+
+	assert.Equal(t, "example.com/hooks", res.failures[0].pkg)
+	assert.Equal(t, "TestFail", res.failures[0].test)
+	assert.Contains(t, res.failures[0].output, "wrong patch")
+}
+
+func TestRunRecordsCasePerTest(t *testing.T) {
+	modulePath := t.TempDir()
+	dir := filepath.Join(modulePath, "hooks", "go")
+	writeFile(t, filepath.Join(dir, "go.mod"),
+		"module example.com/hooks\n\ngo 1.24\n\nrequire github.com/deckhouse/module-sdk v0.3.0\n")
+	writeFile(t, filepath.Join(dir, "hook_test.go"), `package hooks
+
+import "testing"
+
+func TestPass(t *testing.T) {}
+
+func TestFail(t *testing.T) {
+	t.Run("sub", func(t *testing.T) { t.Error("wrong patch") })
+}
+`)
+
+	errorList := pkgerrors.NewTestErrorsList()
+	require.True(t, New(errorList, time.Minute).Run(modulePath))
+
+	cases := errorList.GetCases()
+	require.Len(t, cases, 2)
+	assert.Equal(t, "example.com/hooks.TestPass", cases[0].TestName)
+	assert.False(t, cases[0].Failed)
+	assert.Equal(t, "example.com/hooks.TestFail", cases[1].TestName)
+	assert.True(t, cases[1].Failed)
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "example.com/hooks.TestFail", errs[0].TestName)
+	assert.Contains(t, errs[0].Text, "test TestFail/sub failed")
+	assert.Contains(t, errs[0].Output, "wrong patch")
+}
+
+func TestRunNotApplicable(t *testing.T) {
+	errorList := pkgerrors.NewTestErrorsList()
+
+	assert.False(t, New(errorList, 0).Run(t.TempDir()))
+	assert.Empty(t, errorList.GetErrors())
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapiexamples
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	examplesKey = "x-examples"
+	exampleKey  = "x-example"
+
+	rootPath = "<root>"
+)
+
+// example is a single documented value of a schema node.
+type example struct {
+	file   string
+	path   string
+	key    string
+	value  any
+	schema *spec.Schema
+}
+
+// name is the testcase name, e.g. "config-values.yaml: settings.logLevel x-examples[1]".
+func (e *example) name() string {
+	return fmt.Sprintf("%s: %s %s", e.file, e.path, e.key)
+}
+
+// topLevel reports whether the example documents the whole settings object.
+func (e *example) topLevel() bool {
+	return e.path == rootPath
+}
+
+// collectExamples walks the schema and returns the x-examples and x-example
+// values of every node, together with the node the value must conform to.
+// Root properties listed in skip are not visited (see Tester.Run).
+func collectExamples(file string, s *spec.Schema, skip map[string]struct{}) []example {
+	c := &collector{file: file}
+	c.nodeExamples(s, rootPath)
+
+	for _, key := range sortedKeys(s.Properties) {
+		if _, ok := skip[key]; ok {
+			continue
+		}
+
+		prop := s.Properties[key]
+		c.walk(&prop, key)
+	}
+
+	c.children(s, rootPath)
+
+	return c.examples
+}
+
+type collector struct {
+	file     string
+	examples []example
+}
+
+func (c *collector) walk(s *spec.Schema, path string) {
+	c.nodeExamples(s, path)
+
+	for _, key := range sortedKeys(s.Properties) {
+		prop := s.Properties[key]
+		c.walk(&prop, path+"."+key)
+	}
+
+	c.children(s, path)
+}
+
+// children walks the nodes other than properties. Examples under "not" are
+// expected to violate it and are skipped.
+func (c *collector) children(s *spec.Schema, path string) {
+	for _, pattern := range sortedKeys(s.PatternProperties) {
+		prop := s.PatternProperties[pattern]
+		c.walk(&prop, fmt.Sprintf("%s[%s]", path, pattern))
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		c.walk(s.AdditionalProperties.Schema, path+".*")
+	}
+
+	if s.Items != nil {
+		if s.Items.Schema != nil {
+			c.walk(s.Items.Schema, path+"[*]")
+		}
+
+		for i := range s.Items.Schemas {
+			c.walk(&s.Items.Schemas[i], fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+
+	for _, combinator := range []struct {
+		keyword string
+		schemas []spec.Schema
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}} {
+		for i := range combinator.schemas {
+			c.walk(&combinator.schemas[i], fmt.Sprintf("%s(%s[%d])", path, combinator.keyword, i))
+		}
+	}
+}
+
+func (c *collector) nodeExamples(s *spec.Schema, path string) {
+	if list, ok := s.Extensions[examplesKey].([]any); ok {
+		for i, value := range list {
+			c.examples = append(c.examples, example{
+				file:   c.file,
+				path:   path,
+				key:    fmt.Sprintf("%s[%d]", examplesKey, i),
+				value:  value,
+				schema: s,
+			})
+		}
+	}
+
+	if value, ok := s.Extensions[exampleKey]; ok {
+		c.examples = append(c.examples, example{file: c.file, path: path, key: exampleKey, value: value, schema: s})
+	}
+}
+
+func sortedKeys(m map[string]spec.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
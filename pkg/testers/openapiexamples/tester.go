@@ -0,0 +1,216 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openapiexamples implements the "openapi-examples" tester. It
+// validates the x-examples and x-example values of a module's openapi schemas
+// against the schema node they document, and renders the module with every
+// top-level config-values example.
+package openapiexamples
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/mohae/deepcopy"
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	// ID is the tester identifier surfaced in results.
+	ID = "openapi-examples"
+
+	configValuesFile = "config-values.yaml"
+	valuesFile       = "values.yaml"
+)
+
+// renderFunc renders the module with the given config values, calling onDrop
+// for every template the render dropped.
+type renderFunc func(modulePath string, config map[string]any, onDrop func(templatePath, renderErr string)) (map[string]string, error)
+
+type Tester struct {
+	name, desc string
+
+	globalSchemas *values.GlobalSchemaResolver
+	render        renderFunc
+
+	ErrorList *pkgerrors.TestErrorsList
+}
+
+// New returns the tester. globalSchemas picks the global values schema the
+// modules are rendered with.
+func New(errorList *pkgerrors.TestErrorsList, globalSchemas *values.GlobalSchemaResolver) *Tester {
+	t := &Tester{
+		name:          ID,
+		desc:          "Validates openapi schema examples and renders the module with top-level config examples",
+		globalSchemas: globalSchemas,
+		ErrorList:     errorList.WithTestGroup(ID),
+	}
+	t.render = t.renderModule
+
+	return t
+}
+
+func (t *Tester) Name() string { return t.name }
+func (t *Tester) Desc() string { return t.desc }
+
+// Run executes the openapi-examples tester against the given module path.
+// Returns true if the tester was applicable (i.e. the module's openapi schemas
+// have examples).
+func (t *Tester) Run(modulePath string) bool {
+	errorList := t.ErrorList.WithModule(filepath.Base(modulePath))
+
+	schemas, err := values.LoadModuleSchemas(modulePath)
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	examples := moduleExamples(schemas)
+	if len(examples) == 0 {
+		return false
+	}
+
+	var installable []*example
+
+	for i := range examples {
+		ex := &examples[i]
+
+		errorList.RunCase(ex.name(), func(caseErrors *pkgerrors.TestErrorsList) {
+			if validateExample(ex, caseErrors) && ex.file == configValuesFile && ex.topLevel() {
+				installable = append(installable, ex)
+			}
+		})
+	}
+
+	for _, ex := range installable {
+		errorList.RunCase("render "+ex.key, func(caseErrors *pkgerrors.TestErrorsList) {
+			t.renderExample(modulePath, ex, caseErrors)
+		})
+	}
+
+	return true
+}
+
+// moduleExamples collects the config-values examples, then the values ones.
+// values.yaml extends config-values.yaml, so the inherited nodes are skipped
+// there to report every example once.
+func moduleExamples(schemas values.Schemas) []example {
+	var examples []example
+
+	configSchema := schemas[values.ConfigValuesSchema]
+	if configSchema != nil {
+		examples = append(examples, collectExamples(configValuesFile, configSchema, nil)...)
+	}
+
+	valuesSchema := schemas[values.ValuesSchema]
+	if valuesSchema == nil {
+		return examples
+	}
+
+	skip := map[string]struct{}{}
+	if configSchema != nil {
+		for key := range configSchema.Properties {
+			skip[key] = struct{}{}
+		}
+	}
+
+	for _, ex := range collectExamples(valuesFile, valuesSchema, skip) {
+		if ex.topLevel() && configSchema != nil && reflect.DeepEqual(configSchema.Extensions[extensionName(ex.key)], valuesSchema.Extensions[extensionName(ex.key)]) {
+			// Root examples merged from config-values.yaml.
+			continue
+		}
+
+		examples = append(examples, ex)
+	}
+
+	return examples
+}
+
+// extensionName returns the extension an example key such as "x-examples[0]"
+// comes from.
+func extensionName(key string) string {
+	name, _, _ := strings.Cut(key, "[")
+	return name
+}
+
+// validateExample reports the example's schema violations and returns whether
+// it is valid.
+func validateExample(ex *example, errorList *pkgerrors.TestErrorsList) bool {
+	violations := values.ValidateValues(ex.schema, ex.value)
+	if len(violations) == 0 {
+		return true
+	}
+
+	errorList.AddTestOutput(
+		fmt.Sprintf("%s violates its schema:\n%s", ex.name(), strings.Join(violations, "\n")),
+		exampleYAML(ex.value),
+	)
+
+	return false
+}
+
+// renderExample renders the module with the example as config values,
+// completed with the schema defaults the way Deckhouse does.
+func (t *Tester) renderExample(modulePath string, ex *example, errorList *pkgerrors.TestErrorsList) {
+	config, ok := deepcopy.Copy(ex.value).(map[string]any)
+	if !ok {
+		errorList.Errorf("%s: top-level example must be an object", ex.name())
+		return
+	}
+
+	values.ApplyDefaults(ex.schema, config)
+
+	// A template that aborts the render (e.g. `fail` on a combination of
+	// settings) is dropped by the render; the example fails on it all the same.
+	onDrop := func(templatePath, renderErr string) {
+		errorList.AddTestOutput(
+			fmt.Sprintf("%s: template %s does not render: %s", ex.name(), templatePath, renderErr),
+			exampleYAML(config),
+		)
+	}
+
+	if _, err := t.render(modulePath, config, onDrop); err != nil {
+		errorList.AddTestOutput(fmt.Sprintf("%s: module does not render: %s", ex.name(), err), exampleYAML(config))
+	}
+}
+
+func (t *Tester) renderModule(
+	modulePath string,
+	config map[string]any,
+	onDrop func(templatePath, renderErr string),
+) (map[string]string, error) {
+	globalSchema, err := t.globalSchemas.ForModule(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load global values schema: %w", err)
+	}
+
+	return modules.RenderModuleWithConfig(modulePath, globalSchema.Schema, config, onDrop)
+}
+
+func exampleYAML(value any) string {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
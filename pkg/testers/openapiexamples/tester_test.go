@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapiexamples
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/modules/values"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const configValues = `type: object
+x-examples:
+  - logLevel: Debug
+  - logLevel: Trace
+properties:
+  logLevel:
+    type: string
+    enum: [Info, Debug]
+    default: Info
+    x-examples: [Info, Verbose]
+  replicas:
+    type: integer
+    default: 2
+  nodeSelector:
+    type: object
+    additionalProperties:
+      type: string
+      x-example: "true"
+  tolerations:
+    type: array
+    items:
+      type: object
+      required: [key]
+      properties:
+        key:
+          type: string
+          pattern: '^[a-z.]+$'
+      x-examples:
+        - key: dedicated
+        - operator: Exists
+`
+
+const valuesYAML = `x-extend:
+  schema: config-values.yaml
+type: object
+properties:
+  internal:
+    type: object
+    properties:
+      port:
+        type: integer
+        not:
+          enum: [0]
+          x-example: 0
+        x-example: "8080"
+`
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	modulePath := filepath.Join(t.TempDir(), "demo")
+	require.NoError(t, os.MkdirAll(filepath.Join(modulePath, "openapi"), 0o755))
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(modulePath, "openapi", name), []byte(content), 0o600))
+	}
+
+	return modulePath
+}
+
+func TestOpenAPIExamplesTester(t *testing.T) {
+	modulePath := writeModule(t, map[string]string{
+		"config-values.yaml": configValues,
+		"values.yaml":        valuesYAML,
+	})
+
+	var rendered []map[string]any
+
+	errorList := pkgerrors.NewTestErrorsList()
+	tester := New(errorList, values.NewGlobalSchemaResolver("", ""))
+	tester.render = func(_ string, config map[string]any, _ func(string, string)) (map[string]string, error) {
+		rendered = append(rendered, config)
+		return nil, nil
+	}
+
+	require.True(t, tester.Run(modulePath))
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	require.Len(t, texts, 4, "%v", texts)
+	assert.Contains(t, texts[0], "config-values.yaml: <root> x-examples[1] violates its schema")
+	assert.Contains(t, texts[0], "logLevel in body should be one of [Info Debug]")
+	assert.Contains(t, texts[1], "config-values.yaml: logLevel x-examples[1] violates its schema")
+	assert.Contains(t, texts[2], "config-values.yaml: tolerations[*] x-examples[1] violates its schema")
+	assert.Contains(t, texts[2], "key in body is required")
+	assert.Contains(t, texts[3], "values.yaml: internal.port x-example violates its schema")
+
+	require.Len(t, rendered, 1, "only the valid top-level example is rendered")
+	assert.Equal(t, "Debug", rendered[0]["logLevel"])
+	assert.EqualValues(t, 2, rendered[0]["replicas"], "defaults are applied before rendering")
+
+	var names []string
+	for _, c := range errorList.GetCases() {
+		names = append(names, c.TestName)
+	}
+
+	assert.Equal(t, []string{
+		"config-values.yaml: <root> x-examples[0]",
+		"config-values.yaml: <root> x-examples[1]",
+		"config-values.yaml: logLevel x-examples[0]",
+		"config-values.yaml: logLevel x-examples[1]",
+		"config-values.yaml: nodeSelector.* x-example",
+		"config-values.yaml: tolerations[*] x-examples[0]",
+		"config-values.yaml: tolerations[*] x-examples[1]",
+		"values.yaml: internal.port x-example",
+		"render x-examples[0]",
+	}, names)
+}
+
+func TestOpenAPIExamplesTesterReportsRenderErrors(t *testing.T) {
+	modulePath := writeModule(t, map[string]string{"config-values.yaml": configValues})
+
+	errorList := pkgerrors.NewTestErrorsList()
+	tester := New(errorList, values.NewGlobalSchemaResolver("", ""))
+	tester.render = func(string, map[string]any, func(string, string)) (map[string]string, error) {
+		return nil, errors.New(`template: demo/templates/app.yaml:3: nil pointer`)
+	}
+
+	require.True(t, tester.Run(modulePath))
+
+	var found bool
+	for _, e := range errorList.GetErrors() {
+		if e.Text == "config-values.yaml: <root> x-examples[0]: module does not render: template: demo/templates/app.yaml:3: nil pointer" {
+			found = true
+			assert.Contains(t, e.Output, "logLevel: Debug")
+		}
+	}
+
+	assert.True(t, found, "render error is reported")
+}
+
+func TestOpenAPIExamplesTesterReportsDroppedTemplates(t *testing.T) {
+	modulePath := writeModule(t, map[string]string{"config-values.yaml": `type: object
+x-examples:
+  - logLevel: Info
+  - logLevel: Debug
+properties:
+  logLevel:
+    type: string
+    enum: [Info, Debug]
+    default: Info
+`,
+		"values.yaml": "x-extend:\n  schema: config-values.yaml\ntype: object\n",
+	})
+
+	templates := map[string]string{
+		"configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: demo
+data:
+  logLevel: {{ .Values.demo.logLevel }}
+`,
+		"debug.yaml": `{{- if eq .Values.demo.logLevel "Debug" }}
+{{- fail "debug logging needs a sidecar" }}
+{{- end }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: debug
+`,
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(modulePath, "templates"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(modulePath, "module.yaml"), []byte("name: demo\nnamespace: d8-demo\n"), 0o600))
+
+	for name, content := range templates {
+		require.NoError(t, os.WriteFile(filepath.Join(modulePath, "templates", name), []byte(content), 0o600))
+	}
+
+	errorList := pkgerrors.NewTestErrorsList()
+	require.True(t, New(errorList, values.NewGlobalSchemaResolver("", "")).Run(modulePath))
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1, "%v", errs)
+	assert.Equal(t, "render x-examples[1]", errs[0].TestName)
+	assert.Contains(t, errs[0].Text, "config-values.yaml: <root> x-examples[1]: template templates/debug.yaml does not render: ")
+	assert.Contains(t, errs[0].Text, "debug logging needs a sidecar")
+	assert.Contains(t, errs[0].Output, "logLevel: Debug")
+
+	for _, c := range errorList.GetCases() {
+		assert.Equal(t, c.TestName == "render x-examples[1]", c.Failed, c.TestName)
+	}
+}
+
+func TestOpenAPIExamplesTesterNotApplicable(t *testing.T) {
+	modulePath := writeModule(t, map[string]string{"config-values.yaml": "type: object\nproperties:\n  a:\n    type: string\n"})
+
+	assert.False(t, New(pkgerrors.NewTestErrorsList(), values.NewGlobalSchemaResolver("", "")).Run(modulePath))
+	assert.False(t, New(pkgerrors.NewTestErrorsList(), values.NewGlobalSchemaResolver("", "")).Run(t.TempDir()))
+}
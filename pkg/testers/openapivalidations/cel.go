@@ -0,0 +1,218 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapivalidations
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+
+	"github.com/deckhouse/dmt/internal/openapi"
+)
+
+// rule is an x-deckhouse-validations entry.
+type rule struct {
+	Expression string `json:"expression"`
+	Message    string `json:"message"`
+}
+
+// violation is a rule that rejected the values.
+type violation struct {
+	// path locates the validated value, e.g. "settings.replicas".
+	path    string
+	message string
+}
+
+func (v violation) String() string {
+	return fmt.Sprintf("%s: %s", v.path, v.message)
+}
+
+// validator evaluates the x-deckhouse-validations rules of a schema the way
+// deckhouse-controller does: each rule runs against the value at the schema
+// node that declares it, bound to `self`. Rules of absent values do not run.
+//
+// `oldSelf` is bound to the value at the same path in the old values. As for
+// Kubernetes transition rules, a rule referencing `oldSelf` only runs when an
+// old value exists, and list items are not correlated with old ones.
+type validator struct {
+	env      *cel.Env
+	programs map[string]compiled
+}
+
+type compiled struct {
+	program    cel.Program
+	transition bool
+	err        error
+}
+
+func newValidator() (*validator, error) {
+	env, err := openapi.NewValidationsCELEnv()
+	if err != nil {
+		return nil, fmt.Errorf("create CEL environment: %w", err)
+	}
+
+	return &validator{env: env, programs: map[string]compiled{}}, nil
+}
+
+func (v *validator) compile(expression string) compiled {
+	if c, ok := v.programs[expression]; ok {
+		return c
+	}
+
+	var c compiled
+
+	ast, issues := v.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		c.err = fmt.Errorf("compile: %w", issues.Err())
+	} else {
+		for _, ref := range ast.NativeRep().ReferenceMap() {
+			if ref.Name == openapi.OldSelfVar {
+				c.transition = true
+			}
+		}
+
+		c.program, c.err = v.env.Program(ast)
+	}
+
+	v.programs[expression] = c
+
+	return c
+}
+
+// validate returns the violations of values against the schema; old is nil
+// unless the values replace previous ones.
+func (v *validator) validate(schema map[string]any, values, old any) []violation {
+	var violations []violation
+
+	v.walk(schema, "", values, old, old != nil, &violations)
+
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].path < violations[j].path })
+
+	return violations
+}
+
+func (v *validator) walk(schema map[string]any, path string, value, old any, hasOld bool, violations *[]violation) {
+	if value == nil || schema == nil {
+		return
+	}
+
+	for _, r := range parseRules(schema[openapi.ValidationsKey]) {
+		if msg, ok := v.eval(r, value, old, hasOld); !ok {
+			*violations = append(*violations, violation{path: displayPath(path), message: msg})
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		oldMap, _ := old.(map[string]any)
+		props, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			child, ok := props[key].(map[string]any)
+			if !ok {
+				child = additional
+			}
+
+			oldChild, childHasOld := oldMap[key]
+			v.walk(child, joinPath(path, key), val[key], oldChild, hasOld && childHasOld, violations)
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i := range val {
+			v.walk(items, fmt.Sprintf("%s[%d]", path, i), val[i], nil, false, violations)
+		}
+	}
+}
+
+// eval runs a rule and returns false and the message to report when the rule
+// rejects the value or cannot be evaluated.
+func (v *validator) eval(r rule, value, old any, hasOld bool) (string, bool) {
+	c := v.compile(r.Expression)
+	if c.err != nil {
+		return fmt.Sprintf("rule %q: %s", r.Expression, c.err), false
+	}
+
+	if c.transition && !hasOld {
+		return "", true
+	}
+
+	vars := map[string]any{openapi.SelfVar: value}
+	if hasOld {
+		vars[openapi.OldSelfVar] = old
+	}
+
+	out, _, err := c.program.Eval(vars)
+	if err != nil {
+		return fmt.Sprintf("rule %q: evaluate: %s", r.Expression, err), false
+	}
+
+	if out.Type() != types.BoolType {
+		return fmt.Sprintf("rule %q: evaluates to %s, not bool", r.Expression, out.Type().TypeName()), false
+	}
+
+	if out != types.True {
+		return r.Message, false
+	}
+
+	return "", true
+}
+
+// parseRules reads an x-deckhouse-validations block; malformed entries are
+// reported by the openapi linter and ignored here.
+func parseRules(raw any) []rule {
+	list, _ := raw.([]any)
+
+	rules := make([]rule, 0, len(list))
+
+	for _, item := range list {
+		entry, _ := item.(map[string]any)
+		expression, _ := entry["expression"].(string)
+		message, _ := entry["message"].(string)
+
+		if expression != "" {
+			rules = append(rules, rule{Expression: expression, Message: message})
+		}
+	}
+
+	return rules
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openapivalidations implements the "openapi-validations" tester. It
+// evaluates the x-deckhouse-validations CEL rules of a module's config-values
+// schema against the cases in openapi/validations-tests.yaml.
+package openapivalidations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	// ID is the tester identifier surfaced in results.
+	ID = "openapi-validations"
+
+	configValuesFile = "openapi/config-values.yaml"
+	testsFile        = "openapi/validations-tests.yaml"
+
+	expectPass = "pass"
+	expectFail = "fail"
+)
+
+type testcase struct {
+	Name      string `json:"name"`
+	Values    any    `json:"values"`
+	OldValues any    `json:"oldValues"`
+	Expect    string `json:"expect"`
+	Message   string `json:"message"`
+}
+
+type testsFileContent struct {
+	Testcases []testcase `json:"testcases"`
+}
+
+type Tester struct {
+	name, desc string
+	ErrorList  *pkgerrors.TestErrorsList
+}
+
+func New(errorList *pkgerrors.TestErrorsList) *Tester {
+	return &Tester{
+		name:      ID,
+		desc:      "Evaluates x-deckhouse-validations rules against module config testcases",
+		ErrorList: errorList.WithTestGroup(ID),
+	}
+}
+
+func (t *Tester) Name() string { return t.name }
+func (t *Tester) Desc() string { return t.desc }
+
+// Run executes the openapi-validations tester against the given module path.
+// Returns true if the tester was applicable (i.e. the module ships
+// openapi/validations-tests.yaml).
+func (t *Tester) Run(modulePath string) bool {
+	errorList := t.ErrorList.WithModule(filepath.Base(modulePath))
+
+	testsPath := filepath.Join(modulePath, testsFile)
+	if _, err := os.Stat(testsPath); os.IsNotExist(err) {
+		return false
+	}
+
+	testcases, err := parseTestcases(testsPath)
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	schema, err := readSchema(filepath.Join(modulePath, configValuesFile))
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	v, err := newValidator()
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	for i := range testcases {
+		tc := &testcases[i]
+		if tc.Name == "" {
+			tc.Name = fmt.Sprintf("#%d", i)
+		}
+
+		errorList.RunCase(tc.Name, func(caseErrors *pkgerrors.TestErrorsList) {
+			runTestcase(v, schema, tc, caseErrors)
+		})
+	}
+
+	return true
+}
+
+func runTestcase(v *validator, schema map[string]any, tc *testcase, errorList *pkgerrors.TestErrorsList) {
+	if tc.Expect != expectPass && tc.Expect != expectFail {
+		errorList.Errorf("testcase %q: expect must be %q or %q, got %q", tc.Name, expectPass, expectFail, tc.Expect)
+		return
+	}
+
+	if tc.Values == nil {
+		errorList.Errorf("testcase %q: values are not set", tc.Name)
+		return
+	}
+
+	violations := v.validate(schema, tc.Values, tc.OldValues)
+
+	got := formatViolations(violations)
+
+	switch {
+	case tc.Expect == expectPass && len(violations) > 0:
+		errorList.AddTestResult(fmt.Sprintf("testcase %q: values are rejected, expected them to pass", tc.Name), got, "no violations")
+	case tc.Expect == expectFail && len(violations) == 0:
+		expected := "a violation"
+		if tc.Message != "" {
+			expected = tc.Message
+		}
+
+		errorList.AddTestResult(fmt.Sprintf("testcase %q: values pass, expected them to be rejected", tc.Name), "no violations", expected)
+	case tc.Expect == expectFail && tc.Message != "" && !hasMessage(violations, tc.Message):
+		errorList.AddTestResult(fmt.Sprintf("testcase %q: values are rejected with other messages", tc.Name), got, tc.Message)
+	}
+}
+
+func hasMessage(violations []violation, message string) bool {
+	for _, v := range violations {
+		if v.message == message {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatViolations(violations []violation) string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, v.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func parseTestcases(path string) ([]testcase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read testcases file: %w", err)
+	}
+
+	// Decode like the API server does, so whole numbers are int64 for CEL.
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode testcases file: %w", err)
+	}
+
+	var content testsFileContent
+	if err := utiljson.Unmarshal(jsonData, &content); err != nil {
+		return nil, fmt.Errorf("cannot decode testcases file: %w", err)
+	}
+
+	return content.Testcases, nil
+}
+
+func readSchema(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config-values.yaml: %w", err)
+	}
+
+	schema := map[string]any{}
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("cannot decode config-values.yaml: %w", err)
+	}
+
+	return schema, nil
+}
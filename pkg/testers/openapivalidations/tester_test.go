@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapivalidations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/openapi"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const configValues = `type: object
+properties:
+  replicas:
+    type: integer
+    x-deckhouse-validations:
+      - expression: "self >= oldSelf"
+        message: "replicas cannot be decreased"
+  storage:
+    type: object
+    x-deckhouse-validations:
+      - expression: "!has(self.size) || self.size.endsWith('Gi')"
+        message: "size must be in Gi"
+  nodes:
+    type: array
+    items:
+      type: string
+      x-deckhouse-validations:
+        - expression: "self.size() > 0"
+          message: "node name must not be empty"
+  labels:
+    type: object
+    additionalProperties:
+      type: string
+      x-deckhouse-validations:
+        - expression: "self.size() <= 8"
+          message: "label value is too long"
+x-deckhouse-validations:
+  - expression: "!has(self.replicas) || self.replicas <= 5"
+    message: "at most 5 replicas are supported"
+`
+
+func writeModule(t *testing.T, testcases string) string {
+	t.Helper()
+
+	modulePath := t.TempDir()
+	openapiDir := filepath.Join(modulePath, "openapi")
+	require.NoError(t, os.MkdirAll(openapiDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(openapiDir, "config-values.yaml"), []byte(configValues), 0o600))
+
+	if testcases != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(openapiDir, "validations-tests.yaml"), []byte(testcases), 0o600))
+	}
+
+	return modulePath
+}
+
+func TestOpenAPIValidationsTester_NotApplicable(t *testing.T) {
+	errorList := pkgerrors.NewTestErrorsList()
+
+	assert.False(t, New(errorList).Run(writeModule(t, "")))
+	assert.Empty(t, errorList.GetErrors())
+}
+
+func TestOpenAPIValidationsTester_Pass(t *testing.T) {
+	testcases := `testcases:
+  - name: valid settings
+    values:
+      replicas: 3
+      storage:
+        size: 10Gi
+      nodes: [a, b]
+      labels:
+        tier: web
+    expect: pass
+  - name: too many replicas
+    values:
+      replicas: 6
+    expect: fail
+    message: at most 5 replicas are supported
+  - name: nested rules
+    values:
+      storage:
+        size: 10Mi
+      nodes: [""]
+      labels:
+        tier: verylongvalue
+    expect: fail
+    message: label value is too long
+  - name: transition rule is skipped without oldValues
+    values:
+      replicas: 1
+    expect: pass
+  - name: transition rule with oldValues
+    values:
+      replicas: 1
+    oldValues:
+      replicas: 3
+    expect: fail
+    message: replicas cannot be decreased
+`
+
+	errorList := pkgerrors.NewTestErrorsList()
+
+	assert.True(t, New(errorList).Run(writeModule(t, testcases)))
+	assert.Empty(t, errorList.GetErrors())
+}
+
+func TestOpenAPIValidationsTester_Failures(t *testing.T) {
+	testcases := `testcases:
+  - name: unexpected violation
+    values:
+      replicas: 6
+    expect: pass
+  - name: missing violation
+    values:
+      replicas: 2
+    expect: fail
+  - name: other message
+    values:
+      storage:
+        size: 1Ti
+    expect: fail
+    message: replicas cannot be decreased
+  - name: bad expect
+    values:
+      replicas: 2
+    expect: ok
+`
+
+	errorList := pkgerrors.NewTestErrorsList()
+	New(errorList).Run(writeModule(t, testcases))
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, "unexpected violation", errs[0].TestName)
+	assert.Equal(t, "<root>: at most 5 replicas are supported", errs[0].Got)
+	assert.Equal(t, "missing violation", errs[1].TestName)
+	assert.Equal(t, "no violations", errs[1].Got)
+	assert.Equal(t, "storage: size must be in Gi", errs[2].Got)
+	assert.Equal(t, "replicas cannot be decreased", errs[2].Expected)
+	assert.Contains(t, errs[3].Text, `expect must be "pass" or "fail"`)
+}
+
+func TestValidator_InvalidExpression(t *testing.T) {
+	v, err := newValidator()
+	require.NoError(t, err)
+
+	schema := map[string]any{
+		openapi.ValidationsKey: []any{
+			map[string]any{"expression": "self.", "message": "broken"},
+			map[string]any{"expression": "self", "message": "not bool"},
+		},
+	}
+
+	violations := v.validate(schema, "value", nil)
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0].message, "compile")
+	assert.Contains(t, violations[1].message, "not bool")
+}
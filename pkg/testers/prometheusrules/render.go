@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrules
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// templatePrefix names the templates injected into the chart to render the
+	// rule files, and the ConfigMaps they emit.
+	templatePrefix = "dmt-prometheus-rules-test-"
+	// defaultScrapeInterval is used when the values do not set
+	// global.discovery.prometheusScrapeInterval.
+	defaultScrapeInterval = 30
+)
+
+// ruleTemplate renders a single rule file the way helm_lib does: `.tpl` files
+// are executed as templates, and the scrape interval placeholders are replaced
+// with the configured interval. The result is wrapped into a ConfigMap so it
+// survives the render as an ordinary manifest.
+const ruleTemplate = `{{- $interval := %[1]d }}
+{{- with .Values.global }}{{ with .discovery }}{{ with .prometheusScrapeInterval }}{{ $interval = int . }}{{ end }}{{ end }}{{ end }}
+{{- $rules := .Files.Get %[2]q }}
+%[3]s{{- $rules = $rules | replace "__SCRAPE_INTERVAL_X_4__" (printf "%%ds" (mul $interval 4)) | replace "__SCRAPE_INTERVAL_X_3__" (printf "%%ds" (mul $interval 3)) | replace "__SCRAPE_INTERVAL_X_2__" (printf "%%ds" (mul $interval 2)) | replace "__SCRAPE_INTERVAL__" (printf "%%ds" $interval) }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %[4]s
+data:
+  rules: {{ $rules | toJson }}
+`
+
+// renderFunc renders the module with extra templates, reporting templates that
+// failed to render through onDrop.
+type renderFunc func(templates map[string][]byte, onDrop func(templatePath, renderErr string)) (map[string]string, error)
+
+// renderedRules holds the rendered rule files written as promtool rule files.
+type renderedRules struct {
+	dir string
+	// paths maps a chart-relative rule file to its rendered copy.
+	paths map[string]string
+	// errs holds the render error of each rule file that failed.
+	errs map[string]error
+}
+
+func (r *renderedRules) cleanup() {
+	if r.dir != "" {
+		_ = os.RemoveAll(r.dir)
+	}
+}
+
+// templateName is the chart template rendering the i-th rule file.
+func templateName(i int) string {
+	return fmt.Sprintf("%s%d.yaml", templatePrefix, i)
+}
+
+// buildTemplate returns the template rendering the rule file at the
+// chart-relative path ruleFile into the ConfigMap name.
+func buildTemplate(ruleFile, name string) []byte {
+	rules := ""
+	if strings.HasSuffix(ruleFile, ".tpl") {
+		rules = "{{- $rules = tpl $rules . }}\n"
+	}
+
+	return fmt.Appendf(nil, ruleTemplate, defaultScrapeInterval, ruleFile, rules, name)
+}
+
+// renderRules renders the chart-relative ruleFiles in a single module render
+// and writes each of them as a promtool rule file into a temporary directory.
+func renderRules(ruleFiles []string, render renderFunc) (*renderedRules, error) {
+	templates := make(map[string][]byte, len(ruleFiles))
+	byTemplate := make(map[string]string, len(ruleFiles))
+
+	for i, rf := range ruleFiles {
+		name := templateName(i)
+		templates[name] = buildTemplate(rf, strings.TrimSuffix(name, ".yaml"))
+		byTemplate[path.Join("templates", name)] = rf
+	}
+
+	res := &renderedRules{
+		paths: make(map[string]string, len(ruleFiles)),
+		errs:  make(map[string]error),
+	}
+
+	files, err := render(templates, func(templatePath, renderErr string) {
+		if rf, ok := byTemplate[templatePath]; ok {
+			res.errs[rf] = errors.New(renderErr)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render module: %w", err)
+	}
+
+	res.dir, err = os.MkdirTemp("", "dmt-prometheus-rules-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+
+	for tpl, rf := range byTemplate {
+		if res.errs[rf] != nil {
+			continue
+		}
+
+		out := filepath.Join(res.dir, strings.TrimPrefix(tpl, "templates/"))
+		if err := writeRuleFile(files[tpl], out); err != nil {
+			res.errs[rf] = err
+			continue
+		}
+
+		res.paths[rf] = out
+	}
+
+	return res, nil
+}
+
+// writeRuleFile extracts the rules from the rendered ConfigMap manifest and
+// writes them to out as a promtool rule file. Deckhouse rule files are a plain
+// list of groups, so they are wrapped into `groups:`; files already in the
+// promtool format are kept as is.
+func writeRuleFile(manifest, out string) error {
+	var cm struct {
+		Data map[string]string `json:"data"`
+	}
+
+	if err := yaml.Unmarshal([]byte(manifest), &cm); err != nil {
+		return fmt.Errorf("parse rendered rules: %w", err)
+	}
+
+	rules := cm.Data["rules"]
+	if strings.TrimSpace(rules) == "" {
+		return errors.New("rendered rule file is empty, check that .helmignore does not exclude it")
+	}
+
+	var content any
+	if err := yaml.Unmarshal([]byte(rules), &content); err != nil {
+		return fmt.Errorf("parse rules: %w", err)
+	}
+
+	if _, ok := content.([]any); ok {
+		content = map[string]any{"groups": content}
+	}
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("marshal rules: %w", err)
+	}
+
+	if err := os.WriteFile(out, data, 0o600); err != nil {
+		return fmt.Errorf("write rules: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prometheusrules implements the "prometheus-rules" tester. It runs
+// promtool-style rule unit tests (`*-tests.yaml` next to the rules under
+// monitoring/prometheus-rules) against the module's rendered rules, evaluating
+// them with the Prometheus rules engine.
+package prometheusrules
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/modules"
+	"github.com/deckhouse/dmt/internal/modules/values"
+	"github.com/deckhouse/dmt/internal/promtool"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	// ID is the tester identifier surfaced in results.
+	ID = "prometheus-rules"
+	// RulesDir is the module directory holding the Prometheus rules and their
+	// unit tests.
+	RulesDir = "monitoring/prometheus-rules"
+	// testsFileSuffix marks a promtool rule unit test file.
+	testsFileSuffix = "-tests.yaml"
+)
+
+type Tester struct {
+	name, desc string
+
+	globalSchemas *values.GlobalSchemaResolver
+
+	ErrorList *pkgerrors.TestErrorsList
+}
+
+// New returns the tester. globalSchemas picks the global values schema the
+// modules are rendered with.
+func New(errorList *pkgerrors.TestErrorsList, globalSchemas *values.GlobalSchemaResolver) *Tester {
+	return &Tester{
+		name:          ID,
+		desc:          "Evaluates module Prometheus rules against promtool-style unit tests",
+		globalSchemas: globalSchemas,
+		ErrorList:     errorList.WithTestGroup(ID),
+	}
+}
+
+func (t *Tester) Name() string { return t.name }
+func (t *Tester) Desc() string { return t.desc }
+
+// Run executes the prometheus-rules tester against the given module path.
+// Returns true if the tester was applicable (i.e. the module ships rule tests).
+func (t *Tester) Run(modulePath string) bool {
+	errorList := t.ErrorList.WithModule(filepath.Base(modulePath))
+
+	testFiles, err := discoverTestFiles(filepath.Join(modulePath, RulesDir))
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+
+	if len(testFiles) == 0 {
+		return false
+	}
+
+	tests := make([]ruleTest, 0, len(testFiles))
+	ruleFiles := map[string]struct{}{}
+
+	for _, path := range testFiles {
+		rt, err := loadRuleTest(modulePath, path)
+		if err != nil {
+			errorList.WithTestName(rt.name).Errorf("%s", err.Error())
+			continue
+		}
+
+		for _, rf := range rt.ruleFiles {
+			ruleFiles[rf] = struct{}{}
+		}
+
+		tests = append(tests, rt)
+	}
+
+	if len(tests) == 0 {
+		return true
+	}
+
+	rendered, err := t.renderRuleFiles(modulePath, sortedKeys(ruleFiles))
+	if err != nil {
+		errorList.Errorf("%s", err.Error())
+		return true
+	}
+	defer rendered.cleanup()
+
+	for i := range tests {
+		errorList.RunCase(tests[i].name, func(caseErrors *pkgerrors.TestErrorsList) {
+			runRuleTest(&tests[i], rendered, caseErrors)
+		})
+	}
+
+	return true
+}
+
+// ruleTest is a parsed rule unit test file.
+type ruleTest struct {
+	// name is the test file path relative to the module.
+	name string
+	file *promtool.UnitTestFile
+	// ruleFiles are the chart-relative rule files the tests load.
+	ruleFiles []string
+}
+
+// discoverTestFiles returns the rule unit test files under rulesDir, sorted.
+func discoverTestFiles(rulesDir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(rulesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && strings.HasSuffix(d.Name(), testsFileSuffix) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read prometheus rules dir: %w", err)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// loadRuleTest parses the test file at path and resolves its rule_files, which
+// are relative to the test file and may be globs, to chart-relative paths.
+func loadRuleTest(modulePath, path string) (ruleTest, error) {
+	rt := ruleTest{name: relPath(modulePath, path)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rt, fmt.Errorf("read test file: %w", err)
+	}
+
+	rt.file, err = promtool.ParseUnitTestFile(data)
+	if err != nil {
+		return rt, err
+	}
+
+	for _, pattern := range rt.file.RuleFiles {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return rt, fmt.Errorf("rule_files: %w", err)
+		}
+
+		if len(matches) == 0 {
+			return rt, fmt.Errorf("rule_files: no file matches %q", relPath(modulePath, pattern))
+		}
+
+		for _, match := range matches {
+			rel, err := filepath.Rel(modulePath, match)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				return rt, fmt.Errorf("rule_files: %q is outside the module", match)
+			}
+
+			rt.ruleFiles = append(rt.ruleFiles, filepath.ToSlash(rel))
+		}
+	}
+
+	if len(rt.ruleFiles) == 0 {
+		return rt, fmt.Errorf("rule_files is empty")
+	}
+
+	return rt, nil
+}
+
+// runRuleTest runs the tests of rt against the rendered rule files and reports
+// every failed check.
+func runRuleTest(rt *ruleTest, rendered *renderedRules, errorList *pkgerrors.TestErrorsList) {
+	paths := make([]string, 0, len(rt.ruleFiles))
+
+	for _, rf := range rt.ruleFiles {
+		if err := rendered.errs[rf]; err != nil {
+			errorList.Errorf("render rule file %q: %s", rf, err.Error())
+			return
+		}
+
+		paths = append(paths, rendered.paths[rf])
+	}
+
+	for _, f := range rt.file.Run(paths...) {
+		// Point messages about the rendered copies at the module's rule files.
+		text := f.Text
+		for rf, path := range rendered.paths {
+			text = strings.ReplaceAll(text, path, rf)
+		}
+
+		errorList.AddTestResult(fmt.Sprintf("%s: %s", f.Test, text), f.Got, f.Expected)
+	}
+}
+
+func relPath(modulePath, path string) string {
+	rel, err := filepath.Rel(modulePath, path)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// renderRuleFiles renders the rule files and writes the result to a temporary
+// directory (see render.go).
+func (t *Tester) renderRuleFiles(modulePath string, ruleFiles []string) (*renderedRules, error) {
+	globalSchema, err := t.globalSchemas.ForModule(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load global values schema: %w", err)
+	}
+
+	return renderRules(ruleFiles, func(templates map[string][]byte, onDrop func(string, string)) (map[string]string, error) {
+		return modules.RenderModuleWithTemplates(modulePath, globalSchema.Schema, templates, onDrop)
+	})
+}
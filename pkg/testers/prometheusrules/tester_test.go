@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/modules/values"
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const testRules = `
+- name: test
+  rules:
+  - alert: TargetDown
+    expr: up == 0
+    for: 5m
+    labels:
+      severity: warning
+`
+
+const testCases = `
+rule_files: [rules.yaml]
+tests:
+- name: target down
+  input_series:
+  - series: 'up{job="app"}'
+    values: '0x10'
+  alert_rule_test:
+  - eval_time: 10m
+    alertname: TargetDown
+    exp_alerts:
+    - exp_labels:
+        severity: %s
+        job: app
+`
+
+func writeModule(t *testing.T, severity string) string {
+	t.Helper()
+
+	modulePath := t.TempDir()
+	dir := filepath.Join(modulePath, RulesDir)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(testRules), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules-tests.yaml"), []byte(strings.Replace(testCases, "%s", severity, 1)), 0o600))
+
+	return modulePath
+}
+
+// fakeRender renders the rule files as-is, the way the injected templates do
+// for a plain YAML file.
+func fakeRender(t *testing.T, modulePath string, ruleFiles []string) renderFunc {
+	t.Helper()
+
+	return func(templates map[string][]byte, _ func(string, string)) (map[string]string, error) {
+		files := map[string]string{}
+
+		for i, rf := range ruleFiles {
+			name := templateName(i)
+			require.Contains(t, string(templates[name]), `.Files.Get "`+rf+`"`)
+
+			data, err := os.ReadFile(filepath.Join(modulePath, rf))
+			require.NoError(t, err)
+
+			files["templates/"+name] = "data:\n  rules: |\n    " + strings.ReplaceAll(strings.TrimSpace(string(data)), "\n", "\n    ") + "\n"
+		}
+
+		return files, nil
+	}
+}
+
+func runModule(t *testing.T, modulePath string) *pkgerrors.TestErrorsList {
+	t.Helper()
+
+	testFiles, err := discoverTestFiles(filepath.Join(modulePath, RulesDir))
+	require.NoError(t, err)
+	require.Len(t, testFiles, 1)
+
+	rt, err := loadRuleTest(modulePath, testFiles[0])
+	require.NoError(t, err)
+	assert.Equal(t, "monitoring/prometheus-rules/rules-tests.yaml", rt.name)
+	assert.Equal(t, []string{"monitoring/prometheus-rules/rules.yaml"}, rt.ruleFiles)
+
+	rendered, err := renderRules(rt.ruleFiles, fakeRender(t, modulePath, rt.ruleFiles))
+	require.NoError(t, err)
+	t.Cleanup(rendered.cleanup)
+
+	errorList := pkgerrors.NewTestErrorsList()
+	runRuleTest(&rt, rendered, errorList)
+
+	return errorList
+}
+
+func TestRuleTestPasses(t *testing.T) {
+	assert.Empty(t, runModule(t, writeModule(t, "warning")).GetErrors())
+}
+
+func TestRuleTestReportsFailedAlerts(t *testing.T) {
+	errs := runModule(t, writeModule(t, "critical")).GetErrors()
+	require.Len(t, errs, 1)
+
+	assert.Equal(t, "target down: alertname: TargetDown, time: 10m", errs[0].Text)
+	assert.Contains(t, errs[0].Expected, `severity="critical"`)
+	assert.Contains(t, errs[0].Got, `severity="warning"`)
+}
+
+func TestRunNotApplicable(t *testing.T) {
+	assert.False(t, New(pkgerrors.NewTestErrorsList(), values.NewGlobalSchemaResolver("", "")).Run(t.TempDir()))
+}
+
+func TestLoadRuleTestMissingRuleFile(t *testing.T) {
+	modulePath := writeModule(t, "warning")
+	path := filepath.Join(modulePath, RulesDir, "rules-tests.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rule_files: [missing-*.yaml]\n"), 0o600))
+
+	_, err := loadRuleTest(modulePath, path)
+	require.ErrorContains(t, err, `no file matches "monitoring/prometheus-rules/missing-*.yaml"`)
+}
+
+func TestBuildTemplate(t *testing.T) {
+	plain := string(buildTemplate("monitoring/prometheus-rules/a.yaml", "cm"))
+	assert.NotContains(t, plain, "tpl $rules")
+	assert.Contains(t, plain, "name: cm")
+
+	assert.Contains(t, string(buildTemplate("monitoring/prometheus-rules/a.tpl", "cm")), "tpl $rules .")
+}
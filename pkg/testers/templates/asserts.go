@@ -0,0 +1,505 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// assertsFileName is the per-case assertions file (optional).
+const assertsFileName = "asserts.yaml"
+
+// assertion is a single entry of asserts.yaml. It selects rendered objects and
+// applies exactly one check to each of them.
+type assertion struct {
+	// Name describes the assertion in failure messages; defaults to its index.
+	Name   string   `json:"name,omitempty"`
+	Select selector `json:"select,omitempty"`
+
+	Equal        *pathValue    `json:"equal,omitempty"`
+	Contains     *pathValue    `json:"contains,omitempty"`
+	MatchRegex   *pathPattern  `json:"matchRegex,omitempty"`
+	Exists       *pathOnly     `json:"exists,omitempty"`
+	NotExists    *pathOnly     `json:"notExists,omitempty"`
+	Count        *int          `json:"count,omitempty"`
+	FailedRender *failedRender `json:"failedRender,omitempty"`
+}
+
+// selector picks rendered objects; empty fields match anything.
+type selector struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type pathOnly struct {
+	Path string `json:"path"`
+}
+
+type pathValue struct {
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+type pathPattern struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+}
+
+type failedRender struct {
+	// ErrorMessage is a substring the render error must contain; empty accepts
+	// any error.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// renderedObject is one rendered manifest together with the file it came from.
+type renderedObject struct {
+	source string
+	obj    map[string]any
+}
+
+func (o renderedObject) kind() string { return stringField(o.obj, "kind") }
+
+func (o renderedObject) name() string { return stringField(o.obj, "metadata", "name") }
+
+func (o renderedObject) namespace() string { return stringField(o.obj, "metadata", "namespace") }
+
+func (o renderedObject) String() string {
+	id := o.name()
+	if ns := o.namespace(); ns != "" {
+		id = ns + "/" + id
+	}
+
+	return fmt.Sprintf("%s %s (%s)", o.kind(), id, o.source)
+}
+
+func (s selector) matches(o renderedObject) bool {
+	return (s.Kind == "" || s.Kind == o.kind()) &&
+		(s.Name == "" || s.Name == o.name()) &&
+		(s.Namespace == "" || s.Namespace == o.namespace())
+}
+
+func (s selector) String() string {
+	var parts []string
+
+	for _, p := range []struct{ key, value string }{{"kind", s.Kind}, {"name", s.Name}, {"namespace", s.Namespace}} {
+		if p.value != "" {
+			parts = append(parts, p.key+"="+p.value)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "all objects"
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// assertFailure is a failed assertion, reported through AddTestResult.
+type assertFailure struct {
+	text, got, expected string
+}
+
+// loadAsserts reads and validates the optional per-case asserts file.
+func loadAsserts(path string) ([]assertion, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read asserts: %w", err)
+	}
+
+	var asserts []assertion
+	if err := yaml.UnmarshalStrict(data, &asserts); err != nil {
+		return nil, fmt.Errorf("parse asserts: %w", err)
+	}
+
+	for i := range asserts {
+		a := &asserts[i]
+		if a.Name == "" {
+			a.Name = "#" + strconv.Itoa(i+1)
+		}
+
+		if err := a.validate(); err != nil {
+			return nil, fmt.Errorf("assert %q: %w", a.Name, err)
+		}
+	}
+
+	return asserts, nil
+}
+
+func (a *assertion) validate() error {
+	checks := 0
+
+	for _, set := range []bool{
+		a.Equal != nil, a.Contains != nil, a.MatchRegex != nil, a.Exists != nil,
+		a.NotExists != nil, a.Count != nil, a.FailedRender != nil,
+	} {
+		if set {
+			checks++
+		}
+	}
+
+	if checks != 1 {
+		return errors.New("must define exactly one of equal, contains, matchRegex, exists, notExists, count, failedRender")
+	}
+
+	var path string
+
+	switch {
+	case a.Equal != nil:
+		path = a.Equal.Path
+	case a.Contains != nil:
+		path = a.Contains.Path
+	case a.Exists != nil:
+		path = a.Exists.Path
+	case a.NotExists != nil:
+		path = a.NotExists.Path
+	case a.MatchRegex != nil:
+		path = a.MatchRegex.Path
+		if _, err := regexp.Compile(a.MatchRegex.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	default:
+		return nil
+	}
+
+	if _, err := parsePath(path); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// expectsFailedRender reports whether any assertion expects the render to fail.
+func expectsFailedRender(asserts []assertion) bool {
+	for i := range asserts {
+		if asserts[i].FailedRender != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRendered splits the rendered files into objects, ordered by source path.
+func parseRendered(files map[string]string) ([]renderedObject, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	var objects []renderedObject
+
+	for _, path := range paths {
+		for _, doc := range splitYAMLDocuments(files[path]) {
+			obj := map[string]any{}
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+				return nil, fmt.Errorf("parse rendered manifest %q: %w", path, err)
+			}
+
+			if len(obj) == 0 {
+				continue
+			}
+
+			objects = append(objects, renderedObject{source: path, obj: obj})
+		}
+	}
+
+	return objects, nil
+}
+
+// evaluateAsserts checks the assertions against the render result. When the
+// render failed, only failedRender assertions are evaluated.
+func evaluateAsserts(asserts []assertion, objects []renderedObject, renderErr error) []assertFailure {
+	var failures []assertFailure
+
+	for i := range asserts {
+		a := &asserts[i]
+
+		if a.FailedRender != nil {
+			failures = append(failures, a.checkFailedRender(renderErr)...)
+			continue
+		}
+
+		if renderErr != nil {
+			continue
+		}
+
+		failures = append(failures, a.check(objects)...)
+	}
+
+	return failures
+}
+
+func (a *assertion) checkFailedRender(renderErr error) []assertFailure {
+	if renderErr == nil {
+		return []assertFailure{{
+			text:     fmt.Sprintf("assert %q: expected render to fail, but it succeeded", a.Name),
+			expected: a.FailedRender.ErrorMessage,
+		}}
+	}
+
+	if !strings.Contains(renderErr.Error(), a.FailedRender.ErrorMessage) {
+		return []assertFailure{{
+			text:     fmt.Sprintf("assert %q: render error does not contain the expected message", a.Name),
+			got:      renderErr.Error(),
+			expected: a.FailedRender.ErrorMessage,
+		}}
+	}
+
+	return nil
+}
+
+func (a *assertion) check(objects []renderedObject) []assertFailure {
+	var selected []renderedObject
+
+	for _, o := range objects {
+		if a.Select.matches(o) {
+			selected = append(selected, o)
+		}
+	}
+
+	if a.Count != nil {
+		if len(selected) != *a.Count {
+			return []assertFailure{{
+				text:     fmt.Sprintf("assert %q: unexpected number of objects matching %s", a.Name, a.Select),
+				got:      strconv.Itoa(len(selected)),
+				expected: strconv.Itoa(*a.Count),
+			}}
+		}
+
+		return nil
+	}
+
+	if len(selected) == 0 {
+		return []assertFailure{{
+			text: fmt.Sprintf("assert %q: no rendered object matches %s", a.Name, a.Select),
+		}}
+	}
+
+	var failures []assertFailure
+
+	for _, o := range selected {
+		if f := a.checkObject(o); f != nil {
+			failures = append(failures, *f)
+		}
+	}
+
+	return failures
+}
+
+// checkObject applies the assertion's path check to a single object.
+func (a *assertion) checkObject(o renderedObject) *assertFailure {
+	fail := func(format string, args ...any) *assertFailure {
+		return &assertFailure{text: fmt.Sprintf("assert %q: %s: ", a.Name, o) + fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case a.Exists != nil:
+		if results := mustFind(a.Exists.Path, o.obj); len(results) == 0 {
+			return fail("%s does not exist", a.Exists.Path)
+		}
+	case a.NotExists != nil:
+		if results := mustFind(a.NotExists.Path, o.obj); len(results) > 0 {
+			f := fail("%s exists", a.NotExists.Path)
+			f.got = toYAML(unwrap(results))
+
+			return f
+		}
+	case a.Equal != nil:
+		got := unwrap(mustFind(a.Equal.Path, o.obj))
+		if !jsonEqual(got, a.Equal.Value) {
+			f := fail("%s is not equal to the expected value", a.Equal.Path)
+			f.got, f.expected = toYAML(got), toYAML(a.Equal.Value)
+
+			return f
+		}
+	case a.Contains != nil:
+		results := mustFind(a.Contains.Path, o.obj)
+		if !containsValue(results, a.Contains.Value) {
+			f := fail("%s does not contain the expected value", a.Contains.Path)
+			f.got, f.expected = toYAML(unwrap(results)), toYAML(a.Contains.Value)
+
+			return f
+		}
+	case a.MatchRegex != nil:
+		re := regexp.MustCompile(a.MatchRegex.Pattern)
+
+		results := mustFind(a.MatchRegex.Path, o.obj)
+		if len(results) == 0 {
+			return fail("%s does not exist", a.MatchRegex.Path)
+		}
+
+		for _, r := range results {
+			s, ok := r.(string)
+			if !ok || !re.MatchString(s) {
+				f := fail("%s does not match %q", a.MatchRegex.Path, a.MatchRegex.Pattern)
+				f.got, f.expected = toYAML(r), a.MatchRegex.Pattern
+
+				return f
+			}
+		}
+	}
+
+	return nil
+}
+
+// parsePath accepts both the kubectl form "{.spec.replicas}" and the bare
+// ".spec.replicas".
+func parsePath(path string) (*jsonpath.JSONPath, error) {
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+
+	jp := jsonpath.New("assert").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	return jp, nil
+}
+
+// mustFind returns the values path resolves to in obj. Paths are validated when
+// the asserts file is loaded; a path that fails on this particular object (e.g.
+// indexing a scalar) resolves to nothing.
+func mustFind(path string, obj map[string]any) []any {
+	jp, err := parsePath(path)
+	if err != nil {
+		return nil
+	}
+
+	results, err := jp.FindResults(obj)
+	if err != nil {
+		return nil
+	}
+
+	var values []any
+
+	for _, group := range results {
+		for _, v := range group {
+			if v.IsValid() && v.CanInterface() {
+				values = append(values, v.Interface())
+			}
+		}
+	}
+
+	return values
+}
+
+// unwrap returns the single result of a path as is, and several results as a
+// list.
+func unwrap(results []any) any {
+	switch len(results) {
+	case 0:
+		return nil
+	case 1:
+		return results[0]
+	default:
+		return results
+	}
+}
+
+// containsValue reports whether the path results contain value: as one of the
+// results, as an element of a list result, or as a substring of a string result.
+func containsValue(results []any, value any) bool {
+	for _, r := range results {
+		if jsonEqual(r, value) {
+			return true
+		}
+
+		switch r := r.(type) {
+		case []any:
+			for _, item := range r {
+				if jsonEqual(item, value) {
+					return true
+				}
+			}
+		case string:
+			if s, ok := value.(string); ok && strings.Contains(r, s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jsonEqual compares two values by their JSON representation, so 2 equals 2.0
+// and key order does not matter.
+func jsonEqual(a, b any) bool {
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+func normalizeJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+
+	return out
+}
+
+func toYAML(v any) string {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+func stringField(obj map[string]any, keys ...string) string {
+	var cur any = obj
+
+	for _, key := range keys {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		cur = m[key]
+	}
+
+	s, _ := cur.(string)
+
+	return s
+}
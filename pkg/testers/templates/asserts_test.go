@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderedDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: d8-test
+  labels:
+    app: controller
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: controller
+        image: registry.example.com/controller:v1.2.3
+        args: ["--leader-elect", "--v=2"]
+      - name: proxy
+        image: registry.example.com/proxy:v0.1.0
+`
+
+const renderedService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: controller
+  namespace: d8-test
+`
+
+func writeAsserts(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), assertsFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestEvaluateAsserts(t *testing.T) {
+	objects, err := parseRendered(map[string]string{
+		"templates/deployment.yaml": renderedDeployment + "---\n" + renderedService,
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	asserts, err := loadAsserts(writeAsserts(t, `
+- select: {kind: Deployment}
+  count: 1
+- select: {namespace: d8-test}
+  count: 2
+- name: replicas
+  select: {kind: Deployment, name: controller}
+  equal: {path: .spec.replicas, value: 2}
+- name: containers
+  select: {kind: Deployment}
+  equal: {path: "{.spec.template.spec.containers[*].name}", value: [controller, proxy]}
+- select: {kind: Deployment}
+  contains: {path: ".spec.template.spec.containers[0].args", value: --leader-elect}
+- select: {kind: Deployment}
+  contains: {path: ".spec.template.spec.containers[*].name", value: proxy}
+- select: {kind: Deployment}
+  matchRegex: {path: ".spec.template.spec.containers[*].image", pattern: ':v\d+\.\d+\.\d+$'}
+- select: {kind: Service}
+  exists: {path: .metadata.namespace}
+- notExists: {path: .spec.hostNetwork}
+`))
+	require.NoError(t, err)
+	assert.Empty(t, evaluateAsserts(asserts, objects, nil))
+
+	asserts, err = loadAsserts(writeAsserts(t, `
+- name: count
+  select: {kind: Deployment}
+  count: 2
+- name: replicas
+  select: {kind: Deployment}
+  equal: {path: .spec.replicas, value: 3}
+- name: missing
+  select: {kind: StatefulSet}
+  exists: {path: .spec}
+- name: label
+  select: {kind: Service}
+  exists: {path: .metadata.labels.app}
+- name: failure
+  failedRender: {}
+`))
+	require.NoError(t, err)
+
+	failures := evaluateAsserts(asserts, objects, nil)
+	require.Len(t, failures, 5)
+	assert.Equal(t, assertFailure{
+		text:     `assert "count": unexpected number of objects matching kind=Deployment`,
+		got:      "1",
+		expected: "2",
+	}, failures[0])
+	assert.Equal(t, "2\n", failures[1].got)
+	assert.Equal(t, "3\n", failures[1].expected)
+	assert.Contains(t, failures[2].text, "no rendered object matches kind=StatefulSet")
+	assert.Contains(t, failures[3].text, "Service d8-test/controller (templates/deployment.yaml)")
+	assert.Contains(t, failures[4].text, "expected render to fail")
+}
+
+func TestEvaluateAssertsFailedRender(t *testing.T) {
+	asserts, err := loadAsserts(writeAsserts(t, `
+- failedRender: {errorMessage: "app.name is required"}
+- select: {kind: Deployment}
+  count: 1
+`))
+	require.NoError(t, err)
+
+	assert.Empty(t, evaluateAsserts(asserts, nil, errors.New("render: app.name is required")),
+		"other assertions are skipped when the render fails")
+
+	failures := evaluateAsserts(asserts, nil, errors.New("render: boom"))
+	require.Len(t, failures, 1)
+	assert.Equal(t, "render: boom", failures[0].got)
+}
+
+func TestLoadAssertsValidation(t *testing.T) {
+	for name, content := range map[string]string{
+		"no check":       `- select: {kind: Deployment}`,
+		"two checks":     `- {count: 1, exists: {path: .spec}}`,
+		"bad path":       `- exists: {path: "{.spec["}`,
+		"bad pattern":    `- matchRegex: {path: .spec, pattern: "("}`,
+		"unknown field":  `- equals: {path: .spec, value: 1}`,
+		"path required":  `- exists: {}`,
+		"not a sequence": `count: 1`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadAsserts(writeAsserts(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	asserts, err := loadAsserts(filepath.Join(t.TempDir(), assertsFileName))
+	require.NoError(t, err)
+	assert.Nil(t, asserts, "the asserts file is optional")
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/manifestdiff"
+)
+
+const (
+	// ignorePathsFileName is the per-case list of fields masked in snapshots (optional).
+	ignorePathsFileName = "ignore-paths.yaml"
+	// ignoredValue replaces the masked fields in the rendered output.
+	ignoredValue = "<ignored>"
+)
+
+// ignorePath is a single entry of ignore-paths.yaml: a field masked in the
+// selected objects.
+type ignorePath struct {
+	Select selector `json:"select,omitempty"`
+	// Path is a field path as reported for non-deterministic renders, e.g.
+	// "data.password", "metadata.annotations[checksum/config.v1]" or
+	// "spec.template.spec.containers[name=app].env[*].value".
+	Path string `json:"path"`
+
+	segments []pathSegment
+}
+
+// pathSegment is a map key, a list index, a list item selected by name, or a
+// wildcard matching every key or item.
+type pathSegment struct {
+	kind  segmentKind
+	key   string
+	index int
+	name  string
+}
+
+type segmentKind int
+
+const (
+	segmentKey segmentKind = iota
+	segmentIndex
+	segmentName
+	segmentAny
+)
+
+// loadIgnorePaths reads and validates the optional per-case ignore-paths file.
+func loadIgnorePaths(path string) ([]ignorePath, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read ignore paths: %w", err)
+	}
+
+	var ignores []ignorePath
+	if err := yaml.UnmarshalStrict(data, &ignores); err != nil {
+		return nil, fmt.Errorf("parse ignore paths: %w", err)
+	}
+
+	for i := range ignores {
+		ignores[i].segments, err = parseFieldPath(ignores[i].Path)
+		if err != nil {
+			return nil, fmt.Errorf("ignore path #%d: %w", i+1, err)
+		}
+	}
+
+	return ignores, nil
+}
+
+// parseFieldPath parses the dotted field path format of manifestdiff: keys
+// separated by dots, bracketed keys containing dots, list indexes "[0]", named
+// list items "[name=app]" and wildcards "*" or "[*]".
+func parseFieldPath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+
+	var segments []pathSegment
+
+	for rest := strings.TrimPrefix(path, "."); rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed '['", path)
+			}
+
+			segments = append(segments, bracketSegment(rest[1:end]))
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			if key == "*" {
+				segments = append(segments, pathSegment{kind: segmentAny})
+			} else {
+				segments = append(segments, pathSegment{kind: segmentKey, key: key})
+			}
+
+			rest = rest[end:]
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+
+	return segments, nil
+}
+
+func bracketSegment(content string) pathSegment {
+	if content == "*" {
+		return pathSegment{kind: segmentAny}
+	}
+
+	if name, ok := strings.CutPrefix(content, "name="); ok {
+		return pathSegment{kind: segmentName, name: name}
+	}
+
+	if idx, err := strconv.Atoi(content); err == nil {
+		return pathSegment{kind: segmentIndex, index: idx}
+	}
+
+	return pathSegment{kind: segmentKey, key: content}
+}
+
+// applyIgnorePaths masks the ignored fields of an object in place.
+func applyIgnorePaths(o renderedObject, ignores []ignorePath) {
+	for i := range ignores {
+		if ignores[i].Select.matches(o) {
+			maskField(o.obj, ignores[i].segments)
+		}
+	}
+}
+
+// maskField replaces the values at the path with ignoredValue. Missing fields
+// are not added, so masking does not hide a field that stopped rendering.
+func maskField(value any, segments []pathSegment) {
+	if len(segments) == 0 {
+		return
+	}
+
+	seg, last := segments[0], len(segments) == 1
+
+	visit := func(get func() any, set func(any)) {
+		if last {
+			set(ignoredValue)
+			return
+		}
+
+		maskField(get(), segments[1:])
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for key := range typed {
+			if seg.kind == segmentAny || (seg.kind == segmentKey && seg.key == key) {
+				visit(func() any { return typed[key] }, func(v any) { typed[key] = v })
+			}
+		}
+	case []any:
+		for idx, item := range typed {
+			if seg.matchesItem(idx, item) {
+				visit(func() any { return typed[idx] }, func(v any) { typed[idx] = v })
+			}
+		}
+	}
+}
+
+func (s pathSegment) matchesItem(idx int, item any) bool {
+	switch s.kind {
+	case segmentAny:
+		return true
+	case segmentIndex:
+		return s.index == idx
+	case segmentName:
+		obj, ok := item.(map[string]any)
+		return ok && obj["name"] == s.name
+	default:
+		return false
+	}
+}
+
+// nondeterministicFields compares two renders of the same case and returns the
+// differing fields as "<object>: <path>", and the objects present in only one
+// of them as "<object>".
+func nondeterministicFields(first, second string) ([]string, error) {
+	firstObjects, err := manifestdiff.ParseStream(first)
+	if err != nil {
+		return nil, fmt.Errorf("parse first render: %w", err)
+	}
+
+	secondObjects, err := manifestdiff.ParseStream(second)
+	if err != nil {
+		return nil, fmt.Errorf("parse second render: %w", err)
+	}
+
+	res := manifestdiff.Compare(firstObjects, secondObjects)
+
+	var fields []string
+
+	for _, objects := range [][]manifestdiff.Object{res.Added, res.Removed} {
+		for i := range objects {
+			fields = append(fields, fmt.Sprintf("%s (%s)", objects[i].ID(), objects[i].Source))
+		}
+	}
+
+	for i := range res.Changed {
+		diff := &res.Changed[i]
+		if len(diff.Changes) == 0 {
+			fields = append(fields, fmt.Sprintf("%s: source %s, then %s", diff.New.ID(), diff.Old.Source, diff.New.Source))
+		}
+
+		for _, c := range diff.Changes {
+			fields = append(fields, fmt.Sprintf("%s: %s", diff.New.ID(), c.Path))
+		}
+	}
+
+	// The same text with no object-level changes: document order differs.
+	if len(fields) == 0 {
+		fields = append(fields, "document order")
+	}
+
+	return fields, nil
+}
+
+// missingTemplates returns the module templates the snapshot was rendered from
+// that no longer exist.
+func missingTemplates(modulePath, snapshot string) []string {
+	objects, err := manifestdiff.ParseStream(snapshot)
+	if err != nil {
+		return nil
+	}
+
+	missing := map[string]struct{}{}
+
+	for i := range objects {
+		source := objects[i].Source
+		if !strings.HasPrefix(source, "templates/") {
+			// Library chart templates and unlabelled documents.
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(modulePath, filepath.FromSlash(source))); os.IsNotExist(err) {
+			missing[source] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(missing))
+	for source := range missing {
+		result = append(result, source)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// isEmptyCase reports whether the case directory has nothing to test with.
+func isEmptyCase(c testCase) bool {
+	for _, path := range []string{c.valuesPath, c.snapshotPath, c.assertsPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
+)
+
+const secretTemplate = `apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+  annotations:
+    checksum/config: abc
+data:
+  password: cmFuZG9t
+  user: YWRtaW4=
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          env:
+            - name: SEED
+              value: "1"
+            - name: MODE
+              value: fast
+        - name: sidecar
+          env:
+            - name: SEED
+              value: "2"
+`
+
+func TestParseFieldPath(t *testing.T) {
+	segments, err := parseFieldPath("spec.containers[name=app].env[*].value")
+	require.NoError(t, err)
+	assert.Equal(t, []pathSegment{
+		{kind: segmentKey, key: "spec"},
+		{kind: segmentKey, key: "containers"},
+		{kind: segmentName, name: "app"},
+		{kind: segmentKey, key: "env"},
+		{kind: segmentAny},
+		{kind: segmentKey, key: "value"},
+	}, segments)
+
+	segments, err = parseFieldPath(".metadata.annotations[checksum/config.v1].items[0]")
+	require.NoError(t, err)
+	assert.Equal(t, []pathSegment{
+		{kind: segmentKey, key: "metadata"},
+		{kind: segmentKey, key: "annotations"},
+		{kind: segmentKey, key: "checksum/config.v1"},
+		{kind: segmentKey, key: "items"},
+		{kind: segmentIndex, index: 0},
+	}, segments)
+
+	_, err = parseFieldPath("data[password")
+	require.Error(t, err)
+
+	_, err = parseFieldPath("")
+	require.Error(t, err)
+}
+
+func TestNormalizeManifestsMasksIgnoredPaths(t *testing.T) {
+	dir := t.TempDir()
+	ignoresPath := filepath.Join(dir, ignorePathsFileName)
+	require.NoError(t, os.WriteFile(ignoresPath, []byte(`
+- select:
+    kind: Secret
+  path: data.password
+- path: metadata.annotations.checksum/config
+- select:
+    kind: Deployment
+  path: spec.template.spec.containers[name=app].env[*].value
+- path: data.missing
+`), 0o600))
+
+	ignores, err := loadIgnorePaths(ignoresPath)
+	require.NoError(t, err)
+
+	rendered, err := normalizeManifests(map[string]string{"templates/app.yaml": secretTemplate}, ignores)
+	require.NoError(t, err)
+
+	assert.Contains(t, rendered, "password: <ignored>\n")
+	assert.Contains(t, rendered, "user: YWRtaW4=\n", "fields not listed are kept")
+	assert.Contains(t, rendered, "checksum/config: <ignored>\n")
+	assert.Contains(t, rendered, "- name: SEED\n          value: <ignored>\n")
+	assert.Contains(t, rendered, "- name: MODE\n          value: <ignored>\n")
+	assert.Contains(t, rendered, "- name: SEED\n          value: \"2\"\n", "other containers are kept")
+	assert.NotContains(t, rendered, "missing", "missing fields are not added")
+}
+
+func TestNondeterministicFields(t *testing.T) {
+	first, err := normalizeManifests(map[string]string{"templates/app.yaml": secretTemplate}, nil)
+	require.NoError(t, err)
+
+	changed := map[string]string{"templates/app.yaml": secretTemplate[:len("apiVersion: v1\nkind: Secret\nmetadata:\n  name: creds\n")] +
+		"  annotations:\n    checksum/config: def\ndata:\n  password: b3RoZXI=\n  user: YWRtaW4=\n"}
+
+	second, err := normalizeManifests(changed, nil)
+	require.NoError(t, err)
+
+	fields, err := nondeterministicFields(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Deployment app (templates/app.yaml)",
+		"Secret creds: data.password",
+		"Secret creds: metadata.annotations.checksum/config",
+	}, fields)
+}
+
+func TestMissingTemplates(t *testing.T) {
+	const snapshot = `---
+# Source: templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+# Source: templates/removed.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+---
+# Source: charts/helm_lib/templates/lib.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+`
+
+	assert.Equal(t, []string{"templates/removed.yaml"}, missingTemplates(modulePath, snapshot))
+}
+
+func TestTemplatesTesterReportsEmptyCase(t *testing.T) {
+	module := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(module, TestsDirName, "empty"), 0o755))
+
+	errorList := pkgerrors.NewTestErrorsList()
+	require.True(t, New(errorList, false).Run(module))
+
+	errs := errorList.GetErrors()
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Text, `testcase "empty": case directory has no values.yaml, expected.yaml or asserts.yaml`)
+}
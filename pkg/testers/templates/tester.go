@@ -16,7 +16,8 @@ limitations under the License.
 
 // Package templates implements the "templates" tester. It renders a module's
 // chart with user-supplied values and compares the result against committed
-// golden snapshots, in the spirit of deckhouse's testing/helm harness.
+// golden snapshots, in the spirit of deckhouse's testing/helm harness, and
+// checks per-case assertions on the rendered objects.
 package templates
 
 import (
@@ -28,6 +29,7 @@ import (
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/deckhouse/dmt/internal/manifestdiff"
 	"github.com/deckhouse/dmt/internal/modules"
 	pkgerrors "github.com/deckhouse/dmt/pkg/errors"
 )
@@ -85,17 +87,21 @@ func (t *Tester) Run(modulePath string) bool {
 	errorList := t.ErrorList.WithModule(moduleName)
 
 	for _, c := range cases {
-		t.runCase(modulePath, c, errorList)
+		errorList.RunCase(c.name, func(caseErrors *pkgerrors.TestErrorsList) {
+			t.runCase(modulePath, c, caseErrors)
+		})
 	}
 
 	return true
 }
 
 type testCase struct {
-	name         string
-	dir          string
-	valuesPath   string
-	snapshotPath string
+	name            string
+	dir             string
+	valuesPath      string
+	snapshotPath    string
+	assertsPath     string
+	ignorePathsPath string
 }
 
 // discoverCases returns the test cases under testsDir. A case is any direct
@@ -119,10 +125,12 @@ func discoverCases(testsDir string) ([]testCase, error) {
 
 		dir := filepath.Join(testsDir, entry.Name())
 		cases = append(cases, testCase{
-			name:         entry.Name(),
-			dir:          dir,
-			valuesPath:   filepath.Join(dir, valuesFileName),
-			snapshotPath: filepath.Join(dir, snapshotFileName),
+			name:            entry.Name(),
+			dir:             dir,
+			valuesPath:      filepath.Join(dir, valuesFileName),
+			snapshotPath:    filepath.Join(dir, snapshotFileName),
+			assertsPath:     filepath.Join(dir, assertsFileName),
+			ignorePathsPath: filepath.Join(dir, ignorePathsFileName),
 		})
 	}
 
@@ -131,8 +139,14 @@ func discoverCases(testsDir string) ([]testCase, error) {
 	return cases, nil
 }
 
+// runCase runs a single test case; errorList is scoped to the case.
 func (t *Tester) runCase(modulePath string, c testCase, errorList *pkgerrors.TestErrorsList) {
-	errorList = errorList.WithTestName(c.name)
+	if isEmptyCase(c) {
+		errorList.Errorf("testcase %q: case directory has no %s, %s or %s; remove it or add an empty %s to test the default values",
+			c.name, valuesFileName, snapshotFileName, assertsFileName, valuesFileName)
+
+		return
+	}
 
 	userValues, err := loadValues(c.valuesPath)
 	if err != nil {
@@ -140,18 +154,49 @@ func (t *Tester) runCase(modulePath string, c testCase, errorList *pkgerrors.Tes
 		return
 	}
 
-	files, err := modules.RenderModuleWithValues(modulePath, userValues)
+	asserts, err := loadAsserts(c.assertsPath)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", c.name, err.Error())
+		return
+	}
+
+	ignores, err := loadIgnorePaths(c.ignorePathsPath)
 	if err != nil {
-		errorList.Errorf("testcase %q: render failed: %s", c.name, err.Error())
+		errorList.Errorf("testcase %q: %s", c.name, err.Error())
 		return
 	}
 
-	rendered, err := normalizeManifests(files)
+	files, renderErr := modules.RenderModuleWithValues(modulePath, userValues)
+	if renderErr != nil && !expectsFailedRender(asserts) {
+		errorList.Errorf("testcase %q: render failed: %s", c.name, renderErr.Error())
+		return
+	}
+
+	if len(asserts) > 0 {
+		runAsserts(c, asserts, files, renderErr, errorList)
+	}
+
+	if renderErr != nil {
+		return
+	}
+
+	// A case with assertions only needs a snapshot when it already has one.
+	if len(asserts) > 0 {
+		if _, err := os.Stat(c.snapshotPath); os.IsNotExist(err) {
+			return
+		}
+	}
+
+	rendered, err := normalizeManifests(files, ignores)
 	if err != nil {
 		errorList.Errorf("testcase %q: %s", c.name, err.Error())
 		return
 	}
 
+	if !checkDeterministic(modulePath, c, ignores, rendered, errorList) {
+		return
+	}
+
 	if t.update {
 		if err := os.WriteFile(c.snapshotPath, []byte(rendered), 0o644); err != nil {
 			errorList.Errorf("testcase %q: write snapshot: %s", c.name, err.Error())
@@ -177,12 +222,124 @@ func (t *Tester) runCase(modulePath string, c testCase, errorList *pkgerrors.Tes
 		return
 	}
 
-	if string(expected) != rendered {
+	if string(expected) == rendered {
+		return
+	}
+
+	for _, source := range missingTemplates(modulePath, string(expected)) {
+		errorList.Errorf("testcase %q: snapshot references template %q that no longer exists (run with --update to refresh it)", c.name, source)
+	}
+
+	summary, diff, err := snapshotDiff(string(expected), rendered)
+	if err != nil {
+		// The snapshot cannot be compared object-wise (e.g. it was edited by
+		// hand into invalid YAML): show both sides in full.
 		errorList.AddTestResult(
 			fmt.Sprintf("testcase %q: rendered output does not match snapshot", c.name),
 			rendered,
 			string(expected),
 		)
+
+		return
+	}
+
+	errorList.AddTestDiff(
+		fmt.Sprintf("testcase %q: rendered output does not match snapshot (%s)", c.name, summary),
+		diff,
+	)
+}
+
+// checkDeterministic renders the case a second time and reports the fields
+// that differ from the first render, e.g. generated passwords or timestamps.
+// Returns false when the output is not deterministic.
+func checkDeterministic(modulePath string, c testCase, ignores []ignorePath, rendered string, errorList *pkgerrors.TestErrorsList) bool {
+	userValues, err := loadValues(c.valuesPath)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", c.name, err.Error())
+		return false
+	}
+
+	files, err := modules.RenderModuleWithValues(modulePath, userValues)
+	if err != nil {
+		errorList.Errorf("testcase %q: second render failed: %s", c.name, err.Error())
+		return false
+	}
+
+	again, err := normalizeManifests(files, ignores)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", c.name, err.Error())
+		return false
+	}
+
+	if again == rendered {
+		return true
+	}
+
+	fields, err := nondeterministicFields(rendered, again)
+	if err != nil {
+		errorList.Errorf("testcase %q: %s", c.name, err.Error())
+		return false
+	}
+
+	errorList.AddTestOutput(
+		fmt.Sprintf("testcase %q: rendered output differs between renders; mask these fields in %s", c.name, ignorePathsFileName),
+		strings.Join(fields, "\n"),
+	)
+
+	return false
+}
+
+// snapshotDiff describes how the rendered output differs from the snapshot: a
+// one-line summary and a unified YAML diff of every added, removed or changed
+// object. Documents are matched by kind, namespace and name, so reordered
+// templates do not show up as changes.
+func snapshotDiff(expected, rendered string) (string, string, error) {
+	expectedObjects, err := manifestdiff.ParseStream(expected)
+	if err != nil {
+		return "", "", fmt.Errorf("parse snapshot: %w", err)
+	}
+
+	renderedObjects, err := manifestdiff.ParseStream(rendered)
+	if err != nil {
+		return "", "", fmt.Errorf("parse rendered output: %w", err)
+	}
+
+	res := manifestdiff.Compare(expectedObjects, renderedObjects)
+
+	// Same objects, different text: comments, document order or formatting.
+	if res.Empty() {
+		diff, err := manifestdiff.UnifiedText(expected, rendered, snapshotFileName, "rendered")
+		if err != nil {
+			return "", "", err
+		}
+
+		return "objects are equal, formatting differs", diff, nil
+	}
+
+	var sb strings.Builder
+	if err := manifestdiff.WriteUnified(&sb, &res, "expected", "rendered"); err != nil {
+		return "", "", err
+	}
+
+	return res.Summary(), sb.String(), nil
+}
+
+// runAsserts reports every failed assertion of the case.
+func runAsserts(c testCase, asserts []assertion, files map[string]string, renderErr error, errorList *pkgerrors.TestErrorsList) {
+	var objects []renderedObject
+
+	if renderErr == nil {
+		var err error
+
+		objects, err = parseRendered(files)
+		if err != nil {
+			errorList.Errorf("testcase %q: %s", c.name, err.Error())
+			return
+		}
+	}
+
+	for _, f := range evaluateAsserts(asserts, objects, renderErr) {
+		errorList.AddTestResult(fmt.Sprintf("testcase %q: %s", c.name, f.text), f.got, f.expected)
 	}
 }
 
@@ -207,8 +364,8 @@ func loadValues(path string) (map[string]any, error) {
 
 // normalizeManifests renders the file->manifests map into a deterministic,
 // canonical YAML stream: files sorted by path, each document re-marshalled with
-// sorted keys and prefixed with its source path.
-func normalizeManifests(files map[string]string) (string, error) {
+// sorted keys, its ignored fields masked, and prefixed with its source path.
+func normalizeManifests(files map[string]string, ignores []ignorePath) (string, error) {
 	paths := make([]string, 0, len(files))
 	for path := range files {
 		paths = append(paths, path)
@@ -229,6 +386,10 @@ func normalizeManifests(files map[string]string) (string, error) {
 				continue
 			}
 
+			if m, ok := obj.(map[string]any); ok {
+				applyIgnorePaths(renderedObject{source: path, obj: m}, ignores)
+			}
+
 			canonical, err := yaml.Marshal(obj)
 			if err != nil {
 				return "", fmt.Errorf("marshal rendered manifest %q: %w", path, err)
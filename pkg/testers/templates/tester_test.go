@@ -17,6 +17,9 @@ limitations under the License.
 package templates
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,3 +50,87 @@ func TestTemplatesTesterDetectsMismatch(t *testing.T) {
 	assert.False(t, applicable)
 	assert.Empty(t, errorList.GetErrors())
 }
+
+func TestTemplatesTesterFailedRender(t *testing.T) {
+	module := t.TempDir()
+
+	writeFile := func(rel, content string) {
+		path := filepath.Join(module, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	writeFile("module.yaml", "name: failing\nnamespace: d8-failing\n")
+	writeFile("templates/configmap.yaml", `{{- if .Values.app.broken }}
+{{- fail "app.broken is not supported" }}
+{{- end }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ok
+`)
+	writeFile(TestsDirName+"/expected-failure/values.yaml", "app:\n  broken: true\n")
+	writeFile(TestsDirName+"/expected-failure/asserts.yaml", `- name: broken is rejected
+  failedRender:
+    errorMessage: app.broken is not supported
+`)
+	writeFile(TestsDirName+"/unexpected-failure/values.yaml", "app:\n  broken: true\n")
+	writeFile(TestsDirName+"/unexpected-failure/asserts.yaml", `- name: one configmap
+  select:
+    kind: ConfigMap
+  count: 1
+`)
+	writeFile(TestsDirName+"/no-failure/values.yaml", "app:\n  broken: false\n")
+	writeFile(TestsDirName+"/no-failure/asserts.yaml", `- name: broken is rejected
+  failedRender:
+    errorMessage: app.broken is not supported
+`)
+
+	errorList := pkgerrors.NewTestErrorsList()
+	require.True(t, New(errorList, false).Run(module))
+
+	var texts []string
+	for _, e := range errorList.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+
+	require.Len(t, texts, 2, "only the cases whose render outcome contradicts them fail: %v", texts)
+	assert.Contains(t, texts[0]+texts[1], `testcase "no-failure"`)
+	assert.Contains(t, texts[0]+texts[1], `testcase "unexpected-failure": render failed`)
+	assert.Contains(t, texts[0]+texts[1], "app.broken is not supported")
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	const snapshot = `---
+# Source: templates/a.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  labels:
+    app: demo
+  name: a
+---
+# Source: templates/b.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`
+
+	rendered := strings.Replace(snapshot, "app: demo", "app: renamed", 1)
+
+	summary, diff, err := snapshotDiff(snapshot, rendered)
+	require.NoError(t, err)
+	assert.Equal(t, "0 added, 0 removed, 1 changed, 1 unchanged", summary)
+	assert.Contains(t, diff, "--- expected: ConfigMap a (templates/a.yaml)\n")
+	assert.Contains(t, diff, "-    app: demo\n+    app: renamed\n")
+	assert.NotContains(t, diff, "name: b", "unchanged objects are not shown")
+
+	summary, diff, err = snapshotDiff(snapshot, snapshot+"# trailing comment\n")
+	require.NoError(t, err)
+	assert.Equal(t, "objects are equal, formatting differs", summary)
+	assert.Contains(t, diff, "+# trailing comment")
+
+	_, _, err = snapshotDiff("kind: [", snapshot)
+	require.Error(t, err)
+}
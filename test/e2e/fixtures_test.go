@@ -0,0 +1,61 @@
+/*
+Copyright 2026 Flant JSC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/fixtures"
+	"github.com/deckhouse/dmt/test/e2e"
+)
+
+// TestE2EFixtures runs the generated failing and passing case of every rule in
+// the fixtures catalog, so each of them has a positive and a negative e2e case
+// without committed testdata, e.g. TestE2EFixtures/templates/vpa/vpa-fail.
+func TestE2EFixtures(t *testing.T) {
+	for _, f := range fixtures.All() {
+		t.Run(f.ID(), func(t *testing.T) {
+			t.Parallel()
+
+			cases, err := f.Cases()
+			require.NoError(t, err)
+
+			for i := range cases {
+				c := &cases[i]
+
+				t.Run(c.Name, func(t *testing.T) {
+					t.Parallel()
+
+					dir := filepath.Join(t.TempDir(), c.Name)
+					require.NoError(t, fixtures.WriteCase(dir, c))
+
+					spec, err := e2e.LoadCaseSpec(dir)
+					require.NoError(t, err)
+
+					findings, err := e2e.Run(spec.Kind, filepath.Join(dir, spec.Module))
+					require.NoError(t, err)
+
+					result := e2e.Match(spec, findings)
+					require.True(t, result.OK(), "expectations not met for %s:\n  %v", c.Name, result.Failures)
+				})
+			}
+		})
+	}
+}
@@ -68,11 +68,11 @@ const (
 //   - textContains is a case-sensitive substring match against the message.
 //   - count is the expected number of matching findings; 0 means "at least one".
 type Finding struct {
-	Linter       string `yaml:"linter"`
-	Rule         string `yaml:"rule"`
-	Level        string `yaml:"level"`
-	TextContains string `yaml:"textContains"`
-	Count        int    `yaml:"count"`
+	Linter       string `yaml:"linter,omitempty"`
+	Rule         string `yaml:"rule,omitempty"`
+	Level        string `yaml:"level,omitempty"`
+	TextContains string `yaml:"textContains,omitempty"`
+	Count        int    `yaml:"count,omitempty"`
 }
 
 func (f Finding) String() string {
@@ -95,30 +95,30 @@ func (f Finding) String() string {
 // CaseSpec is the schema of an expected.yaml file.
 type CaseSpec struct {
 	// Description is a human-readable summary of what the case verifies.
-	Description string `yaml:"description"`
+	Description string `yaml:"description,omitempty"`
 	// Skip, when true, causes the test case to be skipped (t.Skip).
-	Skip bool `yaml:"skip"`
+	Skip bool `yaml:"skip,omitempty"`
 	// Kind selects what to run against the module: "lint" (default) runs the
 	// full lint pipeline, "conversions" runs the `dmt test conversions` testers.
 	// For conversions cases, findings are exposed with linter ID "conversions"
 	// and ObjectID set to the test name, so the same expectations apply.
-	Kind string `yaml:"kind"`
+	Kind string `yaml:"kind,omitempty"`
 	// Module is the subdirectory (relative to the case dir) that gets linted.
 	// Defaults to "module".
-	Module string `yaml:"module"`
+	Module string `yaml:"module,omitempty"`
 	// ExpectClean asserts that the lint produced zero findings.
-	ExpectClean bool `yaml:"expectClean"`
+	ExpectClean bool `yaml:"expectClean,omitempty"`
 	// Expect lists the findings that must be present.
-	Expect []Finding `yaml:"expect"`
+	Expect []Finding `yaml:"expect,omitempty"`
 	// ExpectAbsent lists findings that must NOT be present. Each entry uses the
 	// same matching semantics as Expect (linter required; rule/level/textContains
 	// optional); the case fails if any produced finding matches.
-	ExpectAbsent []Finding `yaml:"expectAbsent"`
+	ExpectAbsent []Finding `yaml:"expectAbsent,omitempty"`
 	// ExpectPass lists rules that must not produce any matching findings.
-	ExpectPass []Finding `yaml:"expectPass"`
+	ExpectPass []Finding `yaml:"expectPass,omitempty"`
 	// Exhaustive, when true, asserts that there are no findings beyond those
 	// listed in Expect (every produced finding must be matched by some Finding).
-	Exhaustive bool `yaml:"exhaustive"`
+	Exhaustive bool `yaml:"exhaustive,omitempty"`
 }
 
 // LoadCaseSpec reads and parses the expected.yaml file from a case directory.